@@ -0,0 +1,125 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func newApplyConfigCmd() *cobra.Command {
+	dryRun, plan, printSchema := false, false, false
+	cmd := &cobra.Command{
+		Use:   "apply-config [terraformer.yaml]",
+		Short: "Import the providers described by a config file",
+		Long: "Import the providers described by a config file. Settings are named after the flags of " +
+			"terraformer import <provider> and are validated against the schema printed by --schema.",
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if printSchema {
+				_, err := os.Stdout.Write(terraformutils.ImportConfigSchema)
+				return err
+			}
+			if len(args) == 0 {
+				return errors.New("apply-config requires a config file")
+			}
+			config, err := terraformutils.ReadImportConfig(args[0])
+			if err != nil {
+				return err
+			}
+			commands := make([]*cobra.Command, 0, len(config.Imports))
+			for i, providerImport := range config.Imports {
+				// fail before the first import when any import is invalid
+				command, err := newConfigImportCmd(providerImport, ImportOptions{Plan: plan})
+				if err != nil {
+					return fmt.Errorf("%s: imports/%d: %w", args[0], i, err)
+				}
+				commands = append(commands, command)
+			}
+			for i, command := range commands {
+				providerImport := config.Imports[i]
+				if dryRun {
+					fmt.Println("terraformer import " + providerImport.Provider + " " + strings.Join(quoteArgs(providerImport.Args()), " "))
+					continue
+				}
+				log.Printf("apply-config: import %d/%d %s", i+1, len(commands), providerImport.Provider)
+				if err := command.ExecuteContext(cmd.Context()); err != nil {
+					return fmt.Errorf("%s: imports/%d %s: %w", args[0], i, providerImport.Provider, err)
+				}
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "", false, "validate the config and print the equivalent import commands")
+	cmd.Flags().BoolVarP(&plan, "plan", "", false, "write plan files instead of importing, like terraformer plan")
+	cmd.Flags().BoolVarP(&printSchema, "schema", "", false, "print the JSON schema of the config file")
+	return cmd
+}
+
+// newConfigImportCmd returns the provider command of an import. Its settings map onto ImportOptions through the
+// flags of the command, so that they get the defaults and validation of the command line.
+func newConfigImportCmd(providerImport terraformutils.ProviderImport, options ImportOptions) (*cobra.Command, error) {
+	for _, subcommand := range providerImporterSubcommands {
+		if subcommand(options).Name() != providerImport.Provider {
+			continue
+		}
+		args := providerImport.Args()
+		// flags are parsed by a separate command, parsing list flags twice appends their values again
+		if err := validateConfigImportFlags(subcommand(options), args); err != nil {
+			return nil, err
+		}
+		command := subcommand(options)
+		command.SetArgs(args)
+		command.SilenceUsage = true
+		command.SilenceErrors = true
+		return command, nil
+	}
+	return nil, fmt.Errorf("unsupported provider: %s", providerImport.Provider)
+}
+
+func validateConfigImportFlags(command *cobra.Command, args []string) error {
+	if err := command.ParseFlags(args); err != nil {
+		return err
+	}
+	missing := []string{}
+	command.Flags().VisitAll(func(flag *pflag.Flag) {
+		if required, ok := flag.Annotations[cobra.BashCompOneRequiredFlag]; ok && required[0] == "true" && !flag.Changed {
+			missing = append(missing, flag.Name)
+		}
+	})
+	if len(missing) > 0 {
+		return fmt.Errorf(`required settings "%s" not set`, strings.Join(missing, `", "`))
+	}
+	return nil
+}
+
+// quoteArgs quotes the args which a shell would split or expand
+func quoteArgs(args []string) []string {
+	quoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		if strings.ContainsAny(arg, " \t\"'*?$&|;<>()[]{}!\\`") {
+			arg = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+		quoted = append(quoted, arg)
+	}
+	return quoted
+}
@@ -14,51 +14,23 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
-	"log"
-	"os"
-	"sort"
-	"strings"
-	"sync"
-
-	"github.com/GoogleCloudPlatform/terraformer/terraformutils/terraformerstring"
-
-	"github.com/GoogleCloudPlatform/terraformer/terraformutils/providerwrapper"
-
-	"github.com/spf13/pflag"
 
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
-	"github.com/GoogleCloudPlatform/terraformer/terraformutils/terraformoutput"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/importpipeline"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
-type ImportOptions struct {
-	Resources     []string
-	Excludes      []string
-	PathPattern   string
-	PathOutput    string
-	State         string
-	Bucket        string
-	Profile       string
-	Verbose       bool
-	Zone          string
-	Regions       []string
-	Projects      []string
-	ResourceGroup string
-	Connect       bool
-	Compact       bool
-	Filter        []string
-	Plan          bool `json:"-"`
-	Output        string
-	NoSort        bool
-	RetryCount    int
-	RetrySleepMs  int
-}
+// ImportOptions are the flags of terraformer import <provider>, see importpipeline.ImportOptions
+type ImportOptions = importpipeline.ImportOptions
 
-const DefaultPathPattern = "{output}/{provider}/{service}/"
-const DefaultPathOutput = "generated"
-const DefaultState = "local"
+const DefaultPathPattern = importpipeline.DefaultPathPattern
+const DefaultPathOutput = importpipeline.DefaultPathOutput
+const DefaultState = importpipeline.DefaultState
+const ImportBlocksState = importpipeline.ImportBlocksState
 
 var providerImporterSubcommands []func(options ImportOptions) *cobra.Command
 
@@ -87,315 +59,26 @@ func newImportCmd() *cobra.Command {
 	return cmd
 }
 
+// Import imports the resources of a provider scope without cancellation, see importpipeline.ImportContext
 func Import(provider terraformutils.ProviderGenerator, options ImportOptions, args []string) error {
-
-	providerWrapper, options, err := initOptionsAndWrapper(provider, options, args)
-	if err != nil {
-		return err
-	}
-	defer providerWrapper.Kill()
-	providerMapping := terraformutils.NewProvidersMapping(provider)
-
-	err = initAllServicesResources(providerMapping, options, args, providerWrapper)
-	if err != nil {
-		return err
-	}
-
-	err = terraformutils.RefreshResourcesByProvider(providerMapping, providerWrapper)
-	if err != nil {
-		return err
-	}
-
-	providerMapping.ConvertTFStates(providerWrapper)
-	// change structs with additional data for each resource
-	providerMapping.CleanupProviders()
-
-	err = importFromPlan(providerMapping, options, args)
-	if err != nil {
-		return err
-	}
-
-	log.Println("Performing cleanup of stale directories based on path pattern...")
-
-	// We iterate over the original list of services the user requested for this run.
-	for _, serviceName := range options.Resources {
-		resourcesForService := providerMapping.GetResourcesByService()[serviceName]
-
-		// If a requested service resulted in zero resources...
-		if len(resourcesForService) == 0 {
-			// ...we build its exact expected path using the SAME logic Terraformer uses.
-			servicePath := Path(options.PathPattern, provider.GetName(), serviceName, options.PathOutput)
-
-			// Check if that path actually exists on disk.
-			if _, err := os.Stat(servicePath); !os.IsNotExist(err) {
-				// It exists but is now empty, so remove it.
-				log.Printf("Removing stale directory for service '%s': %s", serviceName, servicePath)
-				if err := os.RemoveAll(servicePath); err != nil {
-					log.Printf("! Failed to remove stale directory %s: %v", servicePath, err)
-				}
-			}
-		}
-	}
-	log.Println("Cleanup complete. ✅")
-
-	return nil
+	return importpipeline.Import(provider, options, args)
 }
 
-func initOptionsAndWrapper(provider terraformutils.ProviderGenerator, options ImportOptions, args []string) (*providerwrapper.ProviderWrapper, ImportOptions, error) {
-	err := provider.Init(args)
-	if err != nil {
-		return nil, options, err
-	}
-
-	if terraformerstring.ContainsString(options.Resources, "*") {
-		log.Println("Attempting an import of ALL resources in " + provider.GetName())
-		options.Resources = providerServices(provider)
-	}
-
-	if len(options.Excludes) > 0 {
-		localSlice := []string{}
-		for _, r := range options.Resources {
-			remove := false
-			for _, e := range options.Excludes {
-				if r == e {
-					remove = true
-					log.Println("Excluding resource " + e)
-				}
-			}
-			if !remove {
-				localSlice = append(localSlice, r)
-			}
-		}
-		options.Resources = localSlice
-	}
-
-	providerWrapper, err := providerwrapper.NewProviderWrapper(provider.GetName(), provider.GetConfig(), options.Verbose, map[string]int{"retryCount": options.RetryCount, "retrySleepMs": options.RetrySleepMs})
-	if err != nil {
-		return nil, options, err
-	}
-
-	return providerWrapper, options, nil
+// ImportContext imports the resources of a provider scope, see importpipeline.ImportContext
+func ImportContext(ctx context.Context, provider terraformutils.ProviderGenerator, options ImportOptions, args []string) error {
+	return importpipeline.ImportContext(ctx, provider, options, args)
 }
 
-func initAllServicesResources(providersMapping *terraformutils.ProvidersMapping, options ImportOptions, args []string, providerWrapper *providerwrapper.ProviderWrapper) error {
-	numOfResources := len(options.Resources)
-	var wg sync.WaitGroup
-	wg.Add(numOfResources)
-
-	var failedServices []string
-
-	for _, service := range options.Resources {
-		serviceProvider := providersMapping.AddServiceToProvider(service)
-		err := serviceProvider.Init(args)
-		if err != nil {
-			return err
-		}
-		err = initServiceResources(service, serviceProvider, options, providerWrapper)
-		if err != nil {
-			failedServices = append(failedServices, service)
-		}
-	}
-
-	// remove providers that failed to init their service
-	providersMapping.RemoveServices(failedServices)
-	providersMapping.ProcessResources(false)
-
-	return nil
-}
-
-func importFromPlan(providerMapping *terraformutils.ProvidersMapping, options ImportOptions, args []string) error {
-	plan := &ImportPlan{
-		Provider:         providerMapping.GetBaseProvider().GetName(),
-		Options:          options,
-		Args:             args,
-		ImportedResource: map[string][]terraformutils.Resource{},
-	}
-
-	resourcesByService := providerMapping.GetResourcesByService()
-	for service := range resourcesByService {
-		plan.ImportedResource[service] = append(plan.ImportedResource[service], resourcesByService[service]...)
-	}
-
-	if options.Plan {
-		path := Path(options.PathPattern, providerMapping.GetBaseProvider().GetName(), "terraformer", options.PathOutput)
-		return ExportPlanFile(plan, path, "plan.json")
-	}
-
-	return ImportFromPlan(providerMapping.GetBaseProvider(), plan)
-}
-
-func initServiceResources(service string, provider terraformutils.ProviderGenerator,
-	options ImportOptions, providerWrapper *providerwrapper.ProviderWrapper) error {
-	log.Println(provider.GetName() + " importing... " + service)
-	err := provider.InitService(service, options.Verbose)
-	if err != nil {
-		log.Printf("%s error importing %s, err: %s\n", provider.GetName(), service, err)
-		return err
-	}
-	provider.GetService().ParseFilters(options.Filter)
-	err = provider.GetService().InitResources()
-	if err != nil {
-		log.Printf("%s error initializing resources in service %s, err: %s\n", provider.GetName(), service, err)
-		return err
-	}
-
-	provider.GetService().PopulateIgnoreKeys(providerWrapper)
-	provider.GetService().InitialCleanup()
-	log.Println(provider.GetName() + " done importing " + service)
-
-	return nil
-}
-
-func ImportFromPlan(provider terraformutils.ProviderGenerator, plan *ImportPlan) error {
-	options := plan.Options
-	importedResource := plan.ImportedResource
-	isServicePath := strings.Contains(options.PathPattern, "{service}")
-
-	if options.Connect {
-		log.Println(provider.GetName() + " Connecting.... ")
-		importedResource = terraformutils.ConnectServices(importedResource, isServicePath, provider.GetResourceConnections())
-	}
-
-	if !isServicePath {
-		var compactedResources []terraformutils.Resource
-		for _, resources := range importedResource {
-			compactedResources = append(compactedResources, resources...)
-		}
-		e := printService(provider, "", options, compactedResources, importedResource)
-		if e != nil {
-			return e
-		}
-	} else {
-		for serviceName, resources := range importedResource {
-			if len(resources) == 0 {
-				log.Printf("%s: No resources found for service %s. Skipping file output.", provider.GetName(), serviceName)
-				continue // Go to the next service
-			}
-			e := printService(provider, serviceName, options, resources, importedResource)
-			if e != nil {
-				return e
-			}
-		}
-	}
-	return nil
-}
-
-func printService(provider terraformutils.ProviderGenerator, serviceName string, options ImportOptions, resources []terraformutils.Resource, importedResource map[string][]terraformutils.Resource) error {
-	log.Println(provider.GetName() + " save " + serviceName)
-	// Print HCL files for Resources
-	path := Path(options.PathPattern, provider.GetName(), serviceName, options.PathOutput)
-	err := terraformoutput.OutputHclFiles(resources, provider, path, serviceName, options.Compact, options.Output, !options.NoSort)
-	if err != nil {
-		return err
-	}
-	tfStateFile, err := terraformutils.PrintTfState(resources)
-	if err != nil {
-		return err
-	}
-	// print or upload State file
-	if options.State == "bucket" {
-		log.Println(provider.GetName() + " upload tfstate to  bucket " + options.Bucket)
-		bucket := terraformoutput.BucketState{
-			Name: options.Bucket,
-		}
-		if err := bucket.BucketUpload(path, tfStateFile); err != nil {
-			return err
-		}
-		// create Bucket file
-		if bucketStateDataFile, err := terraformutils.Print(bucket.BucketGetTfData(path), map[string]struct{}{}, options.Output, !options.NoSort, make(map[string]map[string][]string)); err == nil {
-			terraformoutput.PrintFile(path+"/bucket.tf", bucketStateDataFile)
-		}
-	} else {
-		if serviceName == "" {
-			log.Println(provider.GetName() + " save tfstate")
-		} else {
-			log.Println(provider.GetName() + " save tfstate for " + serviceName)
-		}
-		if err := os.WriteFile(path+"/terraform.tfstate", tfStateFile, os.ModePerm); err != nil {
-			return err
-		}
-	}
-	// Print hcl variables.tf
-	if serviceName != "" {
-		if options.Connect && len(provider.GetResourceConnections()[serviceName]) > 0 {
-			variables := map[string]map[string]map[string]interface{}{}
-			variables["data"] = map[string]map[string]interface{}{}
-			variables["data"]["terraform_remote_state"] = map[string]interface{}{}
-			if options.State == "bucket" {
-				bucket := terraformoutput.BucketState{
-					Name: options.Bucket,
-				}
-				for k := range provider.GetResourceConnections()[serviceName] {
-					if _, exist := importedResource[k]; !exist {
-						continue
-					}
-					variables["data"]["terraform_remote_state"][k] = map[string]interface{}{
-						"backend": "gcs",
-						"config":  bucket.BucketGetTfData(strings.ReplaceAll(path, serviceName, k)),
-					}
-				}
-			} else {
-				for k := range provider.GetResourceConnections()[serviceName] {
-					if _, exist := importedResource[k]; !exist {
-						continue
-					}
-					variables["data"]["terraform_remote_state"][k] = map[string]interface{}{
-						"backend": "local",
-						"config": map[string]interface{}{
-							"path": strings.Repeat("../", strings.Count(path, "/")) + strings.ReplaceAll(path, serviceName, k) + "terraform.tfstate",
-						},
-					}
-				}
-			}
-			// create variables file
-			if len(provider.GetResourceConnections()[serviceName]) > 0 && options.Connect && len(variables["data"]["terraform_remote_state"]) > 0 {
-				variablesFile, err := terraformutils.Print(variables, map[string]struct{}{"config": {}}, options.Output, !options.NoSort, make(map[string]map[string][]string))
-				if err != nil {
-					return err
-				}
-				terraformoutput.PrintFile(path+"/variables."+terraformoutput.GetFileExtension(options.Output), variablesFile)
-			}
-		}
-	} else {
-		if options.Connect {
-			variables := map[string]map[string]map[string]interface{}{}
-			variables["data"] = map[string]map[string]interface{}{}
-			variables["data"]["terraform_remote_state"] = map[string]interface{}{}
-			if options.State == "bucket" {
-				bucket := terraformoutput.BucketState{
-					Name: options.Bucket,
-				}
-				variables["data"]["terraform_remote_state"]["local"] = map[string]interface{}{
-					"backend": "gcs",
-					"config":  bucket.BucketGetTfData(path),
-				}
-			} else {
-				variables["data"]["terraform_remote_state"]["local"] = map[string]interface{}{
-					"backend": "local",
-					"config": map[string]interface{}{
-						"path": "terraform.tfstate",
-					},
-				}
-			}
-			// create variables file
-			if options.Connect {
-				variablesFile, err := terraformutils.Print(variables, map[string]struct{}{"config": {}}, options.Output, !options.NoSort, make(map[string]map[string][]string))
-				if err != nil {
-					return err
-				}
-				terraformoutput.PrintFile(path+"/variables."+terraformoutput.GetFileExtension(options.Output), variablesFile)
-			}
-		}
-	}
-	return nil
+// importContext returns the context of the imports of a provider command, canceled after --timeout, and the
+// options of its imports, see importpipeline.CommandOptions
+func importContext(ctx context.Context, options ImportOptions) (context.Context, ImportOptions, context.CancelFunc) {
+	ctx, cancel := importpipeline.NewContext(ctx, options)
+	return ctx, importpipeline.CommandOptions(options), cancel
 }
 
+// Path returns the directory of a service, see importpipeline.Path
 func Path(pathPattern, providerName, serviceName, output string) string {
-	return strings.NewReplacer(
-		"{provider}", providerName,
-		"{service}", serviceName,
-		"{output}", output,
-	).Replace(pathPattern)
+	return importpipeline.Path(pathPattern, providerName, serviceName, output)
 }
 
 func listCmd(provider terraformutils.ProviderGenerator) *cobra.Command {
@@ -404,7 +87,7 @@ func listCmd(provider terraformutils.ProviderGenerator) *cobra.Command {
 		Short: "List supported resources for " + provider.GetName() + " provider",
 		Long:  "List supported resources for " + provider.GetName() + " provider",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			services := providerServices(provider)
+			services := importpipeline.ProviderServices(provider)
 			for _, k := range services {
 				fmt.Println(k)
 			}
@@ -415,28 +98,47 @@ func listCmd(provider terraformutils.ProviderGenerator) *cobra.Command {
 	return cmd
 }
 
-func providerServices(provider terraformutils.ProviderGenerator) []string {
-	var services []string
-	for k := range provider.GetSupportedService() {
-		services = append(services, k)
-	}
-	sort.Strings(services)
-	return services
-}
-
 func baseProviderFlags(flag *pflag.FlagSet, options *ImportOptions, sampleRes, sampleFilters string) {
-	flag.BoolVarP(&options.Connect, "connect", "c", true, "")
+	defaults := importpipeline.DefaultImportOptions()
+	flag.BoolVarP(&options.Connect, "connect", "c", defaults.Connect, "")
 	flag.BoolVarP(&options.Compact, "compact", "C", false, "")
 	flag.StringSliceVarP(&options.Resources, "resources", "r", []string{}, sampleRes)
 	flag.StringSliceVarP(&options.Excludes, "excludes", "x", []string{}, sampleRes)
-	flag.StringVarP(&options.PathPattern, "path-pattern", "p", DefaultPathPattern, "{output}/{provider}/")
-	flag.StringVarP(&options.PathOutput, "path-output", "o", DefaultPathOutput, "")
-	flag.StringVarP(&options.State, "state", "s", DefaultState, "local or bucket")
+	flag.StringVarP(&options.PathPattern, "path-pattern", "p", defaults.PathPattern, "{output}/{provider}/")
+	flag.StringVarP(&options.PathOutput, "path-output", "o", defaults.PathOutput, "")
+	flag.StringVarP(&options.State, "state", "s", defaults.State, "local, gcs (or bucket), s3, azurerm, http or import-blocks")
 	flag.StringVarP(&options.Bucket, "bucket", "b", "", "gs://terraform-state")
+	flag.StringArrayVarP(&options.BackendConfig, "backend-config", "", []string{}, "key=value passed to the state backend, e.g. region=eu-west-1")
 	flag.StringSliceVarP(&options.Filter, "filter", "f", []string{}, sampleFilters)
+	flag.StringArrayVarP(&options.Where, "where", "", []string{}, `filter expression, e.g. 'type == "google_storage_bucket" && labels.env == "prod"'`)
 	flag.BoolVarP(&options.Verbose, "verbose", "v", false, "")
 	flag.BoolVarP(&options.NoSort, "no-sort", "S", false, "set to disable sorting of HCL")
-	flag.StringVarP(&options.Output, "output", "O", "hcl", "output format hcl or json")
-	flag.IntVarP(&options.RetryCount, "retry-number", "n", 5, "number of retries to perform when refresh fails")
-	flag.IntVarP(&options.RetrySleepMs, "retry-sleep-ms", "m", 300, "time in ms to sleep between retries")
+	flag.StringVarP(&options.Output, "output", "O", defaults.Output, "output format hcl or json")
+	flag.IntVarP(&options.RetryCount, "retry-number", "n", defaults.RetryCount, "number of retries to perform when refresh fails")
+	flag.IntVarP(&options.RetrySleepMs, "retry-sleep-ms", "m", defaults.RetrySleepMs, "time in ms to sleep before the first retry, doubled for every retry")
+	flag.StringSliceVarP(&options.Parallelism, "parallelism", "", []string{}, "concurrent refreshes (default 15), or type=N for a resource type, e.g. 20,google_sql_database_instance=2")
+	flag.StringVarP(&options.Report, "report", "", "", "write the outcome of every service and resource to a JSON file, e.g. report.json")
+	flag.BoolVarP(&options.Strict, "strict", "", false, "fail when a service or resource could not be imported, same as --fail-on=service,refresh,convert")
+	flag.StringSliceVarP(&options.FailOn, "fail-on", "", []string{}, "fail when a step could not be completed: service, refresh, convert")
+	flag.BoolVarP(&options.Resume, "resume", "", false, "skip services and resources listed or refreshed by an interrupted run")
+	flag.DurationVarP(&options.CheckpointMaxAge, "checkpoint-max-age", "", defaults.CheckpointMaxAge, "age after which --resume lists and refreshes resources again")
+	flag.StringVarP(&options.Secrets, "secrets", "", "", "sensitive attributes: omit, variables (sensitive input variables) or tfvars (also write their values to "+terraformutils.SecretsTfvarsFile+"), plaintext when unset")
+	flag.StringVarP(&options.ProviderVersion, "provider-version", "", "", "version constraint of the provider plugin, e.g. '~> 5.0', written to versions.tf")
+	flag.StringVarP(&options.LockFile, "lock-file", "", "", "Terraform lock file pinning the version and checksums of the provider plugin, e.g. .terraform.lock.hcl")
+	flag.StringArrayVarP(&options.PluginMirrors, "plugin-mirror", "", []string{}, "filesystem mirror directory or network mirror URL of provider plugins, in the layout of terraform providers mirror")
+	flag.StringVarP(&options.Record, "record", "", "", "record the API calls (google only) and the provider plugin reads of the import to a directory, with credentials scrubbed")
+	flag.StringVarP(&options.Replay, "replay", "", "", "replay an import recorded by --record offline, without credentials (google only)")
+	flag.DurationVarP(&options.Timeout, "timeout", "", 0, "cancel the import after the duration, e.g. 30m, the report and checkpoint keep what was imported")
+	flag.StringArrayVarP(&options.RateLimits, "rate-limit", "", []string{}, "refreshes per second or minute of a resource type, e.g. google_compute_instance=10/s, * for all types")
+	flag.BoolVarP(&options.LegacyState, "legacy-state", "", false, "write the legacy v3 terraform.tfstate format")
+	flag.BoolVarP(&options.Update, "update", "", false, "update previously generated files in place, keeping manual edits")
+	flag.StringVarP(&options.Layout, "layout", "", defaults.Layout, "services writes a state per service, modules a root module with one child module per service")
+	flag.StringVarP(&options.Naming, "naming", "", defaults.Naming, "resource naming: legacy (tfer--), snake or template")
+	flag.StringVarP(&options.NamingTemplate, "naming-template", "", "", `Go template of --naming=template, e.g. '{{.Attr "name"}}_{{.Attr "location"}}'`)
+	flag.BoolVarP(&options.ResolveReferences, "resolve-references", "", false, "replace IDs, self links and names of other imported resources with references")
+	if options.Drift {
+		flag.StringVarP(&options.DriftState, "tfstate", "", "", "terraform.tfstate to compare with, a path or a gs://, s3://, azurerm:// or http(s):// URL, read from --state at --path-pattern by default")
+		flag.StringVarP(&options.DriftFormat, "format", "", importpipeline.DriftFormatText, "drift report format text or json")
+		flag.StringVarP(&options.DriftOutput, "report-output", "", "", "also write the JSON drift report to this file")
+	}
 }
@@ -0,0 +1,89 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/spf13/pflag"
+)
+
+// providerOnlyFlags are flags of a single provider command, e.g. its credentials. Import configs set them in the
+// flags setting instead of a setting of their own.
+var providerOnlyFlags = map[string]bool{
+	"account-id":     true,
+	"address":        true,
+	"api-key":        true,
+	"api-url":        true,
+	"apikey":         true,
+	"app-key":        true,
+	"base-url":       true,
+	"cis":            true,
+	"credentials":    true,
+	"datasets":       true,
+	"email":          true,
+	"folder_ids":     true,
+	"group":          true,
+	"owner":          true,
+	"provider-type":  true,
+	"region":         true,
+	"resource-group": true,
+	"resource_group": true,
+	"server":         true,
+	"targets":        true,
+	"team":           true,
+	"token":          true,
+	"validate":       true,
+	"vpc":            true,
+	"vsys":           true,
+}
+
+func importConfigSettings(t *testing.T) map[string]interface{} {
+	schema := struct {
+		Defs struct {
+			Settings struct {
+				Properties map[string]interface{} `json:"properties"`
+			} `json:"settings"`
+		} `json:"$defs"`
+	}{}
+	if err := json.Unmarshal(terraformutils.ImportConfigSchema, &schema); err != nil {
+		t.Fatal(err)
+	}
+	return schema.Defs.Settings.Properties
+}
+
+func TestImportConfigSchemaBaseFlags(t *testing.T) {
+	settings := importConfigSettings(t)
+	flags := pflag.NewFlagSet("import", pflag.ContinueOnError)
+	baseProviderFlags(flags, &ImportOptions{}, "", "")
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if _, ok := settings[flag.Name]; !ok {
+			t.Errorf("flag --%s has no setting in import_config.schema.json", flag.Name)
+		}
+	})
+}
+
+func TestImportConfigSchemaProviderFlags(t *testing.T) {
+	settings := importConfigSettings(t)
+	for _, subcommand := range providerImporterSubcommands {
+		cmd := subcommand(ImportOptions{})
+		cmd.LocalFlags().VisitAll(func(flag *pflag.Flag) {
+			if _, ok := settings[flag.Name]; !ok && !providerOnlyFlags[flag.Name] {
+				t.Errorf("flag --%s of %s has no setting in import_config.schema.json", flag.Name, cmd.Name())
+			}
+		})
+	}
+}
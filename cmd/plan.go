@@ -16,22 +16,12 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
-	"log"
-	"os"
-	"path/filepath"
 
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/importpipeline"
 	"github.com/spf13/cobra"
 )
 
-type ImportPlan struct {
-	Version          string
-	Provider         string
-	Options          ImportOptions
-	Args             []string
-	ImportedResource map[string][]terraformutils.Resource
-}
-
 func newPlanCmd() *cobra.Command {
 	options := ImportOptions{
 		Plan: true,
@@ -45,23 +35,85 @@ func newPlanCmd() *cobra.Command {
 		//Version:       version.String(),
 	}
 
+	cmd.AddCommand(newCmdPlanShow())
+	cmd.AddCommand(newCmdPlanDiff())
 	for _, subcommand := range providerImporterSubcommands {
 		cmd.AddCommand(subcommand(options))
 	}
 	return cmd
 }
 
+func newCmdPlanShow() *cobra.Command {
+	verbose := false
+	cmd := &cobra.Command{
+		Use:   "show plan.json",
+		Short: "Print the resources of a plan by service and type",
+		Long:  "Print the resources of a plan by service and type",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			plan, err := importpipeline.ReadPlanFile(args[0])
+			if err != nil {
+				return err
+			}
+			terraformutils.PrintPlanSummary(cmd.OutOrStdout(), plan, verbose)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "list every resource")
+	return cmd
+}
+
+func newCmdPlanDiff() *cobra.Command {
+	format := importpipeline.DriftFormatText
+	cmd := &cobra.Command{
+		Use:   "diff a.json b.json",
+		Short: "Print the resources added, removed and changed from one plan to another",
+		Long:  "Print the resources added, removed and changed from one plan to another, matched by service, type and ID",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			a, err := importpipeline.ReadPlanFile(args[0])
+			if err != nil {
+				return err
+			}
+			b, err := importpipeline.ReadPlanFile(args[1])
+			if err != nil {
+				return err
+			}
+			diff := terraformutils.DiffPlans(a, b)
+			switch format {
+			case importpipeline.DriftFormatText:
+				diff.Print(cmd.OutOrStdout())
+			case importpipeline.DriftFormatJSON:
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(diff)
+			default:
+				return fmt.Errorf("unsupported plan diff format: %s", format)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&format, "format", "", importpipeline.DriftFormatText, "diff format text or json")
+	return cmd
+}
+
 func newCmdPlanImporter(options ImportOptions) *cobra.Command {
+	only := []string{}
 	cmd := &cobra.Command{
 		Use:   "plan",
 		Short: "Import planned state to Terraform configuration",
 		Long:  "Import planned state to Terraform configuration",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			plan, err := LoadPlanfile(args[0])
+			selector, err := terraformutils.ParsePlanSelector(only)
+			if err != nil {
+				return err
+			}
+			plan, err := importpipeline.LoadPlanfile(args[0])
 			if err != nil {
 				return err
 			}
+			plan.ImportedResource = selector.Select(plan.ImportedResource)
 
 			var provider terraformutils.ProviderGenerator
 			if providerGen, ok := getProviderGenerators()[plan.Provider]; ok {
@@ -70,6 +122,7 @@ func newCmdPlanImporter(options ImportOptions) *cobra.Command {
 				return fmt.Errorf("unsupported provider: %s", plan.Provider)
 			}
 
+			importpipeline.SetProviderContext(cmd.Context(), provider)
 			if err = provider.Init(plan.Args); err != nil {
 				return err
 			}
@@ -80,50 +133,12 @@ func newCmdPlanImporter(options ImportOptions) *cobra.Command {
 				}
 			}
 
-			return ImportFromPlan(provider, plan)
+			if err = importpipeline.InstallPlugin(cmd.Context(), provider, plan.Options); err != nil {
+				return err
+			}
+			return importpipeline.ImportFromPlan(cmd.Context(), provider, plan, nil)
 		},
 	}
+	cmd.Flags().StringSliceVarP(&only, "only", "", []string{}, "import a subset of the plan, e.g. type=google_storage_bucket,service=gcs or id=prod-*")
 	return cmd
 }
-
-func LoadPlanfile(path string) (*ImportPlan, error) {
-	f, err := os.Open(path)
-	if err != nil {
-		return nil, err
-	}
-	defer f.Close()
-
-	plan := &ImportPlan{}
-	dec := json.NewDecoder(f)
-	dec.DisallowUnknownFields()
-	if err := dec.Decode(plan); err != nil {
-		return nil, err
-	}
-
-	if plan.Version != version {
-		return nil, fmt.Errorf("planfile version did not match. expected: %s, actual: %s", version, plan.Version)
-	}
-
-	return plan, nil
-}
-
-func ExportPlanFile(plan *ImportPlan, path, filename string) error {
-	plan.Version = version
-
-	planfilePath := filepath.Join(path, filename)
-	log.Println("Saving planfile to", planfilePath)
-
-	if err := os.MkdirAll(path, os.ModePerm); err != nil {
-		return err
-	}
-
-	f, err := os.OpenFile(planfilePath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.ModePerm)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-
-	enc := json.NewEncoder(f)
-	enc.SetIndent("", "\t")
-	return enc.Encode(plan)
-}
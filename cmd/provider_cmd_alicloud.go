@@ -34,6 +34,8 @@ func newCmdAliCloudImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current State to terraform configuration from alicloud",
 		Long:  "Import current State to terraform configuration from alicloud",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			originalPathPattern := options.PathPattern
 			for _, region := range options.Regions {
 				provider := newAliCloudProvider()
@@ -41,7 +43,7 @@ func newCmdAliCloudImporter(options ImportOptions) *cobra.Command {
 				options.PathPattern += region + "/"
 				log.Println(provider.GetName() + " importing region " + region)
 				profile := options.Profile
-				err := Import(provider, options, []string{region, profile})
+				err := ImportContext(ctx, provider, options, []string{region, profile})
 				if err != nil {
 					return err
 				}
@@ -36,6 +36,8 @@ func newCmdAuth0Importer(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from Auth0",
 		Long:  "Import current state to Terraform configuration from Auth0",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			domain := os.Getenv("AUTH0_DOMAIN")
 			if len(domain) == 0 {
 				return errors.New("Domain for Auth0 must be set through `AUTH0_DOMAIN` env var")
@@ -50,7 +52,7 @@ func newCmdAuth0Importer(options ImportOptions) *cobra.Command {
 			}
 
 			provider := newAuth0Provider()
-			err := Import(provider, options, []string{domain, clientID, clientSecret})
+			err := ImportContext(ctx, provider, options, []string{domain, clientID, clientSecret})
 			if err != nil {
 				return err
 			}
@@ -15,6 +15,7 @@
 package cmd
 
 import (
+	"context"
 	"log"
 
 	awsterraformer "github.com/GoogleCloudPlatform/terraformer/providers/aws"
@@ -34,6 +35,8 @@ func newCmdAwsImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from AWS",
 		Long:  "Import current state to Terraform configuration from AWS",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			originalResources := options.Resources
 			originalRegions := options.Regions
 			originalPathPattern := options.PathPattern
@@ -43,14 +46,14 @@ func newCmdAwsImporter(options ImportOptions) *cobra.Command {
 				globalResources, eastOnlyResources, regionalResources := parseAndGroupResources(originalResources)
 				options.Resources = globalResources
 				options.Regions = []string{awsterraformer.GlobalRegion}
-				e := importGlobalResources(options)
+				e := importGlobalResources(ctx, options)
 				if e != nil {
 					return e
 				}
 
 				options.Resources = eastOnlyResources
 				options.Regions = []string{awsterraformer.MainRegionPublicPartition}
-				e = importEastOnlyResources(options)
+				e = importEastOnlyResources(ctx, options)
 				if e != nil {
 					return e
 				}
@@ -62,7 +65,7 @@ func newCmdAwsImporter(options ImportOptions) *cobra.Command {
 						shouldSpecifyPathRegion = true // we should keep global resources away from regional
 					}
 					for _, region := range originalRegions {
-						e := importRegionResources(options, originalPathPattern, region, shouldSpecifyPathRegion)
+						e := importRegionResources(ctx, options, originalPathPattern, region, shouldSpecifyPathRegion)
 						if e != nil {
 							return e
 						}
@@ -70,7 +73,7 @@ func newCmdAwsImporter(options ImportOptions) *cobra.Command {
 				}
 				return nil
 			}
-			err := importRegionResources(options, options.PathPattern, awsterraformer.NoRegion, false)
+			err := importRegionResources(ctx, options, options.PathPattern, awsterraformer.NoRegion, false)
 			if err != nil {
 				return err
 			}
@@ -100,21 +103,21 @@ func parseAndGroupResources(allResources []string) ([]string, []string, []string
 	return globalResources, eastOnlyResources, regionalResources
 }
 
-func importGlobalResources(options ImportOptions) error {
+func importGlobalResources(ctx context.Context, options ImportOptions) error {
 	if len(options.Resources) > 0 {
-		return importRegionResources(options, options.PathPattern, awsterraformer.GlobalRegion, false)
+		return importRegionResources(ctx, options, options.PathPattern, awsterraformer.GlobalRegion, false)
 	}
 	return nil
 }
 
-func importEastOnlyResources(options ImportOptions) error {
+func importEastOnlyResources(ctx context.Context, options ImportOptions) error {
 	if len(options.Resources) > 0 {
-		return importRegionResources(options, options.PathPattern, awsterraformer.MainRegionPublicPartition, false)
+		return importRegionResources(ctx, options, options.PathPattern, awsterraformer.MainRegionPublicPartition, false)
 	}
 	return nil
 }
 
-func importRegionResources(options ImportOptions, originalPathPattern string, region string, shouldSpecifyPathRegion bool) error {
+func importRegionResources(ctx context.Context, options ImportOptions, originalPathPattern string, region string, shouldSpecifyPathRegion bool) error {
 	provider := newAWSProvider()
 	options.PathPattern = originalPathPattern
 	if region != awsterraformer.GlobalRegion && region != awsterraformer.NoRegion {
@@ -125,7 +128,7 @@ func importRegionResources(options ImportOptions, originalPathPattern string, re
 	} else {
 		log.Println(provider.GetName() + " importing default region")
 	}
-	err := Import(provider, options, []string{region, options.Profile})
+	err := ImportContext(ctx, provider, options, []string{region, options.Profile})
 	if err != nil {
 		return err
 	}
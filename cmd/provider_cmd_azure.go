@@ -33,8 +33,10 @@ func newCmdAzureImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from Azure",
 		Long:  "Import current state to Terraform configuration from Azure",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			provider := newAzureProvider()
-			err := Import(provider, options, []string{options.ResourceGroup})
+			err := ImportContext(ctx, provider, options, []string{options.ResourceGroup})
 			if err != nil {
 				return err
 			}
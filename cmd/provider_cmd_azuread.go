@@ -33,8 +33,10 @@ func newCmdAzureADImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from Azure Active Directory",
 		Long:  "Import current state to Terraform configuration from Azure Active Directory",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			provider := newAzureADProvider()
-			err := Import(provider, options, []string{options.ResourceGroup})
+			err := ImportContext(ctx, provider, options, []string{options.ResourceGroup})
 			if err != nil {
 				return err
 			}
@@ -34,8 +34,10 @@ func newCmdAzureDevOpsImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from Azure DevOps",
 		Long:  "Import current state to Terraform configuration from Azure DevOps",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			provider := newAzureDevOpsProvider()
-			err := Import(provider, options, []string{options.ResourceGroup})
+			err := ImportContext(ctx, provider, options, []string{options.ResourceGroup})
 			if err != nil {
 				return err
 			}
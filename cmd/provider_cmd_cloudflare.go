@@ -33,8 +33,10 @@ func newCmdCloudflareImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from Cloudflare",
 		Long:  "Import current state to Terraform configuration from Cloudflare",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			provider := newCloudflareProvider()
-			err := Import(provider, options, []string{})
+			err := ImportContext(ctx, provider, options, []string{})
 			if err != nil {
 				return err
 			}
@@ -40,6 +40,8 @@ func newCmdCommercetoolsImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from Commercetools",
 		Long:  "Import current state to Terraform configuration from Commercetools",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			clientID := os.Getenv("CTP_CLIENT_ID")
 			if len(clientID) == 0 {
 				return errors.New("API client ID for commercetools must be set through `CTP_CLIENT_ID` env var")
@@ -65,7 +67,7 @@ func newCmdCommercetoolsImporter(options ImportOptions) *cobra.Command {
 				tokenURL = defaultCommercetoolsTokenURL
 			}
 			provider := newCommercetoolsProvider()
-			err := Import(provider, options, []string{clientID, clientScope, clientSecret, projectKey, baseURL, tokenURL})
+			err := ImportContext(ctx, provider, options, []string{clientID, clientScope, clientSecret, projectKey, baseURL, tokenURL})
 			if err != nil {
 				return err
 			}
@@ -33,8 +33,10 @@ func newCmdDatadogImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from Datadog",
 		Long:  "Import current state to Terraform configuration from Datadog",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			provider := newDataDogProvider()
-			err := Import(provider, options, []string{apiKey, appKey, apiURL, validate})
+			err := ImportContext(ctx, provider, options, []string{apiKey, appKey, apiURL, validate})
 			if err != nil {
 				return err
 			}
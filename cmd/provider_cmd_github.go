@@ -38,13 +38,15 @@ func newCmdGithubImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from GitHub",
 		Long:  "Import current state to Terraform configuration from GitHub",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			originalPathPattern := options.PathPattern
 			for _, organization := range owner {
 				provider := newGitHubProvider()
 				options.PathPattern = originalPathPattern
 				options.PathPattern = strings.ReplaceAll(options.PathPattern, "{provider}", "{provider}/"+organization)
 				log.Println(provider.GetName() + " importing organization " + organization)
-				err := Import(provider, options, []string{organization, token, baseURL})
+				err := ImportContext(ctx, provider, options, []string{organization, token, baseURL})
 				if err != nil {
 					return err
 				}
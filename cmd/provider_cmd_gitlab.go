@@ -38,13 +38,15 @@ func newCmdGitLabImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from GitLab",
 		Long:  "Import current state to Terraform configuration from GitLab",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			originalPathPattern := options.PathPattern
 			for _, group := range groups {
 				provider := newGitLabProvider()
 				options.PathPattern = originalPathPattern
 				options.PathPattern = strings.ReplaceAll(options.PathPattern, "{provider}", "{provider}/"+group)
 				log.Println(provider.GetName() + " importing group " + group)
-				err := Import(provider, options, []string{group, token, baseURL})
+				err := ImportContext(ctx, provider, options, []string{group, token, baseURL})
 				if err != nil {
 					return err
 				}
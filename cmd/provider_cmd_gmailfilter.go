@@ -34,8 +34,10 @@ func newCmdGmailfilterImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from Gmail",
 		Long:  "Import current state to Terraform configuration from Gmail",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			provider := newGmailfilterProvider()
-			err := Import(provider, options, []string{
+			err := ImportContext(ctx, provider, options, []string{
 				creds,
 				impersonatedUserEmail,
 			})
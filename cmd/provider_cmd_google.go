@@ -16,14 +16,25 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"log"
+	"os"
+	"path/filepath"
 	"strings"
 
 	gcp_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/gcp"
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/importpipeline"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/terraformerstring"
 	"github.com/spf13/cobra"
 )
 
+// projectsManifestFile is the manifest of the projects discovered by --organization and --folder, written to
+// {output}/google
+const projectsManifestFile = "projects.json"
+
 // init will automatically register this provider with the global lists.
 func init() {
 	providerImporterSubcommands = append(providerImporterSubcommands, newCmdGoogleImporter)
@@ -32,38 +43,86 @@ func init() {
 
 func newCmdGoogleImporter(options ImportOptions) *cobra.Command {
 	providerType := ""
+	selector := gcp_terraforming.ProjectSelector{}
 	cmd := &cobra.Command{
 		Use:   "google",
 		Short: "Import current state to Terraform configuration from Google Cloud",
 		Long:  "Import current state to Terraform configuration from Google Cloud",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			originalPathPattern := options.PathPattern
-			for _, project := range options.Projects {
-				for _, region := range options.Regions {
-					provider := newGoogleProvider()
-					options.PathPattern = originalPathPattern
-					options.PathPattern = strings.ReplaceAll(options.PathPattern, "{service}", project+"/{service}/"+region)
-					log.Println(provider.GetName() + " importing project " + project + " region " + region)
-					err := Import(provider, options, []string{region, project, providerType})
-					if err == gcp_terraforming.InvalidRegion {
-						log.Println(err)
-					} else if err != nil {
-						return err
-					}
-				}
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
+			if err := discoverProjects(ctx, &options, selector); err != nil {
+				return err
+			}
+			if len(options.Projects) == 0 {
+				return errors.New("no projects to import, set --projects, --organization or --folder")
+			}
+			scopes := []importpipeline.Scope{}
+			for _, scope := range gcp_terraforming.Scopes(options.Projects, options.Regions, providerType) {
+				scopes = append(scopes, importpipeline.Scope{
+					Name:        "project " + scope.Project + " region " + scope.Region,
+					Provider:    newGoogleProvider(),
+					Args:        scope.Args(),
+					PathPattern: strings.ReplaceAll(options.PathPattern, "{service}", scope.Project+"/{service}/"+scope.Region),
+					Ignore: func(err error) bool {
+						return errors.Is(err, gcp_terraforming.InvalidRegion)
+					},
+				})
 			}
-			return nil
+			return importpipeline.ImportScopes(ctx, options, scopes)
 		},
 	}
 	cmd.AddCommand(listCmd(newGoogleProvider()))
 	baseProviderFlags(cmd.PersistentFlags(), &options, "firewalls,networks", "compute_firewall=id1:id2:id4")
 	cmd.PersistentFlags().StringSliceVarP(&options.Regions, "regions", "z", []string{"global"}, "europe-west1,")
 	cmd.PersistentFlags().StringSliceVarP(&options.Projects, "projects", "", []string{}, "")
+	cmd.PersistentFlags().StringSliceVarP(&selector.Organizations, "organization", "", []string{}, "import the projects of an organization and its folders, e.g. 123456789")
+	cmd.PersistentFlags().StringSliceVarP(&selector.Folders, "folder", "", []string{}, "import the projects of a folder and its subfolders, e.g. 123456789")
+	cmd.PersistentFlags().StringArrayVarP(&selector.Filters, "project-filter", "", []string{}, "import the discovered projects with a label only, e.g. labels.env=prod")
+	cmd.PersistentFlags().StringSliceVarP(&selector.Excludes, "project-exclude", "", []string{}, "leave out the discovered projects whose ID matches a glob, e.g. sandbox-*")
 	cmd.PersistentFlags().StringVarP(&providerType, "provider-type", "", "", "beta")
-	_ = cmd.MarkPersistentFlagRequired("projects")
+	cmd.PersistentFlags().IntVarP(&options.ScopeParallelism, "scope-parallelism", "", importpipeline.DefaultScopeParallelism, "project and region pairs imported at once, the regions of a project share a provider plugin")
 	return cmd
 }
 
 func newGoogleProvider() terraformutils.ProviderGenerator {
 	return &gcp_terraforming.GCPProvider{}
 }
+
+// discoverProjects adds the projects of --organization and --folder to --projects and writes their manifest, see
+// gcp_terraforming.DiscoverProjects. The API calls are recorded and replayed with --record and --replay.
+func discoverProjects(ctx context.Context, options *ImportOptions, selector gcp_terraforming.ProjectSelector) error {
+	if len(selector.Organizations) == 0 && len(selector.Folders) == 0 {
+		if len(selector.Filters) > 0 || len(selector.Excludes) > 0 {
+			return errors.New("--project-filter and --project-exclude require --organization or --folder")
+		}
+		return nil
+	}
+	provider := newGoogleProvider()
+	if err := importpipeline.SetAPIMiddleware(provider, *options); err != nil {
+		return err
+	}
+	discovery, err := gcp_terraforming.DiscoverProjects(ctx, selector, provider.(terraformutils.ProviderWithAPIMiddleware).APIMiddleware())
+	if err != nil {
+		return err
+	}
+	log.Printf("google discovered %d projects, %d excluded by --project-filter and --project-exclude", len(discovery.Projects), len(discovery.Excluded))
+	data, err := json.MarshalIndent(discovery, "", "  ")
+	if err != nil {
+		return err
+	}
+	dir := Path("{output}/{provider}", "google", "", options.PathOutput)
+	if err := options.FileSystem().MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+	log.Println("Saving discovered projects to", filepath.Join(dir, projectsManifestFile))
+	if err := options.FileSystem().WriteFile(filepath.Join(dir, projectsManifestFile), append(data, '\n'), os.ModePerm); err != nil {
+		return err
+	}
+	for _, project := range discovery.ProjectIDs() {
+		if !terraformerstring.ContainsString(options.Projects, project) {
+			options.Projects = append(options.Projects, project)
+		}
+	}
+	return nil
+}
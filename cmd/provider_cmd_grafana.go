@@ -33,8 +33,10 @@ func newCmdGrafanaImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from Grafana",
 		Long:  "Import current state to Terraform configuration from Grafana",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			provider := newGrafanaProvider()
-			err := Import(provider, options, []string{})
+			err := ImportContext(ctx, provider, options, []string{})
 			if err != nil {
 				return err
 			}
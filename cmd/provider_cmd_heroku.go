@@ -38,11 +38,13 @@ func newCmdHerokuImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from Heroku",
 		Long:  "Import current state to Terraform configuration from Heroku",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			if apiKey = os.Getenv("HEROKU_API_KEY"); apiKey == "" {
 				return errors.New("Requires HEROKU_API_KEY env var")
 			}
 			provider := newHerokuProvider()
-			err := Import(provider, options, []string{apiKey, team})
+			err := ImportContext(ctx, provider, options, []string{apiKey, team})
 			if err != nil {
 				return err
 			}
@@ -33,8 +33,10 @@ func newCmdHoneycombioImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from Honeycomb.io",
 		Long:  "Import current state to Terraform configuration from Honeycomb.io",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			provider := newHoneycombioProvider()
-			err := Import(provider, options, options.Projects)
+			err := ImportContext(ctx, provider, options, options.Projects)
 			if err != nil {
 				return err
 			}
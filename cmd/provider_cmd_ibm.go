@@ -37,8 +37,10 @@ func newCmdIbmImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from ibm",
 		Long:  "Import current state to Terraform configuration from ibm",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			provider := newIbmProvider()
-			err := Import(provider, options, []string{resourceGroup, region, cis, vpc})
+			err := ImportContext(ctx, provider, options, []string{resourceGroup, region, cis, vpc})
 			if err != nil {
 				return err
 			}
@@ -34,8 +34,10 @@ func newCmdKubernetesImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from Kubernetes",
 		Long:  "Import current state to Terraform configuration from Kubernetes",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			provider := newKubernetesProvider()
-			err := Import(provider, options, []string{strconv.FormatBool(options.Verbose)})
+			err := ImportContext(ctx, provider, options, []string{strconv.FormatBool(options.Verbose)})
 			if err != nil {
 				return err
 			}
@@ -33,8 +33,10 @@ func newCmdLaunchDarklyImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from LaunchDarkly",
 		Long:  "Import current state to Terraform configuration from LaunchDarkly",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			provider := newLaunchDarklyProvider()
-			err := Import(provider, options, []string{})
+			err := ImportContext(ctx, provider, options, []string{})
 			if err != nil {
 				return err
 			}
@@ -39,6 +39,8 @@ func newCmdLogzioImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from Logz.io",
 		Long:  "Import current state to Terraform configuration from Logz.io",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			token := os.Getenv("LOGZIO_API_TOKEN")
 			if len(token) == 0 {
 				return errors.New("API Token for Logz.io must be set through `LOGZIO_API_TOKEN` env var")
@@ -49,7 +51,7 @@ func newCmdLogzioImporter(options ImportOptions) *cobra.Command {
 			}
 
 			provider := newLogzioProvider()
-			err := Import(provider, options, []string{token, baseURL})
+			err := ImportContext(ctx, provider, options, []string{token, baseURL})
 			if err != nil {
 				return err
 			}
@@ -34,8 +34,10 @@ func newCmdMackerelImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from Mackerel",
 		Long:  "Import current state to Terraform configuration from Mackerel",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			provider := newMackerelProvider()
-			err := Import(provider, options, []string{apiKey})
+			err := ImportContext(ctx, provider, options, []string{apiKey})
 			if err != nil {
 				return err
 			}
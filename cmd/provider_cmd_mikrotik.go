@@ -32,8 +32,10 @@ func newCmdMikrotikImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from RouterOS",
 		Long:  "Import current state to Terraform configuration from RouterOS",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			provider := newMikrotikProvider()
-			err := Import(provider, options, []string{})
+			err := ImportContext(ctx, provider, options, []string{})
 			if err != nil {
 				return err
 			}
@@ -22,8 +22,10 @@ func newCmdMyrasecImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from Myra Security",
 		Long:  "Import current state to Terraform configuration from Myra Security",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			provider := newMyrasecProvider()
-			err := Import(provider, options, []string{})
+			err := ImportContext(ctx, provider, options, []string{})
 			if err != nil {
 				return err
 			}
@@ -36,8 +36,10 @@ func newCmdNewRelicImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from New Relic",
 		Long:  "Import current state to Terraform configuration from New Relic",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			provider := newNewRelicProvider()
-			err := Import(provider, options, []string{apiKey, accountID, region})
+			err := ImportContext(ctx, provider, options, []string{apiKey, accountID, region})
 			if err != nil {
 				return err
 			}
@@ -33,8 +33,10 @@ func newCmdNs1Importer(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from NS1",
 		Long:  "Import current state to Terraform configuration from NS1",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			provider := newNs1Provider()
-			err := Import(provider, options, []string{})
+			err := ImportContext(ctx, provider, options, []string{})
 			if err != nil {
 				return err
 			}
@@ -21,9 +21,11 @@ func newCmdOctopusDeployImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from Octopus Deploy",
 		Long:  "Import current state to Terraform configuration from Octopus Deploy",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			provider := newOctopusDeployProvider()
 			options.PathPattern = "{output}/{provider}/"
-			err := Import(provider, options, []string{server, apiKey})
+			err := ImportContext(ctx, provider, options, []string{server, apiKey})
 			if err != nil {
 				return err
 			}
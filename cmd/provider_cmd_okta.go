@@ -35,6 +35,8 @@ func newCmdOktaImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current State to terraform configuration from okta",
 		Long:  "Import current State to terraform configuration from okta",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			token := os.Getenv("OKTA_API_TOKEN")
 			if len(token) == 0 {
 				return errors.New("API Token for Okta must be set through `OKTA_API_TOKEN` env var")
@@ -49,7 +51,7 @@ func newCmdOktaImporter(options ImportOptions) *cobra.Command {
 			}
 
 			provider := newOktaProvider()
-			err := Import(provider, options, []string{orgName, token, baseURL})
+			err := ImportContext(ctx, provider, options, []string{orgName, token, baseURL})
 			if err != nil {
 				return err
 			}
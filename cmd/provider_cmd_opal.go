@@ -33,8 +33,10 @@ func newCmdOpalImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from opal.dev",
 		Long:  "Import current state to Terraform configuration from opal.dev",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			provider := newOpalProvider()
-			err := Import(provider, options, options.Projects)
+			err := ImportContext(ctx, provider, options, options.Projects)
 			if err != nil {
 				return err
 			}
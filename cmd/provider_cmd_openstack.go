@@ -34,13 +34,15 @@ func newCmdOpenStackImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from OpenStack",
 		Long:  "Import current state to Terraform configuration from OpenStack",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			originalPathPattern := options.PathPattern
 			for _, region := range options.Regions {
 				provider := newOpenStackProvider()
 				options.PathPattern = originalPathPattern
 				options.PathPattern += region + "/"
 				log.Println(provider.GetName() + " importing region " + region)
-				err := Import(provider, options, []string{region})
+				err := ImportContext(ctx, provider, options, []string{region})
 				if err != nil {
 					return err
 				}
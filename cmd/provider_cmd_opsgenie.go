@@ -21,8 +21,10 @@ func newCmdOpsgenieImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from Opsgenie",
 		Long:  "Import current state to Terraform configuration from Opsgenie",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			provider := newOpsgenieProvider()
-			err := Import(provider, options, []string{apiKey})
+			err := ImportContext(ctx, provider, options, []string{apiKey})
 			if err != nil {
 				return err
 			}
@@ -34,8 +34,10 @@ func newCmdPagerDutyImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from PagerDuty",
 		Long:  "Import current state to Terraform configuration from PagerDuty",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			provider := newPagerDutyProvider()
-			err := Import(provider, options, []string{token})
+			err := ImportContext(ctx, provider, options, []string{token})
 			if err != nil {
 				return err
 			}
@@ -38,6 +38,8 @@ func newCmdPanosImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from a PAN-OS",
 		Long:  "Import current state to Terraform configuration from a PAN-OS",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			var t interface{}
 
 			if len(vsys) == 0 {
@@ -66,7 +68,7 @@ func newCmdPanosImporter(options ImportOptions) *cobra.Command {
 				options.PathPattern = originalPathPattern
 				options.PathPattern = strings.ReplaceAll(options.PathPattern, "{provider}", "{provider}/"+v)
 
-				err := Import(provider, options, []string{v})
+				err := ImportContext(ctx, provider, options, []string{v})
 				if err != nil {
 					return err
 				}
@@ -40,6 +40,8 @@ func newCmdRabbitMQImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from RabbitMQ",
 		Long:  "Import current state to Terraform configuration from RabbitMQ",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			endpoint := os.Getenv("RABBITMQ_SERVER_URL")
 			if len(endpoint) == 0 {
 				endpoint = defaultRabbitMQEndpoint
@@ -47,7 +49,7 @@ func newCmdRabbitMQImporter(options ImportOptions) *cobra.Command {
 			username := os.Getenv("RABBITMQ_USERNAME")
 			password := os.Getenv("RABBITMQ_PASSWORD")
 			provider := newRabbitMQProvider()
-			err := Import(provider, options, []string{endpoint, username, password})
+			err := ImportContext(ctx, provider, options, []string{endpoint, username, password})
 			if err != nil {
 				return err
 			}
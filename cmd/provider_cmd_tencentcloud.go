@@ -35,13 +35,15 @@ func newCmdTencentCloudImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from Tencent Cloud",
 		Long:  "Import current state to Terraform configuration from Tencent Cloud",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			originalPathPattern := options.PathPattern
 			for _, region := range options.Regions {
 				provider := newTencentCloudProvider()
 				options.PathPattern = originalPathPattern
 				options.PathPattern += region + "/"
 				log.Println(provider.GetName() + " importing region " + region)
-				err := Import(provider, options, []string{region})
+				err := ImportContext(ctx, provider, options, []string{region})
 				if err != nil {
 					return err
 				}
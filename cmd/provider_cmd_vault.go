@@ -34,8 +34,10 @@ func newCmdVaultImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from Vault",
 		Long:  "Import current state to Terraform configuration from Vault",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			provider := newVaultProvider()
-			err := Import(provider, options, []string{address, token})
+			err := ImportContext(ctx, provider, options, []string{address, token})
 			if err != nil {
 				return err
 			}
@@ -33,8 +33,10 @@ func newCmdVultrImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from Vultr",
 		Long:  "Import current state to Terraform configuration from Vultr",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 			provider := newVultrProvider()
-			err := Import(provider, options, []string{})
+			err := ImportContext(ctx, provider, options, []string{})
 			if err != nil {
 				return err
 			}
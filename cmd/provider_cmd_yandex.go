@@ -37,6 +37,8 @@ func newCmdYandexImporter(options ImportOptions) *cobra.Command {
 		Short: "Import current state to Terraform configuration from Yandex Cloud",
 		Long:  "Import current state to Terraform configuration from Yandex Cloud",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, options, cancel := importContext(cmd.Context(), options)
+			defer cancel()
 
 			originalPathPattern := options.PathPattern
 			// iterate over provided folder_ids
@@ -45,7 +47,7 @@ func newCmdYandexImporter(options ImportOptions) *cobra.Command {
 				options.PathPattern = originalPathPattern
 				options.PathPattern = strings.ReplaceAll(options.PathPattern, "{provider}/{service}", "{provider}/"+folderID+"/{service}")
 				log.Println(provider.GetName() + " importing folder id " + folderID)
-				err := Import(provider, options, []string{folderID})
+				err := ImportContext(ctx, provider, options, []string{folderID})
 				if err != nil {
 					return err
 				}
@@ -15,6 +15,13 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
 	"github.com/spf13/cobra"
 )
@@ -29,13 +36,33 @@ func NewCmdRoot() *cobra.Command {
 	}
 	cmd.AddCommand(newImportCmd())
 	cmd.AddCommand(newPlanCmd())
+	cmd.AddCommand(newDriftCmd())
+	cmd.AddCommand(newApplyConfigCmd())
+	cmd.AddCommand(newSchemaCmd())
+	cmd.AddCommand(newCoverageCmd())
 	cmd.AddCommand(versionCmd)
 	return cmd
 }
 
+// Execute runs the command line. SIGINT and SIGTERM cancel the running import, a second signal exits at once.
 func Execute() error {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		defer signal.Stop(signals)
+		select {
+		case sig := <-signals:
+			// the next signal terminates the process
+			signal.Stop(signals)
+			log.Printf("received %s, stopping the import, interrupt again to exit at once", sig)
+			cancel(fmt.Errorf("import interrupted by %s", sig))
+		case <-ctx.Done():
+		}
+	}()
 	cmd := NewCmdRoot()
-	return cmd.Execute()
+	return cmd.ExecuteContext(ctx)
 }
 
 func getProviderGenerators() map[string]func() terraformutils.ProviderGenerator {
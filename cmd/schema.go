@@ -0,0 +1,73 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/providerwrapper"
+	"github.com/spf13/cobra"
+)
+
+func newSchemaCmd() *cobra.Command {
+	verbose := false
+	cmd := &cobra.Command{
+		Use:   "schema provider [type]",
+		Short: "Print the resource types of a provider plugin, or the schema of a resource type",
+		Long: "Print the resource types of a provider plugin, or the attributes and blocks of a resource type with " +
+			"their computed and sensitive flags. Schemas are cached by the hash of the plugin binary, see " +
+			providerwrapper.SchemaCacheEnv + ".",
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			schema, err := providerwrapper.ProviderSchema(args[0], verbose)
+			if err != nil {
+				return err
+			}
+			if len(args) == 1 {
+				terraformutils.PrintResourceTypes(cmd.OutOrStdout(), schema)
+				return nil
+			}
+			resourceSchema, exists := schema.ResourceTypes[args[1]]
+			if !exists {
+				return fmt.Errorf("%s is not a resource type of provider %s", args[1], args[0])
+			}
+			terraformutils.PrintResourceSchema(cmd.OutOrStdout(), args[1], resourceSchema)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "")
+	return cmd
+}
+
+func newCoverageCmd() *cobra.Command {
+	verbose := false
+	cmd := &cobra.Command{
+		Use:   "coverage provider",
+		Short: "Print the resource types of a provider plugin without a generator",
+		Long: "Print the resource types in the schema of a provider plugin which no generator of terraformer " +
+			"creates, found by scanning the resource types passed to NewResource",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			schema, err := providerwrapper.ProviderSchema(args[0], verbose)
+			if err != nil {
+				return err
+			}
+			terraformutils.PrintCoverage(cmd.OutOrStdout(), args[0], schema)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "")
+	return cmd
+}
@@ -3,10 +3,11 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/importpipeline"
 	"github.com/spf13/cobra"
 )
 
-const version = "v0.8.30"
+const version = importpipeline.Version
 
 var versionCmd = &cobra.Command{
 	Use:   "version",
@@ -0,0 +1,236 @@
+//go:build google || !single_provider
+
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package importer
+
+import (
+	"context"
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	gcp_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/gcp"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/httpfixture"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/importpipeline"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/providerwrapper/providertest"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/terraformoutput"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files in test_data")
+
+// lineage matches the lineage of a state, a random UUID
+var lineage = regexp.MustCompile(`"lineage": "[0-9a-f-]+"`)
+
+// TestImportGCP imports a synthetic project from the recorded responses of the compute API and the resource
+// states of a fake google plugin, and compares the generated files with test_data/gcp/golden
+func TestImportGCP(t *testing.T) {
+	t.Setenv("GOOGLE_CLOUD_PROJECT", "")
+	plugin, err := providertest.Load(filepath.Join("test_data", "gcp", "provider.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fixture, err := httpfixture.Load(filepath.Join("test_data", "gcp", "api.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayer, err := httpfixture.NewReplayer(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider := &gcp_terraforming.GCPProvider{}
+	provider.SetAPIMiddleware(replayer)
+
+	options := DefaultOptions()
+	options.Resources = []string{"networks", "firewall"}
+	options.Connect = true
+	options.Plugin = plugin
+	result, err := Import(context.Background(), Config{
+		Provider: provider,
+		Args:     gcp_terraforming.Scope{Project: "fixture-project", Region: "global"}.Args(),
+		Options:  options,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unmatched := replayer.Unmatched(); len(unmatched) != 0 {
+		t.Errorf("requests without recorded responses: %v", unmatched)
+	}
+	if len(result.Diagnostics) != 0 {
+		t.Errorf("unexpected diagnostics %v", result.Diagnostics)
+	}
+	// deleted-allow-all is listed by the API but read as deleted by the plugin
+	reads := plugin.Reads()
+	sort.Strings(reads)
+	expectedReads := []string{
+		"google_compute_firewall.app-allow-https",
+		"google_compute_firewall.default-allow-ssh",
+		"google_compute_firewall.deleted-allow-all",
+		"google_compute_network.default",
+		"google_compute_network.vpc-app",
+	}
+	if !reflect.DeepEqual(reads, expectedReads) {
+		t.Errorf("expected reads %v, got %v", expectedReads, reads)
+	}
+	if resources := len(result.Resources["networks"]) + len(result.Resources["firewall"]); resources != 4 {
+		t.Errorf("expected 4 resources, got %d", resources)
+	}
+
+	compareGolden(t, filepath.Join("test_data", "gcp", "golden"), result.Files)
+}
+
+// TestReplayGCP replays test_data/gcp as a directory written by --record, and compares the generated files with
+// the golden files of TestImportGCP
+func TestReplayGCP(t *testing.T) {
+	t.Setenv("GOOGLE_CLOUD_PROJECT", "")
+	options := DefaultOptions()
+	options.Resources = []string{"networks", "firewall"}
+	options.Connect = true
+	options.Replay = filepath.Join("test_data", "gcp")
+	result, err := Import(context.Background(), Config{
+		Provider: &gcp_terraforming.GCPProvider{},
+		Args:     gcp_terraforming.Scope{Project: "fixture-project", Region: "global"}.Args(),
+		Options:  options,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Diagnostics) != 0 {
+		t.Errorf("unexpected diagnostics %v", result.Diagnostics)
+	}
+	compareGolden(t, filepath.Join("test_data", "gcp", "golden"), result.Files)
+
+	options.Record = t.TempDir()
+	if _, err := Import(context.Background(), Config{Provider: &gcp_terraforming.GCPProvider{}, Args: gcp_terraforming.Scope{Project: "fixture-project", Region: "global"}.Args(), Options: options}); err == nil {
+		t.Error("expected an error with --record and --replay")
+	}
+}
+
+// TestImportGCPRegions imports two regions of a project with --scope-parallelism, they share one plugin configured
+// without a region, so regional resources are only found by the region of their state
+func TestImportGCPRegions(t *testing.T) {
+	t.Setenv("GOOGLE_CLOUD_PROJECT", "")
+	plugin, err := providertest.Load(filepath.Join("test_data", "gcp_regions", "provider.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fixture, err := httpfixture.Load(filepath.Join("test_data", "gcp_regions", "api.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayer, err := httpfixture.NewReplayer(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fsys := terraformoutput.NewMemoryFileSystem()
+	options := DefaultOptions()
+	options.Resources = []string{"subnetworks"}
+	options.Plugin = plugin
+	options.FS = fsys
+	options.ScopeParallelism = 2
+	scopes := []importpipeline.Scope{}
+	for _, scope := range gcp_terraforming.Scopes([]string{"fixture-project"}, []string{"europe-west1", "us-central1"}, "") {
+		provider := &gcp_terraforming.GCPProvider{}
+		provider.SetAPIMiddleware(replayer)
+		scopes = append(scopes, importpipeline.Scope{
+			Name:        scope.Region,
+			Provider:    provider,
+			Args:        scope.Args(),
+			PathPattern: strings.ReplaceAll(options.PathPattern, "{service}", scope.Project+"/{service}/"+scope.Region),
+		})
+	}
+	if err := importpipeline.ImportScopes(context.Background(), options, scopes); err != nil {
+		t.Fatal(err)
+	}
+	if unmatched := replayer.Unmatched(); len(unmatched) != 0 {
+		t.Errorf("requests without recorded responses: %v", unmatched)
+	}
+	if config := plugin.Config(); !config.GetAttr("region").IsNull() {
+		t.Errorf("expected the shared config without a region, got %#v", config)
+	}
+	for region, subnetwork := range map[string]string{"europe-west1": "app-eu", "us-central1": "app-us"} {
+		state, exists := fsys.Files()["google/fixture-project/subnetworks/"+region+"/terraform.tfstate"]
+		if !exists {
+			t.Fatalf("no state of %s in %v", region, fsys.Files())
+		}
+		if id := "projects/fixture-project/regions/" + region + "/subnetworks/" + subnetwork; !strings.Contains(string(state), id) {
+			t.Errorf("state of %s does not contain %s:\n%s", region, id, state)
+		}
+	}
+
+	scopes[1].PathPattern = scopes[0].PathPattern
+	if err := importpipeline.ImportScopes(context.Background(), options, scopes); err == nil {
+		t.Error("expected an error for scopes written to the same directory")
+	}
+}
+
+// compareGolden compares files by slash separated path with the files of a golden directory, -update rewrites it.
+// The lineage of states is replaced by zeros.
+func compareGolden(t *testing.T, dir string, files map[string][]byte) {
+	t.Helper()
+	normalized := map[string][]byte{}
+	for name, data := range files {
+		normalized[name] = lineage.ReplaceAll(data, []byte(`"lineage": "00000000-0000-0000-0000-000000000000"`))
+	}
+	files = normalized
+	if *updateGolden {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Fatal(err)
+		}
+		for name, data := range files {
+			path := filepath.Join(dir, filepath.FromSlash(name))
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, data, 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	golden := map[string][]byte{}
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(dir, path)
+		golden[filepath.ToSlash(name)] = data
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, expected := range golden {
+		data, exists := files[name]
+		if !exists {
+			t.Errorf("%s was not written", name)
+		} else if string(data) != string(expected) {
+			t.Errorf("%s does not match %s, got:\n%s", name, dir, data)
+		}
+	}
+	for name := range files {
+		if _, exists := golden[name]; !exists {
+			t.Errorf("unexpected file %s", name)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package importer runs imports of terraformer import <provider> from Go and returns the generated files,
+// states and report in memory, e.g.
+//
+//	options := importer.DefaultOptions()
+//	options.Resources = []string{"gcs", "networks"}
+//	result, err := importer.Import(ctx, importer.Config{
+//		Provider: &gcp_terraforming.GCPProvider{},
+//		Args:     gcp_terraforming.Scope{Project: "my-project", Region: "global"}.Args(),
+//		Options:  options,
+//	})
+//
+// The provider plugins are started as by the command line, see providerwrapper.
+package importer
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"log/slog"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/importpipeline"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/terraformoutput"
+)
+
+// Config is an import of a provider scope, e.g. a project and region
+type Config struct {
+	// Provider is the provider to import, a new ProviderGenerator per import
+	Provider terraformutils.ProviderGenerator
+	// Args are the args of Provider.Init, e.g. the Args of a gcp_terraforming.Scope
+	Args []string
+	// Options are the flags of terraformer import <provider>, start from DefaultOptions. PathOutput is relative
+	// to Output.
+	Options importpipeline.ImportOptions
+	// Output receives the generated files, they are kept in memory only when nil
+	Output terraformoutput.FileSystem
+	// Logger receives the log of the import and its refresh, the standard logger when nil. The provider plugins
+	// log to the standard logger.
+	Logger *slog.Logger
+}
+
+// Result is the outcome of an import
+type Result struct {
+	// Resources are the imported resources by service, by "" when PathPattern has no {service}
+	Resources map[string][]terraformutils.Resource
+	// Files are the written files by slash separated path, e.g. google/gcs/storage_bucket.tf
+	Files map[string][]byte
+	// States are the terraform.tfstate files of Files by directory, states of remote backends aren't included
+	States map[string][]byte
+	// Report is the outcome of every service and resource
+	Report *terraformutils.ImportReport
+	// Diagnostics are the services and resources which could not be imported
+	Diagnostics []terraformutils.ImportError
+}
+
+// DefaultOptions returns the options of terraformer import <provider> without flags, writing the files of a
+// service to {provider}/{service}/ of the output
+func DefaultOptions() importpipeline.ImportOptions {
+	options := importpipeline.DefaultImportOptions()
+	options.PathOutput = "."
+	return options
+}
+
+// Import runs an import, until ctx is canceled or Options.Timeout passed. The result is returned with the
+// error of a failed import, it holds what was written before the import failed.
+func Import(ctx context.Context, config Config) (*Result, error) {
+	if config.Provider == nil {
+		return nil, errors.New("importer: no provider")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	output := config.Output
+	if output == nil {
+		output = terraformoutput.NewMemoryFileSystem()
+	}
+	files := &recordingFileSystem{FileSystem: output, files: map[string][]byte{}}
+	result := &Result{
+		Resources: map[string][]terraformutils.Resource{},
+		Report:    terraformutils.NewImportReport(),
+	}
+
+	options := withDefaults(config.Options)
+	options.FS = files
+	options.ImportReport = result.Report
+	// --report writes the report to the local file system, the report of the result replaces it
+	options.Report = ""
+	if config.Logger != nil {
+		options.Logger = config.Logger
+	}
+	var resourcesMu sync.Mutex
+	imported := options.Imported
+	options.Imported = func(resources map[string][]terraformutils.Resource) {
+		resourcesMu.Lock()
+		for service, serviceResources := range resources {
+			result.Resources[service] = append(result.Resources[service], serviceResources...)
+		}
+		resourcesMu.Unlock()
+		if imported != nil {
+			imported(resources)
+		}
+	}
+
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+	err := importpipeline.ImportContext(ctx, config.Provider, options, config.Args)
+
+	result.Files = files.Files()
+	result.States = map[string][]byte{}
+	for name, data := range result.Files {
+		if path.Base(name) == "terraform.tfstate" {
+			result.States[path.Dir(name)] = data
+		}
+	}
+	result.Diagnostics = diagnostics(result.Report)
+	return result, err
+}
+
+// withDefaults sets the options which have no usable zero value
+func withDefaults(options importpipeline.ImportOptions) importpipeline.ImportOptions {
+	defaults := DefaultOptions()
+	if options.PathPattern == "" {
+		options.PathPattern = defaults.PathPattern
+	}
+	if options.PathOutput == "" {
+		options.PathOutput = defaults.PathOutput
+	}
+	if options.State == "" {
+		options.State = defaults.State
+	}
+	if options.Output == "" {
+		options.Output = defaults.Output
+	}
+	if options.Layout == "" {
+		options.Layout = defaults.Layout
+	}
+	return options
+}
+
+// diagnostics returns the errors of every category of every scope of a report
+func diagnostics(report *terraformutils.ImportReport) []terraformutils.ImportError {
+	all, _ := terraformutils.ParseFailOn(nil, true)
+	diagnostics := []terraformutils.ImportError{}
+	for _, scope := range report.Scopes {
+		var importErrors *terraformutils.ImportErrors
+		if errors.As(scope.Errors(all), &importErrors) {
+			diagnostics = append(diagnostics, importErrors.Errors...)
+		}
+	}
+	return diagnostics
+}
+
+// recordingFileSystem records the files written to a FileSystem
+type recordingFileSystem struct {
+	terraformoutput.FileSystem
+
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+func recordedPath(name string) string {
+	return strings.TrimPrefix(path.Clean(strings.ReplaceAll(name, "\\", "/")), "./")
+}
+
+// Files returns a copy of the recorded files by path
+func (r *recordingFileSystem) Files() map[string][]byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	files := make(map[string][]byte, len(r.files))
+	for name, data := range r.files {
+		files[name] = append([]byte{}, data...)
+	}
+	return files
+}
+
+func (r *recordingFileSystem) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	if err := r.FileSystem.WriteFile(name, data, perm); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.files[recordedPath(name)] = append([]byte{}, data...)
+	return nil
+}
+
+func (r *recordingFileSystem) Remove(name string) error {
+	if err := r.FileSystem.Remove(name); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.files, recordedPath(name))
+	return nil
+}
+
+func (r *recordingFileSystem) RemoveAll(name string) error {
+	if err := r.FileSystem.RemoveAll(name); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	dir := recordedPath(name)
+	for file := range r.files {
+		if file == dir || dir == "." || strings.HasPrefix(file, dir+"/") {
+			delete(r.files, file)
+		}
+	}
+	return nil
+}
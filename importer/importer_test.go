@@ -0,0 +1,111 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package importer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/importpipeline"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/terraformoutput"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+var errNoCredentials = errors.New("no credentials")
+
+type testProvider struct{}
+
+func (p testProvider) Init(args []string) error                           { return errNoCredentials }
+func (p testProvider) InitService(serviceName string, verbose bool) error { return nil }
+func (p testProvider) GetName() string                                    { return "google" }
+func (p testProvider) GetService() terraformutils.ServiceGenerator        { return nil }
+func (p testProvider) GetConfig() cty.Value                               { return cty.EmptyObjectVal }
+func (p testProvider) GetBasicConfig() cty.Value                          { return cty.EmptyObjectVal }
+func (p testProvider) GetSupportedService() map[string]terraformutils.ServiceGenerator {
+	return nil
+}
+func (p testProvider) GenerateFiles() {}
+func (p testProvider) GetProviderData(arg ...string) map[string]interface{} {
+	return map[string]interface{}{}
+}
+func (p testProvider) GenerateOutputPath() error { return nil }
+func (p testProvider) GetResourceConnections() map[string]map[string][]string {
+	return map[string]map[string][]string{}
+}
+
+func TestImportFailedScope(t *testing.T) {
+	options := DefaultOptions()
+	options.Resources = []string{"networks"}
+	result, err := Import(context.Background(), Config{Provider: testProvider{}, Args: []string{"global", "project"}, Options: options})
+	if !errors.Is(err, errNoCredentials) {
+		t.Fatalf("expected the error of the provider, got %v", err)
+	}
+	if result == nil || len(result.Report.Scopes) != 1 {
+		t.Fatalf("expected the report of the scope, got %+v", result)
+	}
+	scope := result.Report.Scopes[0]
+	if scope.Status != terraformutils.StatusFailed || !reflect.DeepEqual(scope.Args, []string{"global", "project"}) {
+		t.Errorf("unexpected scope %+v", scope)
+	}
+	if len(result.Files) != 0 || len(result.Diagnostics) != 0 {
+		t.Errorf("unexpected files %v and diagnostics %v", result.Files, result.Diagnostics)
+	}
+}
+
+func TestImportCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Import(ctx, Config{Provider: testProvider{}}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestWithDefaults(t *testing.T) {
+	options := withDefaults(importpipeline.ImportOptions{Resources: []string{"gcs"}})
+	if options.PathPattern != importpipeline.DefaultPathPattern || options.PathOutput != "." || options.State != importpipeline.DefaultState ||
+		options.Output != "hcl" || options.Layout != importpipeline.LayoutServices {
+		t.Errorf("unexpected options %+v", options)
+	}
+	if path := importpipeline.Path(options.PathPattern, "google", "gcs", options.PathOutput); recordedPath(path) != "google/gcs" {
+		t.Errorf("expected files relative to the output, got %s", path)
+	}
+}
+
+func TestRecordingFileSystem(t *testing.T) {
+	output := terraformoutput.NewMemoryFileSystem()
+	files := &recordingFileSystem{FileSystem: output, files: map[string][]byte{}}
+	for _, name := range []string{"./google/gcs/provider.tf", "google/gcs/terraform.tfstate", "google/networks/provider.tf"} {
+		if err := files.WriteFile(name, []byte(name), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := files.RemoveAll("google/networks"); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string][]byte{
+		"google/gcs/provider.tf":       []byte("./google/gcs/provider.tf"),
+		"google/gcs/terraform.tfstate": []byte("google/gcs/terraform.tfstate"),
+	}
+	if !reflect.DeepEqual(files.Files(), expected) {
+		t.Errorf("unexpected recorded files %v", files.Files())
+	}
+	if !reflect.DeepEqual(output.Files(), expected) {
+		t.Errorf("unexpected output files %v", output.Files())
+	}
+}
@@ -0,0 +1,29 @@
+resource "google_compute_firewall" "tfer--app-allow-https" {
+  direction     = "INGRESS"
+  disabled      = "false"
+  name          = "app-allow-https"
+  network       = data.terraform_remote_state.networks.outputs.google_compute_network_tfer--vpc-app_self_link
+  priority      = "1000"
+  source_ranges = ["10.0.0.0/8", "35.191.0.0/16"]
+  target_tags   = ["https-server"]
+
+  allow {
+    ports    = ["443", "8443"]
+    protocol = "tcp"
+  }
+}
+
+resource "google_compute_firewall" "tfer--default-allow-ssh" {
+  description   = "Allow SSH from anywhere"
+  direction     = "INGRESS"
+  disabled      = "false"
+  name          = "default-allow-ssh"
+  network       = data.terraform_remote_state.networks.outputs.google_compute_network_tfer--default_self_link
+  priority      = "65534"
+  source_ranges = ["0.0.0.0/0"]
+
+  allow {
+    ports    = ["22"]
+    protocol = "tcp"
+  }
+}
@@ -0,0 +1,7 @@
+output "google_compute_firewall_tfer--app-allow-https_self_link" {
+  value = google_compute_firewall.tfer--app-allow-https.self_link
+}
+
+output "google_compute_firewall_tfer--default-allow-ssh_self_link" {
+  value = google_compute_firewall.tfer--default-allow-ssh.self_link
+}
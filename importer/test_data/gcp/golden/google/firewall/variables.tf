@@ -0,0 +1,6 @@
+data "terraform_remote_state" "networks" {
+  backend = "local"
+  config = {
+    path = "../../.././google/networks/terraform.tfstate"
+  }
+}
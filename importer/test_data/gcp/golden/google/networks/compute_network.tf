@@ -0,0 +1,16 @@
+resource "google_compute_network" "tfer--default" {
+  auto_create_subnetworks         = "true"
+  delete_default_routes_on_create = "false"
+  description                     = "Default network for the project"
+  mtu                             = "1460"
+  name                            = "default"
+  routing_mode                    = "REGIONAL"
+}
+
+resource "google_compute_network" "tfer--vpc-app" {
+  auto_create_subnetworks         = "false"
+  delete_default_routes_on_create = "false"
+  mtu                             = "1500"
+  name                            = "vpc-app"
+  routing_mode                    = "GLOBAL"
+}
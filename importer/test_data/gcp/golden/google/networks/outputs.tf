@@ -0,0 +1,7 @@
+output "google_compute_network_tfer--default_self_link" {
+  value = google_compute_network.tfer--default.self_link
+}
+
+output "google_compute_network_tfer--vpc-app_self_link" {
+  value = google_compute_network.tfer--vpc-app.self_link
+}
@@ -0,0 +1,11 @@
+provider "google" {
+  project = "fixture-project"
+}
+
+terraform {
+  required_providers {
+    google = {
+      version = ""
+    }
+  }
+}
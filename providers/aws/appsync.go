@@ -1,8 +1,6 @@
 package aws
 
 import (
-	"context"
-
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
 	"github.com/aws/aws-sdk-go-v2/service/appsync"
 )
@@ -21,7 +19,7 @@ func (g *AppSyncGenerator) InitResources() error {
 
 	var nextToken *string
 	for {
-		apis, err := svc.ListGraphqlApis(context.TODO(), &appsync.ListGraphqlApisInput{
+		apis, err := svc.ListGraphqlApis(g.Context(), &appsync.ListGraphqlApisInput{
 			NextToken: nextToken,
 		})
 		if err != nil {
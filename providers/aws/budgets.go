@@ -15,7 +15,6 @@
 package aws
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
@@ -53,7 +52,7 @@ func (g *BudgetsGenerator) InitResources() error {
 		return err
 	}
 
-	output, err := budgetsSvc.DescribeBudgets(context.TODO(), &budgets.DescribeBudgetsInput{AccountId: account})
+	output, err := budgetsSvc.DescribeBudgets(g.Context(), &budgets.DescribeBudgetsInput{AccountId: account})
 	if err != nil {
 		return err
 	}
@@ -15,7 +15,6 @@
 package aws
 
 import (
-	"context"
 	"log"
 
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
@@ -33,7 +32,7 @@ func (g *DirectConnectGenerator) getDirectConnectGateways(svc *directconnect.Cli
 	input := &directconnect.DescribeDirectConnectGatewaysInput{}
 	for {
 		// Fetch a page of results
-		output, err := svc.DescribeDirectConnectGateways(context.TODO(), input)
+		output, err := svc.DescribeDirectConnectGateways(g.Context(), input)
 		if err != nil {
 			return err
 		}
@@ -62,7 +61,7 @@ func (g *DirectConnectGenerator) getDirectConnectGateways(svc *directconnect.Cli
 
 func (g *DirectConnectGenerator) getDirectConnectConnections(svc *directconnect.Client) error {
 	input := &directconnect.DescribeConnectionsInput{}
-	output, err := svc.DescribeConnections(context.TODO(), input)
+	output, err := svc.DescribeConnections(g.Context(), input)
 	if err != nil {
 		return err
 	}
@@ -81,7 +80,7 @@ func (g *DirectConnectGenerator) getDirectConnectConnections(svc *directconnect.
 
 func (g *DirectConnectGenerator) getDirectConnectVritualInterfaces(svc *directconnect.Client) error {
 	input := &directconnect.DescribeVirtualInterfacesInput{}
-	output, err := svc.DescribeVirtualInterfaces(context.TODO(), input)
+	output, err := svc.DescribeVirtualInterfaces(g.Context(), input)
 	if err != nil {
 		return err
 	}
@@ -15,7 +15,6 @@
 package aws
 
 import (
-	"context"
 	"fmt"
 	"log"
 	"strings"
@@ -72,7 +71,7 @@ func (g *IamGenerator) InitResources() error {
 func (g *IamGenerator) getRoles(svc *iam.Client) error {
 	p := iam.NewListRolesPaginator(svc, &iam.ListRolesInput{})
 	for p.HasMorePages() {
-		page, err := p.NextPage(context.TODO())
+		page, err := p.NextPage(g.Context())
 		if err != nil {
 			return err
 		}
@@ -86,7 +85,7 @@ func (g *IamGenerator) getRoles(svc *iam.Client) error {
 				IamAllowEmptyValues))
 			rolePoliciesPage := iam.NewListRolePoliciesPaginator(svc, &iam.ListRolePoliciesInput{RoleName: role.RoleName})
 			for rolePoliciesPage.HasMorePages() {
-				rolePoliciesNextPage, err := rolePoliciesPage.NextPage(context.TODO())
+				rolePoliciesNextPage, err := rolePoliciesPage.NextPage(g.Context())
 				if err != nil {
 					log.Println(err)
 					continue
@@ -104,7 +103,7 @@ func (g *IamGenerator) getRoles(svc *iam.Client) error {
 				RoleName: &roleName,
 			})
 			for roleAttachedPoliciesPage.HasMorePages() {
-				roleAttachedPoliciesNextPage, err := roleAttachedPoliciesPage.NextPage(context.TODO())
+				roleAttachedPoliciesNextPage, err := roleAttachedPoliciesPage.NextPage(g.Context())
 				if err != nil {
 					log.Println(err)
 					continue
@@ -131,7 +130,7 @@ func (g *IamGenerator) getRoles(svc *iam.Client) error {
 func (g *IamGenerator) getUsers(svc *iam.Client) error {
 	p := iam.NewListUsersPaginator(svc, &iam.ListUsersInput{})
 	for p.HasMorePages() {
-		page, err := p.NextPage(context.TODO())
+		page, err := p.NextPage(g.Context())
 		if err != nil {
 			return err
 		}
@@ -171,7 +170,7 @@ func (g *IamGenerator) getUsers(svc *iam.Client) error {
 func (g *IamGenerator) getUserGroup(svc *iam.Client, userName *string) error {
 	p := iam.NewListGroupsForUserPaginator(svc, &iam.ListGroupsForUserInput{UserName: userName})
 	for p.HasMorePages() {
-		page, err := p.NextPage(context.TODO())
+		page, err := p.NextPage(g.Context())
 		if err != nil {
 			return err
 		}
@@ -198,7 +197,7 @@ func (g *IamGenerator) getUserGroup(svc *iam.Client, userName *string) error {
 func (g *IamGenerator) getUserPolices(svc *iam.Client, userName *string) error {
 	p := iam.NewListUserPoliciesPaginator(svc, &iam.ListUserPoliciesInput{UserName: userName})
 	for p.HasMorePages() {
-		page, err := p.NextPage(context.TODO())
+		page, err := p.NextPage(g.Context())
 		if err != nil {
 			return err
 		}
@@ -222,7 +221,7 @@ func (g *IamGenerator) getUserPolicyAttachment(svc *iam.Client, userName *string
 		UserName: userName,
 	})
 	for p.HasMorePages() {
-		page, err := p.NextPage(context.TODO())
+		page, err := p.NextPage(g.Context())
 		if err != nil {
 			return err
 		}
@@ -246,7 +245,7 @@ func (g *IamGenerator) getUserPolicyAttachment(svc *iam.Client, userName *string
 func (g *IamGenerator) getPolicies(svc *iam.Client) error {
 	p := iam.NewListPoliciesPaginator(svc, &iam.ListPoliciesInput{Scope: types.PolicyScopeTypeLocal})
 	for p.HasMorePages() {
-		page, err := p.NextPage(context.TODO())
+		page, err := p.NextPage(g.Context())
 		if err != nil {
 			return err
 		}
@@ -268,7 +267,7 @@ func (g *IamGenerator) getPolicies(svc *iam.Client) error {
 func (g *IamGenerator) getGroups(svc *iam.Client) error {
 	p := iam.NewListGroupsPaginator(svc, &iam.ListGroupsInput{})
 	for p.HasMorePages() {
-		page, err := p.NextPage(context.TODO())
+		page, err := p.NextPage(g.Context())
 		if err != nil {
 			return err
 		}
@@ -290,7 +289,7 @@ func (g *IamGenerator) getGroups(svc *iam.Client) error {
 func (g *IamGenerator) getGroupPolicies(svc *iam.Client, group types.Group) {
 	groupPoliciesPage := iam.NewListGroupPoliciesPaginator(svc, &iam.ListGroupPoliciesInput{GroupName: group.GroupName})
 	for groupPoliciesPage.HasMorePages() {
-		groupPoliciesNextPage, err := groupPoliciesPage.NextPage(context.TODO())
+		groupPoliciesNextPage, err := groupPoliciesPage.NextPage(g.Context())
 		if err != nil {
 			log.Println(err)
 			continue
@@ -314,7 +313,7 @@ func (g *IamGenerator) getAttachedGroupPolicies(svc *iam.Client, group types.Gro
 	groupAttachedPoliciesPage := iam.NewListAttachedGroupPoliciesPaginator(svc,
 		&iam.ListAttachedGroupPoliciesInput{GroupName: group.GroupName})
 	for groupAttachedPoliciesPage.HasMorePages() {
-		groupAttachedPoliciesNextPage, err := groupAttachedPoliciesPage.NextPage(context.TODO())
+		groupAttachedPoliciesNextPage, err := groupAttachedPoliciesPage.NextPage(g.Context())
 		if err != nil {
 			log.Println(err)
 			continue
@@ -342,7 +341,7 @@ func (g *IamGenerator) getAttachedGroupPolicies(svc *iam.Client, group types.Gro
 func (g *IamGenerator) getInstanceProfiles(svc *iam.Client) error {
 	p := iam.NewListInstanceProfilesPaginator(svc, &iam.ListInstanceProfilesInput{})
 	for p.HasMorePages() {
-		page, err := p.NextPage(context.TODO())
+		page, err := p.NextPage(g.Context())
 		if err != nil {
 			return err
 		}
@@ -367,7 +366,7 @@ func (g *IamGenerator) getInstanceProfiles(svc *iam.Client) error {
 func (g *IamGenerator) getUserAccessKey(svc *iam.Client, userName *string, userID string) error {
 	p := iam.NewListAccessKeysPaginator(svc, &iam.ListAccessKeysInput{UserName: userName})
 	for p.HasMorePages() {
-		page, err := p.NextPage(context.TODO())
+		page, err := p.NextPage(g.Context())
 		if err != nil {
 			return err
 		}
@@ -15,8 +15,6 @@
 package aws
 
 import (
-	"context"
-
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
 	"github.com/aws/aws-sdk-go-v2/service/kinesis"
 )
@@ -54,7 +52,7 @@ func (g *KinesisGenerator) InitResources() error {
 	var err error
 
 	for results == nil || *results.HasMoreStreams {
-		results, err = svc.ListStreams(context.TODO(), &request)
+		results, err = svc.ListStreams(g.Context(), &request)
 		if err != nil {
 			return err
 		}
@@ -15,8 +15,6 @@
 package aws
 
 import (
-	"context"
-
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
 	"github.com/aws/aws-sdk-go-v2/service/mediapackage"
 )
@@ -36,7 +34,7 @@ func (g *MediaPackageGenerator) InitResources() error {
 	p := mediapackage.NewListChannelsPaginator(svc, &mediapackage.ListChannelsInput{})
 	var resources []terraformutils.Resource
 	for p.HasMorePages() {
-		page, err := p.NextPage(context.TODO())
+		page, err := p.NextPage(g.Context())
 		if err != nil {
 			return err
 		}
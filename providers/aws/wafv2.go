@@ -15,8 +15,6 @@
 package aws
 
 import (
-	"context"
-
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
 	"github.com/aws/aws-sdk-go-v2/service/wafv2"
 	"github.com/aws/aws-sdk-go-v2/service/wafv2/types"
@@ -64,7 +62,7 @@ func (g *Wafv2Generator) InitResources() error {
 }
 
 func (g *Wafv2Generator) loadWebACL(svc *wafv2.Client) error {
-	output, err := svc.ListWebACLs(context.TODO(), &wafv2.ListWebACLsInput{Scope: g.scope})
+	output, err := svc.ListWebACLs(g.Context(), &wafv2.ListWebACLsInput{Scope: g.scope})
 	if err != nil {
 		return err
 	}
@@ -95,7 +93,7 @@ func (g *Wafv2Generator) loadWebACL(svc *wafv2.Client) error {
 
 func (g *Wafv2Generator) loadWebACLAssociations(svc *wafv2.Client, webACLArn *string) error {
 	for _, resourceType := range types.ResourceTypeApplicationLoadBalancer.Values() {
-		output, err := svc.ListResourcesForWebACL(context.TODO(),
+		output, err := svc.ListResourcesForWebACL(g.Context(),
 			&wafv2.ListResourcesForWebACLInput{WebACLArn: webACLArn, ResourceType: resourceType})
 		if err != nil {
 			return err
@@ -119,7 +117,7 @@ func (g *Wafv2Generator) loadWebACLAssociations(svc *wafv2.Client, webACLArn *st
 }
 
 func (g *Wafv2Generator) loadIPSet(svc *wafv2.Client) error {
-	output, err := svc.ListIPSets(context.TODO(), &wafv2.ListIPSetsInput{Scope: g.scope})
+	output, err := svc.ListIPSets(g.Context(), &wafv2.ListIPSetsInput{Scope: g.scope})
 	if err != nil {
 		return err
 	}
@@ -141,7 +139,7 @@ func (g *Wafv2Generator) loadIPSet(svc *wafv2.Client) error {
 }
 
 func (g *Wafv2Generator) loadRegexPatternSets(svc *wafv2.Client) error {
-	output, err := svc.ListRegexPatternSets(context.TODO(), &wafv2.ListRegexPatternSetsInput{Scope: g.scope})
+	output, err := svc.ListRegexPatternSets(g.Context(), &wafv2.ListRegexPatternSetsInput{Scope: g.scope})
 	if err != nil {
 		return err
 	}
@@ -163,7 +161,7 @@ func (g *Wafv2Generator) loadRegexPatternSets(svc *wafv2.Client) error {
 }
 
 func (g *Wafv2Generator) loadWafRuleGroups(svc *wafv2.Client) error {
-	output, err := svc.ListRuleGroups(context.TODO(), &wafv2.ListRuleGroupsInput{Scope: g.scope})
+	output, err := svc.ListRuleGroups(g.Context(), &wafv2.ListRuleGroupsInput{Scope: g.scope})
 	if err != nil {
 		return err
 	}
@@ -186,7 +184,7 @@ func (g *Wafv2Generator) loadWafRuleGroups(svc *wafv2.Client) error {
 }
 
 func (g *Wafv2Generator) loadWebACLLoggingConfiguration(svc *wafv2.Client) error {
-	output, err := svc.ListLoggingConfigurations(context.TODO(), &wafv2.ListLoggingConfigurationsInput{Scope: g.scope})
+	output, err := svc.ListLoggingConfigurations(g.Context(), &wafv2.ListLoggingConfigurationsInput{Scope: g.scope})
 	if err != nil {
 		return err
 	}
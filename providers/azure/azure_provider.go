@@ -15,7 +15,6 @@
 package azure
 
 import (
-	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -98,7 +97,7 @@ func (p *AzureProvider) getAuthorizer() (autorest.Authorizer, error) {
 		return nil, fmt.Errorf("unable to configure OAuthConfig for tenant %s", p.config.TenantID)
 	}
 	sender := sender.BuildSender("terraformer")
-	ctx := context.Background()
+	ctx := p.Context()
 	var auth autorest.Authorizer
 
 	if p.config.UseMicrosoftGraph {
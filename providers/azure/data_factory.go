@@ -15,7 +15,6 @@
 package azure
 
 import (
-	"context"
 	"fmt"
 	"log"
 	"reflect"
@@ -134,7 +133,7 @@ func (az *DataFactoryGenerator) listFactories() ([]datafactory.Factory, error) {
 		iterator datafactory.FactoryListResponseIterator
 		err      error
 	)
-	ctx := context.Background()
+	ctx := az.Context()
 	if resourceGroup != "" {
 		iterator, err = client.ListByResourceGroupComplete(ctx, resourceGroup)
 	} else {
@@ -183,7 +182,7 @@ func (az *DataFactoryGenerator) createIntegrationRuntimesResources(dataFactories
 	subscriptionID, _, authorizer, resourceManagerEndpoint := az.getClientArgs()
 	client := datafactory.NewIntegrationRuntimesClientWithBaseURI(resourceManagerEndpoint, subscriptionID)
 	client.Authorizer = authorizer
-	ctx := context.Background()
+	ctx := az.Context()
 	var resources []terraformutils.Resource
 	for _, factory := range dataFactories {
 		id, err := ParseAzureResourceID(*factory.ID)
@@ -211,7 +210,7 @@ func (az *DataFactoryGenerator) createLinkedServiceResources(dataFactories []dat
 	subscriptionID, _, authorizer, resourceManagerEndpoint := az.getClientArgs()
 	client := datafactory.NewLinkedServicesClientWithBaseURI(resourceManagerEndpoint, subscriptionID)
 	client.Authorizer = authorizer
-	ctx := context.Background()
+	ctx := az.Context()
 	var resources []terraformutils.Resource
 	for _, factory := range dataFactories {
 		id, err := ParseAzureResourceID(*factory.ID)
@@ -238,7 +237,7 @@ func (az *DataFactoryGenerator) createPipelineResources(dataFactories []datafact
 	subscriptionID, _, authorizer, resourceManagerEndpoint := az.getClientArgs()
 	client := datafactory.NewPipelinesClientWithBaseURI(resourceManagerEndpoint, subscriptionID)
 	client.Authorizer = authorizer
-	ctx := context.Background()
+	ctx := az.Context()
 	var resources []terraformutils.Resource
 	for _, factory := range dataFactories {
 		id, err := ParseAzureResourceID(*factory.ID)
@@ -265,7 +264,7 @@ func (az *DataFactoryGenerator) createPipelineTriggerScheduleResources(dataFacto
 	subscriptionID, _, authorizer, resourceManagerEndpoint := az.getClientArgs()
 	client := datafactory.NewTriggersClientWithBaseURI(resourceManagerEndpoint, subscriptionID)
 	client.Authorizer = authorizer
-	ctx := context.Background()
+	ctx := az.Context()
 	var resources []terraformutils.Resource
 	for _, factory := range dataFactories {
 		id, err := ParseAzureResourceID(*factory.ID)
@@ -292,7 +291,7 @@ func (az *DataFactoryGenerator) createDataFlowResources(dataFactories []datafact
 	subscriptionID, _, authorizer, resourceManagerEndpoint := az.getClientArgs()
 	client := datafactory.NewDataFlowsClientWithBaseURI(resourceManagerEndpoint, subscriptionID)
 	client.Authorizer = authorizer
-	ctx := context.Background()
+	ctx := az.Context()
 	var resources []terraformutils.Resource
 	for _, factory := range dataFactories {
 		id, err := ParseAzureResourceID(*factory.ID)
@@ -319,7 +318,7 @@ func (az *DataFactoryGenerator) createPipelineDatasetResources(dataFactories []d
 	subscriptionID, _, authorizer, resourceManagerEndpoint := az.getClientArgs()
 	client := datafactory.NewDatasetsClientWithBaseURI(resourceManagerEndpoint, subscriptionID)
 	client.Authorizer = authorizer
-	ctx := context.Background()
+	ctx := az.Context()
 	var resources []terraformutils.Resource
 	for _, factory := range dataFactories {
 		id, err := ParseAzureResourceID(*factory.ID)
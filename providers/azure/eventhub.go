@@ -15,7 +15,6 @@
 package azure
 
 import (
-	"context"
 	"log"
 
 	"github.com/Azure/azure-sdk-for-go/services/eventhub/mgmt/2017-04-01/eventhub"
@@ -33,7 +32,7 @@ func (az *EventHubGenerator) listNamespaces() ([]eventhub.EHNamespace, error) {
 		iterator eventhub.EHNamespaceListResultIterator
 		err      error
 	)
-	ctx := context.Background()
+	ctx := az.Context()
 	if resourceGroup != "" {
 		iterator, err = client.ListByResourceGroupComplete(ctx, resourceGroup)
 	} else {
@@ -62,7 +61,7 @@ func (az *EventHubGenerator) appendEventHubs(namespace *eventhub.EHNamespace, na
 	subscriptionID, _, authorizer, resourceManagerEndpoint := az.getClientArgs()
 	client := eventhub.NewEventHubsClientWithBaseURI(resourceManagerEndpoint, subscriptionID)
 	client.Authorizer = authorizer
-	ctx := context.Background()
+	ctx := az.Context()
 	iterator, err := client.ListByNamespaceComplete(ctx, namespaceRg.ResourceGroup, *namespace.Name, nil, nil)
 	if err != nil {
 		return err
@@ -87,7 +86,7 @@ func (az *EventHubGenerator) appendConsumerGroups(namespace *eventhub.EHNamespac
 	subscriptionID, _, authorizer, resourceManagerEndpoint := az.getClientArgs()
 	client := eventhub.NewConsumerGroupsClientWithBaseURI(resourceManagerEndpoint, subscriptionID)
 	client.Authorizer = authorizer
-	ctx := context.Background()
+	ctx := az.Context()
 	iterator, err := client.ListByEventHubComplete(ctx, namespaceRg.ResourceGroup, *namespace.Name, eventHubName, nil, nil)
 	if err != nil {
 		return err
@@ -107,7 +106,7 @@ func (az *EventHubGenerator) appendAuthorizationRules(namespace *eventhub.EHName
 	subscriptionID, _, authorizer, resourceManagerEndpoint := az.getClientArgs()
 	client := eventhub.NewNamespacesClientWithBaseURI(resourceManagerEndpoint, subscriptionID)
 	client.Authorizer = authorizer
-	ctx := context.Background()
+	ctx := az.Context()
 	iterator, err := client.ListAuthorizationRulesComplete(ctx, namespaceRg.ResourceGroup, *namespace.Name)
 	if err != nil {
 		return err
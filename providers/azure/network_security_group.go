@@ -15,7 +15,6 @@
 package azure
 
 import (
-	"context"
 	"log"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-03-01/network"
@@ -33,7 +32,7 @@ func (az *NetworkSecurityGroupGenerator) listResources() ([]network.SecurityGrou
 		iterator network.SecurityGroupListResultIterator
 		err      error
 	)
-	ctx := context.Background()
+	ctx := az.Context()
 	if resourceGroup != "" {
 		iterator, err = client.ListComplete(ctx, resourceGroup)
 	} else {
@@ -62,7 +61,7 @@ func (az *NetworkSecurityGroupGenerator) appendRules(parent *network.SecurityGro
 	subscriptionID, _, authorizer, resourceManagerEndpoint := az.getClientArgs()
 	client := network.NewSecurityRulesClientWithBaseURI(resourceManagerEndpoint, subscriptionID)
 	client.Authorizer = authorizer
-	ctx := context.Background()
+	ctx := az.Context()
 	iterator, err := client.ListComplete(ctx, resourceGroupID.ResourceGroup, *parent.Name)
 	if err != nil {
 		return err
@@ -15,7 +15,6 @@
 package azure
 
 import (
-	"context"
 	"log"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
@@ -33,7 +32,7 @@ func (az *PrivateEndpointGenerator) listServices() ([]network.PrivateLinkService
 		iterator network.PrivateLinkServiceListResultIterator
 		err      error
 	)
-	ctx := context.Background()
+	ctx := az.Context()
 	if resourceGroup != "" {
 		iterator, err = client.ListComplete(ctx, resourceGroup)
 	} else {
@@ -66,7 +65,7 @@ func (az *PrivateEndpointGenerator) listEndpoints() ([]network.PrivateEndpoint,
 		iterator network.PrivateEndpointListResultIterator
 		err      error
 	)
-	ctx := context.Background()
+	ctx := az.Context()
 	if resourceGroup != "" {
 		iterator, err = client.ListComplete(ctx, resourceGroup)
 	} else {
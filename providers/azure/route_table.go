@@ -15,7 +15,6 @@
 package azure
 
 import (
-	"context"
 	"log"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-03-01/network"
@@ -33,7 +32,7 @@ func (az *RouteTableGenerator) listResources() ([]network.RouteTable, error) {
 		iterator network.RouteTableListResultIterator
 		err      error
 	)
-	ctx := context.Background()
+	ctx := az.Context()
 	if resourceGroup != "" {
 		iterator, err = client.ListComplete(ctx, resourceGroup)
 	} else {
@@ -62,7 +61,7 @@ func (az *RouteTableGenerator) appendRoutes(parent *network.RouteTable, resource
 	subscriptionID, _, authorizer, resourceManagerEndpoint := az.getClientArgs()
 	client := network.NewRoutesClientWithBaseURI(resourceManagerEndpoint, subscriptionID)
 	client.Authorizer = authorizer
-	ctx := context.Background()
+	ctx := az.Context()
 	iterator, err := client.ListComplete(ctx, resourceGroupID.ResourceGroup, *parent.Name)
 	if err != nil {
 		return err
@@ -86,7 +85,7 @@ func (az *RouteTableGenerator) listRouteFilters() ([]network.RouteFilter, error)
 		iterator network.RouteFilterListResultIterator
 		err      error
 	)
-	ctx := context.Background()
+	ctx := az.Context()
 	if resourceGroup != "" {
 		iterator, err = client.ListByResourceGroupComplete(ctx, resourceGroup)
 	} else {
@@ -15,7 +15,6 @@
 package azure
 
 import (
-	"context"
 	"log"
 
 	"github.com/Azure/azure-sdk-for-go/services/preview/synapse/2019-06-01-preview/managedvirtualnetwork"
@@ -35,7 +34,7 @@ func (az *SynapseGenerator) listWorkspaces() ([]synapse.Workspace, error) {
 		iterator synapse.WorkspaceInfoListResultIterator
 		err      error
 	)
-	ctx := context.Background()
+	ctx := az.Context()
 	if resourceGroup != "" {
 		iterator, err = client.ListByResourceGroupComplete(ctx, resourceGroup)
 	} else {
@@ -64,7 +63,7 @@ func (az *SynapseGenerator) appendSQLPools(workspace *synapse.Workspace, workspa
 	subscriptionID, _, authorizer, resourceManagerEndpoint := az.getClientArgs()
 	client := synapse.NewSQLPoolsClientWithBaseURI(resourceManagerEndpoint, subscriptionID)
 	client.Authorizer = authorizer
-	ctx := context.Background()
+	ctx := az.Context()
 	iterator, err := client.ListByWorkspaceComplete(ctx, workspaceRg.ResourceGroup, *workspace.Name)
 	if err != nil {
 		return err
@@ -84,7 +83,7 @@ func (az *SynapseGenerator) appendSparkPools(workspace *synapse.Workspace, works
 	subscriptionID, _, authorizer, resourceManagerEndpoint := az.getClientArgs()
 	client := synapse.NewBigDataPoolsClientWithBaseURI(resourceManagerEndpoint, subscriptionID)
 	client.Authorizer = authorizer
-	ctx := context.Background()
+	ctx := az.Context()
 	iterator, err := client.ListByWorkspaceComplete(ctx, workspaceRg.ResourceGroup, *workspace.Name)
 	if err != nil {
 		return err
@@ -104,7 +103,7 @@ func (az *SynapseGenerator) appendFirewallRule(workspace *synapse.Workspace, wor
 	subscriptionID, _, authorizer, resourceManagerEndpoint := az.getClientArgs()
 	client := synapse.NewIPFirewallRulesClientWithBaseURI(resourceManagerEndpoint, subscriptionID)
 	client.Authorizer = authorizer
-	ctx := context.Background()
+	ctx := az.Context()
 	iterator, err := client.ListByWorkspaceComplete(ctx, workspaceRg.ResourceGroup, *workspace.Name)
 	if err != nil {
 		return err
@@ -133,7 +132,7 @@ func (az *SynapseGenerator) appendManagedPrivateEndpoint(workspace *synapse.Work
 	// ManagedPrivateEndpointsClient does not have a ...WithBaseURI function, why is this different?
 	client := managedvirtualnetwork.NewManagedPrivateEndpointsClient(subscriptionID)
 	client.Authorizer = authorizer
-	ctx := context.Background()
+	ctx := az.Context()
 	iterator, err := client.ListComplete(ctx, virtualNetworkName)
 	if err != nil {
 		return err
@@ -157,7 +156,7 @@ func (az *SynapseGenerator) listPrivateLinkHubs() ([]synapse.PrivateLinkHub, err
 		iterator synapse.PrivateLinkHubInfoListResultIterator
 		err      error
 	)
-	ctx := context.Background()
+	ctx := az.Context()
 	if resourceGroup != "" {
 		iterator, err = client.ListByResourceGroupComplete(ctx, resourceGroup)
 	} else {
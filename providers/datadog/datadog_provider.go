@@ -85,7 +85,7 @@ func (p *DatadogProvider) Init(args []string) error {
 
 	// Initialize the Datadog V1 API client
 	auth := context.WithValue(
-		context.Background(),
+		p.Context(),
 		datadog.ContextAPIKeys,
 		map[string]datadog.APIKey{
 			"apiKeyAuth": {
@@ -70,8 +70,8 @@ func (g *AddressesGenerator) InitResources() error {
 		return nil
 	}
 
-	ctx := context.Background()
-	computeService, err := compute.NewService(ctx)
+	ctx := g.Context()
+	computeService, err := compute.NewService(ctx, g.ClientOptions()...)
 	if err != nil {
 		return err
 	}
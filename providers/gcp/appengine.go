@@ -36,8 +36,8 @@ func (g *AppEngineGenerator) InitResources() error {
 	}
 
 	project := g.GetArgs()["project"].(string)
-	ctx := context.Background()
-	appengineService, err := appengine.NewService(ctx)
+	ctx := g.Context()
+	appengineService, err := appengine.NewService(ctx, g.ClientOptions()...)
 	if err != nil {
 		return fmt.Errorf("failed to create appengine service: %w", err)
 	}
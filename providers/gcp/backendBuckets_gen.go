@@ -68,8 +68,8 @@ func (g *BackendBucketsGenerator) InitResources() error {
 		return nil
 	}
 
-	ctx := context.Background()
-	computeService, err := compute.NewService(ctx)
+	ctx := g.Context()
+	computeService, err := compute.NewService(ctx, g.ClientOptions()...)
 	if err != nil {
 		return err
 	}
@@ -67,8 +67,8 @@ func (g *BackendServicesGenerator) InitResources() error {
 		return nil
 	}
 
-	ctx := context.Background()
-	computeService, err := compute.NewService(ctx)
+	ctx := g.Context()
+	computeService, err := compute.NewService(ctx, g.ClientOptions()...)
 	if err != nil {
 		return err
 	}
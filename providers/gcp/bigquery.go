@@ -98,8 +98,8 @@ func (g *BigQueryGenerator) InitResources() error {
 		return nil
 	}
 
-	ctx := context.Background()
-	bigQueryService, err := bigquery.NewService(ctx)
+	ctx := g.Context()
+	bigQueryService, err := bigquery.NewService(ctx, g.ClientOptions()...)
 	if err != nil {
 		return err
 	}
@@ -100,8 +100,8 @@ func (g *CloudFunctionsGenerator) InitResources() error {
 		return nil
 	}
 
-	ctx := context.Background()
-	cloudfunctionsService, err := cloudfunctions.NewService(ctx)
+	ctx := g.Context()
+	cloudfunctionsService, err := cloudfunctions.NewService(ctx, g.ClientOptions()...)
 	if err != nil {
 		return err
 	}
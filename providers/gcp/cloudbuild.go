@@ -23,17 +23,17 @@ type CloudBuildGenerator struct {
 
 // InitResources generates TerraformResources from GCP API.
 func (g *CloudBuildGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	project := g.GetArgs()["project"].(string)
 
 	// v1 client
-	c, err := cloudbuild.NewClient(ctx)
+	c, err := cloudbuild.NewClient(ctx, g.GRPCClientOptions()...)
 	if err != nil {
 		return err
 	}
 
 	// v2 client
-	c2, err := cloudbuildv2.NewRepositoryManagerClient(ctx)
+	c2, err := cloudbuildv2.NewRepositoryManagerClient(ctx, g.GRPCClientOptions()...)
 	if err != nil {
 		return err
 	}
@@ -181,8 +181,8 @@ func (g *CloudDNSGenerator) InitResources() error {
 	}
 
 	project := g.GetArgs()["project"].(string)
-	ctx := context.Background()
-	svc, err := dns.NewService(ctx)
+	ctx := g.Context()
+	svc, err := dns.NewService(ctx, g.ClientOptions()...)
 	if err != nil {
 		return err
 	}
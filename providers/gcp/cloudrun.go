@@ -39,13 +39,13 @@ func (g *CloudRunGenerator) InitResources() error {
 	project := g.GetArgs()["project"].(string)
 	location := g.GetArgs()["region"].(compute.Region).Name
 
-	ctx := context.Background()
+	ctx := g.Context()
 
-	runService, err := run.NewService(ctx)
+	runService, err := run.NewService(ctx, g.ClientOptions()...)
 	if err != nil {
 		return fmt.Errorf("failed to create cloud run service: %w", err)
 	}
-	runServicev2, err := runv2.NewService(ctx)
+	runServicev2, err := runv2.NewService(ctx, g.ClientOptions()...)
 	if err != nil {
 		return fmt.Errorf("failed to create cloud run v2 service: %w", err)
 	}
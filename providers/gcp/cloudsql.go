@@ -15,7 +15,6 @@
 package gcp
 
 import (
-	"context"
 	"fmt"
 	"log"
 	"strings"
@@ -178,8 +177,8 @@ func (g *CloudSQLGenerator) loadSslCerts(svc *sqladmin.Service, instanceName, pr
 // Need dbinstance name as ID for terraform resource
 func (g *CloudSQLGenerator) InitResources() error {
 	project := g.GetArgs()["project"].(string)
-	ctx := context.Background()
-	svc, err := sqladmin.NewService(ctx)
+	ctx := g.Context()
+	svc, err := sqladmin.NewService(ctx, g.ClientOptions()...)
 	if err != nil {
 		return err
 	}
@@ -66,8 +66,8 @@ func (g *CloudTaskGenerator) InitResources() error {
 		return nil
 	}
 
-	ctx := context.Background()
-	client, err := cloudtasks.NewClient(ctx)
+	ctx := g.Context()
+	client, err := cloudtasks.NewClient(ctx, g.GRPCClientOptions()...)
 	if err != nil {
 		return err
 	}
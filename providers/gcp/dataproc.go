@@ -95,8 +95,8 @@ func (g *DataprocGenerator) InitResources() error {
 		return nil
 	}
 
-	ctx := context.Background()
-	dataprocService, err := dataproc.NewService(ctx)
+	ctx := g.Context()
+	dataprocService, err := dataproc.NewService(ctx, g.ClientOptions()...)
 	if err != nil {
 		return err
 	}
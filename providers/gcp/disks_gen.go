@@ -69,8 +69,8 @@ func (g *DisksGenerator) InitResources() error {
 		return nil
 	}
 
-	ctx := context.Background()
-	computeService, err := compute.NewService(ctx)
+	ctx := g.Context()
+	computeService, err := compute.NewService(ctx, g.ClientOptions()...)
 	if err != nil {
 		return err
 	}
@@ -33,9 +33,9 @@ type FirestoreGenerator struct {
 func (g *FirestoreGenerator) InitResources() error {
 	project := g.GetArgs()["project"].(string)
 
-	ctx := context.Background()
+	ctx := g.Context()
 
-	firestoreService, err := firestore.NewService(ctx)
+	firestoreService, err := firestore.NewService(ctx, g.ClientOptions()...)
 	if err != nil {
 		return fmt.Errorf("failed to create firestore service: %w", err)
 	}
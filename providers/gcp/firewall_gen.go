@@ -62,8 +62,8 @@ func (g FirewallGenerator) createResources(ctx context.Context, firewallList *co
 // from each firewall create 1 TerraformResource
 // Need firewall name as ID for terraform resource
 func (g *FirewallGenerator) InitResources() error {
-	ctx := context.Background()
-	computeService, err := compute.NewService(ctx)
+	ctx := g.Context()
+	computeService, err := compute.NewService(ctx, g.ClientOptions()...)
 	if err != nil {
 		return err
 	}
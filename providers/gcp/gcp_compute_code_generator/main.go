@@ -107,8 +107,8 @@ func (g *{{.titleResourceName}}Generator) InitResources() error {
         return nil
     }
     {{ end }}
-	ctx := context.Background()
-	computeService, err := compute.NewService(ctx)
+	ctx := g.Context()
+	computeService, err := compute.NewService(ctx, g.ClientOptions()...)
 	if err != nil {
 		return err
 	}
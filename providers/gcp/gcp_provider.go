@@ -19,13 +19,18 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"strings"
 	"sync"
 
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/httpfixture"
 	"github.com/zclconf/go-cty/cty"
 	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
+	"google.golang.org/grpc"
 )
 
 var (
@@ -43,16 +48,17 @@ type GCPProvider struct { //nolint
 	regions      []string
 	region       compute.Region
 	providerType string
+	middleware   httpfixture.Middleware
 }
 
-func GetRegions(project string) []string {
+func GetRegions(ctx context.Context, project string, opts ...option.ClientOption) []string {
 	// 1. Check the cache first.
 	if cachedRegions, ok := regionsCache.Load(project); ok {
 		return cachedRegions.([]string)
 	}
 
 	// 2. If not in cache, make the API call.
-	computeService, err := compute.NewService(context.Background())
+	computeService, err := compute.NewService(ctx, opts...)
 	if err != nil {
 		log.Printf("ERROR creating compute service: %v", err)
 		return []string{}
@@ -75,7 +81,7 @@ func GetRegions(project string) []string {
 	return regions
 }
 
-func getRegion(project, regionName string) (compute.Region, error) {
+func getRegion(ctx context.Context, project, regionName string, opts ...option.ClientOption) (compute.Region, error) {
 	if regionName == "global" {
 		return compute.Region{}, nil
 	}
@@ -88,7 +94,7 @@ func getRegion(project, regionName string) (compute.Region, error) {
 	}
 
 	// 2. If not in cache, make the API call.
-	computeService, err := compute.NewService(context.Background())
+	computeService, err := compute.NewService(ctx, opts...)
 	if err != nil {
 		return compute.Region{}, fmt.Errorf("failed to create compute service: %w", err)
 	}
@@ -108,6 +114,30 @@ func getRegion(project, regionName string) (compute.Region, error) {
 	return *region, nil
 }
 
+// Scope is a project and region imported by a GCPProvider, with the provider type, "" or "beta"
+type Scope struct {
+	Project      string
+	Region       string
+	ProviderType string
+}
+
+// Scopes returns the scopes of every region of every project, by project
+func Scopes(projects, regions []string, providerType string) []Scope {
+	scopes := []Scope{}
+	for _, project := range projects {
+		for _, region := range regions {
+			scopes = append(scopes, Scope{Project: project, Region: region, ProviderType: providerType})
+		}
+	}
+	return scopes
+}
+
+// Args returns the args of Init importing the scope
+func (s Scope) Args() []string {
+	return []string{s.Region, s.Project, s.ProviderType}
+}
+
+// Init initializes the provider for a scope, args are the Args of a Scope
 func (p *GCPProvider) Init(args []string) error {
 	// The main project name for Terraformer to scan, taken from the arguments.
 	projectName := args[1]
@@ -128,8 +158,8 @@ func (p *GCPProvider) Init(args []string) error {
 
 	// Call the region functions using the dedicated regional project ID.
 	var err error
-	p.regions = GetRegions(regionalProject)
-	p.region, err = getRegion(regionalProject, args[0])
+	p.regions = GetRegions(p.Context(), regionalProject, clientOptions(p.Context(), p.middleware)...)
+	p.region, err = getRegion(p.Context(), regionalProject, args[0], clientOptions(p.Context(), p.middleware)...)
 	if err != nil {
 		return err
 	}
@@ -138,6 +168,66 @@ func (p *GCPProvider) Init(args []string) error {
 	return nil
 }
 
+// SetAPIMiddleware sends the calls of the API clients of the provider and its services through a middleware, e.g.
+// to record them or to replay recorded responses. The clients need no credentials when the middleware is offline.
+func (p *GCPProvider) SetAPIMiddleware(middleware httpfixture.Middleware) {
+	p.middleware = middleware
+}
+
+// APIMiddleware returns the middleware set by SetAPIMiddleware, nil by default
+func (p *GCPProvider) APIMiddleware() httpfixture.Middleware {
+	return p.middleware
+}
+
+// cloudPlatformScope is the OAuth scope of the application default credentials of the HTTP API clients
+const cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
+// clientOptions returns the options of the HTTP API clients sending their requests through a middleware, none
+// when it is nil. Unless the middleware is offline, the requests are authenticated with the application default
+// credentials.
+func clientOptions(ctx context.Context, middleware httpfixture.Middleware) []option.ClientOption {
+	if middleware == nil {
+		return nil
+	}
+	var base http.RoundTripper
+	if !middleware.Offline() {
+		base = &authTransport{ctx: ctx}
+	}
+	return []option.ClientOption{option.WithHTTPClient(&http.Client{Transport: middleware.RoundTripper(base)})}
+}
+
+// grpcClientOptions returns the options of the gRPC API clients sending their calls through a middleware, none
+// when it is nil
+func grpcClientOptions(middleware httpfixture.Middleware) []option.ClientOption {
+	if middleware == nil {
+		return nil
+	}
+	options := []option.ClientOption{option.WithGRPCDialOption(grpc.WithUnaryInterceptor(middleware.UnaryClientInterceptor()))}
+	if middleware.Offline() {
+		options = append(options, option.WithoutAuthentication())
+	}
+	return options
+}
+
+// authTransport authenticates requests with the application default credentials, looked up on the first request
+type authTransport struct {
+	ctx context.Context
+
+	once      sync.Once
+	transport http.RoundTripper
+	err       error
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.once.Do(func() {
+		t.transport, t.err = htransport.NewTransport(t.ctx, http.DefaultTransport, option.WithScopes(cloudPlatformScope))
+	})
+	if t.err != nil {
+		return nil, t.err
+	}
+	return t.transport.RoundTrip(req)
+}
+
 func (p *GCPProvider) GetName() string {
 	if p.providerType != "" {
 		return "google-" + p.providerType
@@ -155,9 +245,10 @@ func (p *GCPProvider) InitService(serviceName string, verbose bool) error {
 	p.Service.SetVerbose(verbose)
 	p.Service.SetProviderName(p.GetName())
 	p.Service.SetArgs(map[string]interface{}{
-		"region":  p.region,
-		"regions": p.regions,
-		"project": p.projectName,
+		"region":         p.region,
+		"regions":        p.regions,
+		"project":        p.projectName,
+		"api_middleware": p.middleware,
 	})
 	return nil
 }
@@ -253,6 +344,17 @@ func (GCPProvider) GetResourceConnections() map[string]map[string][]string {
 		},
 	}
 }
+
+// GetIdentityAttributes returns the attributes other resources use to reference a resource, besides id, self_link and name
+func (p *GCPProvider) GetIdentityAttributes() map[string][]string {
+	return map[string][]string{
+		"google_service_account":        {"email", "member"},
+		"google_compute_address":        {"address"},
+		"google_compute_global_address": {"address"},
+		"google_storage_bucket":         {"url"},
+	}
+}
+
 func (p GCPProvider) GetProviderData(arg ...string) map[string]interface{} {
 	return map[string]interface{}{
 		"provider": map[string]interface{}{
@@ -281,3 +383,11 @@ func (p *GCPProvider) GetConfig() cty.Value {
 func (p *GCPProvider) GetBasicConfig() cty.Value {
 	return p.GetConfig()
 }
+
+// GetSharedConfig is the config of a plugin shared by the regions of a project, the generated provider block has
+// no region either
+func (p *GCPProvider) GetSharedConfig() cty.Value {
+	return cty.ObjectVal(map[string]cty.Value{
+		"project": cty.StringVal(p.projectName),
+	})
+}
@@ -16,12 +16,27 @@ package gcp
 
 import (
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/httpfixture"
+
+	"google.golang.org/api/option"
 )
 
 type GCPService struct { //nolint
 	terraformutils.Service
 }
 
+// ClientOptions returns the options of the HTTP API clients of the service, see GCPProvider.SetAPIMiddleware
+func (s *GCPService) ClientOptions() []option.ClientOption {
+	middleware, _ := s.GetArgs()["api_middleware"].(httpfixture.Middleware)
+	return clientOptions(s.Context(), middleware)
+}
+
+// GRPCClientOptions returns the options of the gRPC API clients of the service, see GCPProvider.SetAPIMiddleware
+func (s *GCPService) GRPCClientOptions() []option.ClientOption {
+	middleware, _ := s.GetArgs()["api_middleware"].(httpfixture.Middleware)
+	return grpcClientOptions(middleware)
+}
+
 func (s *GCPService) applyCustomProviderType(resources []terraformutils.Resource, providerName string) []terraformutils.Resource {
 	editedResources := []terraformutils.Resource{}
 	for _, r := range resources {
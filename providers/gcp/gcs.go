@@ -205,17 +205,17 @@ func (g *GcsGenerator) createTransferJobsResources(ctx context.Context, storageT
 // from each bucket  create 1 TerraformResource
 // Need bucket name as ID for terraform resource
 func (g *GcsGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	projectID := g.GetArgs()["project"].(string)
 
-	gcsService, err := storage.NewService(ctx)
+	gcsService, err := storage.NewService(ctx, g.ClientOptions()...)
 	if err != nil {
 		log.Print(err)
 		return err
 	}
 	g.Resources = g.createBucketsResources(ctx, gcsService)
 
-	storageTransferService, err := storagetransfer.NewService(ctx, option.WithQuotaProject(projectID))
+	storageTransferService, err := storagetransfer.NewService(ctx, append(g.ClientOptions(), option.WithQuotaProject(projectID))...)
 	if err != nil {
 		log.Print(err)
 		return err
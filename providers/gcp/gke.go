@@ -15,7 +15,6 @@
 package gcp
 
 import (
-	"context"
 	"fmt"
 	"log"
 	"strconv"
@@ -98,8 +97,8 @@ func (g *GkeGenerator) InitResources() error {
 		return nil
 	}
 
-	ctx := context.Background()
-	service, err := container.NewService(ctx)
+	ctx := g.Context()
+	service, err := container.NewService(ctx, g.ClientOptions()...)
 	if err != nil {
 		log.Print(err)
 		return err
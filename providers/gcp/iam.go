@@ -15,7 +15,6 @@
 package gcp
 
 import (
-	"context"
 	"log"
 	"regexp"
 
@@ -132,10 +131,10 @@ func (g *IamGenerator) InitResources() error {
 		return nil
 	}
 
-	ctx := context.Background()
+	ctx := g.Context()
 
 	projectID := g.GetArgs()["project"].(string)
-	client, err := admin.NewIamClient(ctx)
+	client, err := admin.NewIamClient(ctx, g.GRPCClientOptions()...)
 	if err != nil {
 		return err
 	}
@@ -145,7 +144,7 @@ func (g *IamGenerator) InitResources() error {
 		return err
 	}
 
-	cm, err := cloudresourcemanager.NewService(context.Background())
+	cm, err := cloudresourcemanager.NewService(ctx, g.ClientOptions()...)
 	if err != nil {
 		return err
 	}
@@ -154,7 +153,7 @@ func (g *IamGenerator) InitResources() error {
 			RequestedPolicyVersion: 3,
 		},
 	}
-	policyResponse, err := cm.Projects.GetIamPolicy(projectID, rb).Context(context.Background()).Do()
+	policyResponse, err := cm.Projects.GetIamPolicy(projectID, rb).Context(ctx).Do()
 	if err != nil {
 		return err
 	}
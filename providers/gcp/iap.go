@@ -224,7 +224,7 @@ func (g *IapGenerator) initGlobalIapResources(ctx context.Context, iapService *i
 	}
 	globalResources = append(globalResources, clientResources...)
 
-	appengineService, err := appengine.NewService(ctx)
+	appengineService, err := appengine.NewService(ctx, g.ClientOptions()...)
 	if err == nil {
 		app, err := appengineService.Apps.Get(project).Do()
 		if err == nil {
@@ -266,7 +266,7 @@ func (g *IapGenerator) initGlobalIapResources(ctx context.Context, iapService *i
 		}
 	}
 
-	computeService, err := compute.NewService(ctx)
+	computeService, err := compute.NewService(ctx, g.ClientOptions()...)
 	if err == nil {
 		g.addIamMemberAndSettingsResourcesWithPolicyCheck(&globalResources, iapService,
 			fmt.Sprintf("projects/%s/iap_web/compute", project),
@@ -315,7 +315,7 @@ func (g *IapGenerator) initRegionalIapResources(ctx context.Context, iapService
 	var regionalResources []terraformutils.Resource
 	var parent string
 
-	computeService, err := compute.NewService(ctx)
+	computeService, err := compute.NewService(ctx, g.ClientOptions()...)
 	if err == nil {
 		_ = computeService.RegionBackendServices.List(project, region).Pages(ctx, func(page *compute.BackendServiceList) error {
 			for _, backendService := range page.Items {
@@ -359,7 +359,7 @@ func (g *IapGenerator) initRegionalIapResources(ctx context.Context, iapService
 
 	}
 
-	runService, err := run.NewService(ctx)
+	runService, err := run.NewService(ctx, g.ClientOptions()...)
 	if err == nil {
 		parent = "projects/" + project + "/locations/" + region
 		listCall := runService.Projects.Locations.Services.List(parent)
@@ -435,8 +435,8 @@ func (g *IapGenerator) initRegionalIapResources(ctx context.Context, iapService
 func (g *IapGenerator) InitResources() error {
 	project := g.GetArgs()["project"].(string)
 	regionName := g.GetArgs()["region"].(compute.Region).Name
-	ctx := context.Background()
-	iapService, err := iap.NewService(ctx)
+	ctx := g.Context()
+	iapService, err := iap.NewService(ctx, g.ClientOptions()...)
 	if err != nil {
 		return err
 	}
@@ -68,8 +68,8 @@ func (g InstancesGenerator) createResources(ctx context.Context, instancesList *
 // from each instances create 1 TerraformResource
 // Need instances name as ID for terraform resource
 func (g *InstancesGenerator) InitResources() error {
-	ctx := context.Background()
-	computeService, err := compute.NewService(ctx)
+	ctx := g.Context()
+	computeService, err := compute.NewService(ctx, g.ClientOptions()...)
 	if err != nil {
 		return err
 	}
@@ -91,8 +91,8 @@ func (g *KmsGenerator) createKmsKeyResources(ctx context.Context, keyRingName st
 
 // Generate TerraformResources from GCP API,
 func (g *KmsGenerator) InitResources() error {
-	ctx := context.Background()
-	kmsService, err := cloudkms.NewService(ctx)
+	ctx := g.Context()
+	kmsService, err := cloudkms.NewService(ctx, g.ClientOptions()...)
 	if err != nil {
 		return err
 	}
@@ -67,8 +67,8 @@ func (g *LoggingGenerator) InitResources() error {
 	}
 
 	project := g.GetArgs()["project"].(string)
-	ctx := context.Background()
-	client, err := logadmin.NewClient(ctx, project)
+	ctx := g.Context()
+	client, err := logadmin.NewClient(ctx, project, g.GRPCClientOptions()...)
 	if err != nil {
 		return err
 	}
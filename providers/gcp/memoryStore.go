@@ -150,11 +150,11 @@ func (g *MemoryStoreGenerator) InitResources() error {
 		return nil
 	}
 
-	ctx := context.Background()
+	ctx := g.Context()
 	var allResources []terraformutils.Resource
 
 	// Redis Service for Redis Instances and Clusters
-	redisService, err := redis.NewService(ctx)
+	redisService, err := redis.NewService(ctx, g.ClientOptions()...)
 	if err != nil {
 		return err
 	}
@@ -172,7 +172,7 @@ func (g *MemoryStoreGenerator) InitResources() error {
 	allResources = append(allResources, redisClusters...)
 
 	// Memcache Service for Memcache Instances
-	memcacheService, err := memcache.NewService(ctx)
+	memcacheService, err := memcache.NewService(ctx, g.ClientOptions()...)
 	if err != nil {
 		// Not returning an error because the API might not be enabled for the project.
 		log.Printf("Error creating Memcache service, skipping Memcache instances: %v", err)
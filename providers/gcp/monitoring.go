@@ -36,7 +36,7 @@ type MonitoringGenerator struct {
 }
 
 func (g *MonitoringGenerator) loadAlerts(ctx context.Context, project string) error {
-	client, err := monitoring.NewAlertPolicyClient(ctx)
+	client, err := monitoring.NewAlertPolicyClient(ctx, g.GRPCClientOptions()...)
 	if err != nil {
 		return err
 	}
@@ -73,7 +73,7 @@ func (g *MonitoringGenerator) loadAlerts(ctx context.Context, project string) er
 }
 
 func (g *MonitoringGenerator) loadGroups(ctx context.Context, project string) error {
-	client, err := monitoring.NewGroupClient(ctx)
+	client, err := monitoring.NewGroupClient(ctx, g.GRPCClientOptions()...)
 	if err != nil {
 		return err
 	}
@@ -109,7 +109,7 @@ func (g *MonitoringGenerator) loadGroups(ctx context.Context, project string) er
 }
 
 func (g *MonitoringGenerator) loadNotificationChannel(ctx context.Context, project string) error {
-	client, err := monitoring.NewNotificationChannelClient(ctx)
+	client, err := monitoring.NewNotificationChannelClient(ctx, g.GRPCClientOptions()...)
 	if err != nil {
 		return err
 	}
@@ -144,7 +144,7 @@ func (g *MonitoringGenerator) loadNotificationChannel(ctx context.Context, proje
 	return nil
 }
 func (g *MonitoringGenerator) loadUptimeCheck(ctx context.Context, project string) error {
-	client, err := monitoring.NewUptimeCheckClient(ctx)
+	client, err := monitoring.NewUptimeCheckClient(ctx, g.GRPCClientOptions()...)
 	if err != nil {
 		return err
 	}
@@ -188,7 +188,7 @@ func (g *MonitoringGenerator) InitResources() error {
 	}
 
 	project := g.GetArgs()["project"].(string)
-	ctx := context.Background()
+	ctx := g.Context()
 
 	if err := g.loadAlerts(ctx, project); err != nil {
 		return err
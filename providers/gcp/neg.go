@@ -39,8 +39,8 @@ func (g *NEGGenerator) InitResources() error {
 	project := g.GetArgs()["project"].(string)
 	region := g.GetArgs()["region"].(compute.Region).Name
 
-	ctx := context.Background()
-	computeService, err := compute.NewService(ctx)
+	ctx := g.Context()
+	computeService, err := compute.NewService(ctx, g.ClientOptions()...)
 	if err != nil {
 		return fmt.Errorf("failed to create compute service: %w", err)
 	}
@@ -0,0 +1,188 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/httpfixture"
+	"google.golang.org/api/cloudresourcemanager/v3"
+)
+
+// ProjectSelector selects the projects of organizations and folders, see DiscoverProjects
+type ProjectSelector struct {
+	// Organizations and Folders are the IDs of the parents whose projects are imported, with or without the
+	// organizations/ and folders/ prefix. Their folders are walked recursively.
+	Organizations []string `json:"organizations,omitempty"`
+	Folders       []string `json:"folders,omitempty"`
+	// Filters are label filters every project must match, e.g. labels.env=prod. The value is a glob.
+	Filters []string `json:"filters,omitempty"`
+	// Excludes are globs of the IDs of projects left out, e.g. sandbox-*
+	Excludes []string `json:"excludes,omitempty"`
+}
+
+// DiscoveredProject is an active project found by DiscoverProjects
+type DiscoveredProject struct {
+	ID string `json:"id"`
+	// Name is the display name of the project
+	Name string `json:"name,omitempty"`
+	// Parent is the organization or folder holding the project, e.g. folders/123
+	Parent string            `json:"parent"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// ProjectDiscovery is the outcome of DiscoverProjects, the manifest of the discovered projects
+type ProjectDiscovery struct {
+	Selector ProjectSelector `json:"selector"`
+	// Projects are the selected projects, sorted by ID
+	Projects []DiscoveredProject `json:"projects"`
+	// Excluded are the projects left out by the filters or the excludes of the selector, sorted by ID
+	Excluded []DiscoveredProject `json:"excluded,omitempty"`
+}
+
+// ProjectIDs returns the IDs of the selected projects
+func (d *ProjectDiscovery) ProjectIDs() []string {
+	ids := []string{}
+	for _, project := range d.Projects {
+		ids = append(ids, project.ID)
+	}
+	return ids
+}
+
+// labelFilter is a parsed filter of a ProjectSelector
+type labelFilter struct {
+	label string
+	value string
+}
+
+func parseLabelFilter(filter string) (labelFilter, error) {
+	label, value, ok := strings.Cut(strings.TrimPrefix(filter, "labels."), "=")
+	if !ok || !strings.HasPrefix(filter, "labels.") || label == "" {
+		return labelFilter{}, fmt.Errorf("invalid project filter %q, expected labels.<key>=<value>", filter)
+	}
+	if _, err := path.Match(value, ""); err != nil {
+		return labelFilter{}, fmt.Errorf("invalid project filter %q: %w", filter, err)
+	}
+	return labelFilter{label: label, value: value}, nil
+}
+
+func (f labelFilter) match(project DiscoveredProject) bool {
+	value, ok := project.Labels[f.label]
+	if !ok {
+		return false
+	}
+	matched, _ := path.Match(f.value, value)
+	return matched
+}
+
+// DiscoverProjects returns the active projects of the organizations and folders of a selector, walking their
+// folders with Cloud Resource Manager v3. The API calls are sent through middleware unless it is nil.
+func DiscoverProjects(ctx context.Context, selector ProjectSelector, middleware httpfixture.Middleware) (*ProjectDiscovery, error) {
+	filters := []labelFilter{}
+	for _, filter := range selector.Filters {
+		parsed, err := parseLabelFilter(filter)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, parsed)
+	}
+	for _, exclude := range selector.Excludes {
+		if _, err := path.Match(exclude, ""); err != nil {
+			return nil, fmt.Errorf("invalid project exclude %q: %w", exclude, err)
+		}
+	}
+	service, err := cloudresourcemanager.NewService(ctx, clientOptions(ctx, middleware)...)
+	if err != nil {
+		return nil, err
+	}
+	parents := []string{}
+	for _, organization := range selector.Organizations {
+		parents = append(parents, "organizations/"+strings.TrimPrefix(organization, "organizations/"))
+	}
+	for _, folder := range selector.Folders {
+		parents = append(parents, "folders/"+strings.TrimPrefix(folder, "folders/"))
+	}
+
+	found := map[string]DiscoveredProject{}
+	walked := map[string]bool{}
+	for len(parents) > 0 {
+		parent := parents[0]
+		parents = parents[1:]
+		if walked[parent] {
+			continue
+		}
+		walked[parent] = true
+		err := service.Projects.List().Parent(parent).Pages(ctx, func(response *cloudresourcemanager.ListProjectsResponse) error {
+			for _, project := range response.Projects {
+				if project.State != "" && project.State != "ACTIVE" {
+					continue
+				}
+				found[project.ProjectId] = DiscoveredProject{
+					ID:     project.ProjectId,
+					Name:   project.DisplayName,
+					Parent: project.Parent,
+					Labels: project.Labels,
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the projects of %s: %w", parent, err)
+		}
+		err = service.Folders.List().Parent(parent).Pages(ctx, func(response *cloudresourcemanager.ListFoldersResponse) error {
+			for _, folder := range response.Folders {
+				if folder.State != "" && folder.State != "ACTIVE" {
+					continue
+				}
+				parents = append(parents, folder.Name)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the folders of %s: %w", parent, err)
+		}
+	}
+
+	discovery := &ProjectDiscovery{Selector: selector, Projects: []DiscoveredProject{}}
+	for _, project := range found {
+		if selected(project, filters, selector.Excludes) {
+			discovery.Projects = append(discovery.Projects, project)
+		} else {
+			discovery.Excluded = append(discovery.Excluded, project)
+		}
+	}
+	sort.Slice(discovery.Projects, func(i, j int) bool { return discovery.Projects[i].ID < discovery.Projects[j].ID })
+	sort.Slice(discovery.Excluded, func(i, j int) bool { return discovery.Excluded[i].ID < discovery.Excluded[j].ID })
+	return discovery, nil
+}
+
+// selected returns true for a project matching all filters and none of the excludes
+func selected(project DiscoveredProject, filters []labelFilter, excludes []string) bool {
+	for _, exclude := range excludes {
+		if matched, _ := path.Match(exclude, project.ID); matched {
+			return false
+		}
+	}
+	for _, filter := range filters {
+		if !filter.match(project) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/httpfixture"
+)
+
+func projectsFixture(t *testing.T) *httpfixture.Replayer {
+	t.Helper()
+	body := func(value string) json.RawMessage { return json.RawMessage(value) }
+	replayer, err := httpfixture.NewReplayer(&httpfixture.Fixture{Interactions: []httpfixture.Interaction{
+		{URL: "https://cloudresourcemanager.googleapis.com/v3/projects?parent=organizations%2F1", Body: body(`{"projects": [
+			{"projectId": "shared-vpc", "parent": "organizations/1", "state": "ACTIVE", "labels": {"env": "prod"}}
+		]}`)},
+		{URL: "https://cloudresourcemanager.googleapis.com/v3/folders?parent=organizations%2F1", Body: body(`{"folders": [
+			{"name": "folders/2", "state": "ACTIVE"},
+			{"name": "folders/3", "state": "DELETE_REQUESTED"}
+		]}`)},
+		{URL: "https://cloudresourcemanager.googleapis.com/v3/projects?parent=folders%2F2", Body: body(`{"projects": [
+			{"projectId": "app-prod", "displayName": "App", "parent": "folders/2", "state": "ACTIVE", "labels": {"env": "prod"}}
+		], "nextPageToken": "next"}`)},
+		{URL: "https://cloudresourcemanager.googleapis.com/v3/projects?pageToken=next&parent=folders%2F2", Body: body(`{"projects": [
+			{"projectId": "app-dev", "parent": "folders/2", "state": "ACTIVE", "labels": {"env": "dev"}},
+			{"projectId": "sandbox-prod", "parent": "folders/2", "state": "ACTIVE", "labels": {"env": "prod"}}
+		]}`)},
+		{URL: "https://cloudresourcemanager.googleapis.com/v3/folders?parent=folders%2F2", Body: body(`{}`)},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return replayer
+}
+
+func TestDiscoverProjects(t *testing.T) {
+	replayer := projectsFixture(t)
+	discovery, err := DiscoverProjects(context.Background(), ProjectSelector{
+		Organizations: []string{"1"},
+		Filters:       []string{"labels.env=prod"},
+		Excludes:      []string{"sandbox-*"},
+	}, replayer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unmatched := replayer.Unmatched(); len(unmatched) > 0 {
+		t.Fatalf("unmatched requests: %v", unmatched)
+	}
+	if ids := discovery.ProjectIDs(); !reflect.DeepEqual(ids, []string{"app-prod", "shared-vpc"}) {
+		t.Errorf("got projects %v", ids)
+	}
+	excluded := []string{}
+	for _, project := range discovery.Excluded {
+		excluded = append(excluded, project.ID)
+	}
+	if !reflect.DeepEqual(excluded, []string{"app-dev", "sandbox-prod"}) {
+		t.Errorf("got excluded projects %v", excluded)
+	}
+	if discovery.Projects[0].Name != "App" || discovery.Projects[0].Parent != "folders/2" {
+		t.Errorf("got project %+v", discovery.Projects[0])
+	}
+}
+
+func TestDiscoverProjectsInvalidSelector(t *testing.T) {
+	for _, selector := range []ProjectSelector{
+		{Folders: []string{"2"}, Filters: []string{"env=prod"}},
+		{Folders: []string{"2"}, Filters: []string{"labels.=prod"}},
+		{Folders: []string{"2"}, Excludes: []string{"sandbox-["}},
+	} {
+		if _, err := DiscoverProjects(context.Background(), selector, projectsFixture(t)); err == nil {
+			t.Errorf("%+v: expected an error", selector)
+		}
+	}
+}
@@ -93,8 +93,8 @@ func (g *PubsubGenerator) InitResources() error {
 		return nil
 	}
 
-	ctx := context.Background()
-	pubsubService, err := pubsub.NewService(ctx)
+	ctx := g.Context()
+	pubsubService, err := pubsub.NewService(ctx, g.ClientOptions()...)
 	if err != nil {
 		return err
 	}
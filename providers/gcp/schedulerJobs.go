@@ -67,8 +67,8 @@ func (g *SchedulerJobsGenerator) InitResources() error {
 		return nil
 	}
 
-	ctx := context.Background()
-	cloudSchedulerService, err := cloudscheduler.NewService(ctx)
+	ctx := g.Context()
+	cloudSchedulerService, err := cloudscheduler.NewService(ctx, g.ClientOptions()...)
 	if err != nil {
 		return err
 	}
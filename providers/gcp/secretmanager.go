@@ -177,7 +177,7 @@ func (g *SecretManagerGenerator) getSecretID(name string) string {
 
 // InitResources generates the GCP Secret Manager resources.
 func (g *SecretManagerGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 
 	project := g.GetArgs()["project"].(string)
 	region := g.GetArgs()["region"].(compute.Region).Name
@@ -187,7 +187,7 @@ func (g *SecretManagerGenerator) InitResources() error {
 
 	if region == "global" || region == "" {
 		// Global secrets
-		service, err = secretmanager.NewService(ctx)
+		service, err = secretmanager.NewService(ctx, g.ClientOptions()...)
 		if err != nil {
 			return fmt.Errorf("failed to create global secret manager service: %w", err)
 		}
@@ -198,7 +198,7 @@ func (g *SecretManagerGenerator) InitResources() error {
 	} else {
 		// Regional secrets
 		endpoint := fmt.Sprintf("secretmanager.%s.rep.googleapis.com", region)
-		service, err = secretmanager.NewService(ctx, option.WithEndpoint(endpoint))
+		service, err = secretmanager.NewService(ctx, append(g.ClientOptions(), option.WithEndpoint(endpoint))...)
 		if err != nil {
 			return fmt.Errorf("failed to create regional secret manager service for %s: %w", region, err)
 		}
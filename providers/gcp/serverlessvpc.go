@@ -15,7 +15,6 @@
 package gcp
 
 import (
-	"context"
 	"strings"
 
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
@@ -37,8 +36,8 @@ func (g *VpcAccessConnectorGenerator) InitResources() error {
 		return nil
 	}
 
-	ctx := context.Background()
-	vpcaccessService, err := vpcaccess.NewService(ctx)
+	ctx := g.Context()
+	vpcaccessService, err := vpcaccess.NewService(ctx, g.ClientOptions()...)
 	if err != nil {
 		return err
 	}
@@ -1,7 +1,6 @@
 package gcp
 
 import (
-	"context"
 	"strings"
 
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
@@ -20,8 +19,8 @@ func (g *ServiceUsageGenerator) InitResources() error {
 	}
 
 	project := g.GetArgs()["project"].(string)
-	ctx := context.Background()
-	service, err := serviceusage.NewService(ctx)
+	ctx := g.Context()
+	service, err := serviceusage.NewService(ctx, g.ClientOptions()...)
 	if err != nil {
 		return err
 	}
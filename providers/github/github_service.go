@@ -15,7 +15,6 @@
 package github
 
 import (
-	"context"
 	"net/http"
 
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
@@ -38,7 +37,7 @@ func (g *GithubService) createClient() (*github.Client, error) {
 }
 
 func (g *GithubService) createRegularClient() *github.Client {
-	ctx := context.Background()
+	ctx := g.Context()
 	if g.Args["app_id"].(int64) != 0 && g.Args["installation_id"].(int64) != 0 && g.Args["pem"].(string) != "" {
 		itr, err := ghinstallation.New(http.DefaultTransport, g.Args["app_id"].(int64), g.Args["installation_id"].(int64), []byte(g.Args["pem"].(string)))
 		if err != nil {
@@ -54,7 +53,7 @@ func (g *GithubService) createRegularClient() *github.Client {
 }
 
 func (g *GithubService) createEnterpriseClient() (*github.Client, error) {
-	ctx := context.Background()
+	ctx := g.Context()
 	baseURL := g.GetArgs()["base_url"].(string)
 	if g.Args["app_id"].(int64) != 0 && g.Args["installation_id"].(int64) != 0 && g.Args["pem"].(string) != "" {
 		itr, err := ghinstallation.New(http.DefaultTransport, g.Args["app_id"].(int64), g.Args["installation_id"].(int64), []byte(g.Args["pem"].(string)))
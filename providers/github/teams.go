@@ -84,7 +84,7 @@ func (g *TeamsGenerator) createTeamRepositoriesResources(ctx context.Context, te
 
 // InitResources generates TerraformResources from Github API,
 func (g *TeamsGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	client, err := g.createClient()
 	if err != nil {
 		return err
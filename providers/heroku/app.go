@@ -53,7 +53,7 @@ func (g AppGenerator) createResources(appList []heroku.App) ([]terraformutils.Re
 
 func (g *AppGenerator) InitResources() error {
 	svc := g.generateService()
-	ctx := context.Background()
+	ctx := g.Context()
 	team := g.GetArgs()["team"].(string)
 
 	var output []heroku.App
@@ -147,7 +147,7 @@ func (g *AppGenerator) InitResources() error {
 
 func (g AppGenerator) getSettableConfigVars(appID string) (map[string]string, error) {
 	svc := g.generateService()
-	ctx := context.Background()
+	ctx := g.Context()
 	output := map[string]string{}
 	emptyOutput := map[string]string{}
 
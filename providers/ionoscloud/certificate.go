@@ -1,7 +1,6 @@
 package ionoscloud
 
 import (
-	"context"
 	"log"
 
 	"github.com/GoogleCloudPlatform/terraformer/providers/ionoscloud/helpers"
@@ -17,7 +16,7 @@ func (g *CertificateGenerator) InitResources() error {
 	certManagerAPIClient := client.CertificateManagerAPIClient
 	resourceType := "ionoscloud_certificate"
 
-	response, _, err := certManagerAPIClient.CertificatesApi.CertificatesGet(context.TODO()).Execute()
+	response, _, err := certManagerAPIClient.CertificatesApi.CertificatesGet(g.Context()).Execute()
 	if err != nil {
 		return err
 	}
@@ -15,7 +15,6 @@
 package kubernetes
 
 import (
-	"context"
 	"reflect"
 
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
@@ -58,7 +57,7 @@ func (k *Kind) InitResources() error {
 
 	resource := group.MethodByName(extractClientSetFuncTypeName(k.Name)).Call(param)[0]
 
-	results := resource.MethodByName("List").Call([]reflect.Value{reflect.ValueOf(context.Background()),
+	results := resource.MethodByName("List").Call([]reflect.Value{reflect.ValueOf(k.Context()),
 		reflect.ValueOf(metav1.ListOptions{})})
 
 	if !results[1].IsNil() {
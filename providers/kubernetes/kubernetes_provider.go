@@ -29,7 +29,6 @@ import (
 
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils/providerwrapper"
-	"github.com/zclconf/go-cty/cty"
 
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -92,12 +91,11 @@ func (p *KubernetesProvider) GetSupportedService() map[string]terraformutils.Ser
 		log.Println(err)
 		return resources
 	}
-	provider, err := providerwrapper.NewProviderWrapper("kubernetes", cty.Value{}, p.verbose == "true")
+	resp, err := providerwrapper.ProviderSchema("kubernetes", p.verbose == "true")
 	if err != nil {
 		log.Println(err)
 		return resources
 	}
-	resp := provider.GetSchema()
 	for _, list := range lists {
 		if len(list.APIResources) == 0 {
 			continue
@@ -52,7 +52,7 @@ func (p *LaunchDarklyProvider) Init(args []string) error {
 
 	p.client = launchdarkly.NewAPIClient(cfg)
 
-	p.ctx = context.WithValue(context.Background(), launchdarkly.ContextAPIKey, launchdarkly.APIKey{
+	p.ctx = context.WithValue(p.Context(), launchdarkly.ContextAPIKey, launchdarkly.APIKey{
 		Key: p.apiKey,
 	})
 	return nil
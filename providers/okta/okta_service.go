@@ -36,7 +36,7 @@ func (s *OktaService) Client() (context.Context, *oktaV2.Client, error) {
 	orgURL := fmt.Sprintf("https://%v.%v", orgName, baseURL)
 
 	ctx, client, err := oktaV2.NewClient(
-		context.Background(),
+		s.Context(),
 		oktaV2.WithOrgUrl(orgURL),
 		oktaV2.WithToken(apiToken),
 	)
@@ -63,7 +63,7 @@ func (s *OktaService) ClientV5() (context.Context, *oktaV5.APIClient, error) {
 	}
 	client := oktaV5.NewAPIClient(config)
 
-	return context.Background(), client, nil
+	return s.Context(), client, nil
 }
 
 func (s *OktaService) APISupplementClient() (context.Context, *sdk.APISupplement, error) {
@@ -74,7 +74,7 @@ func (s *OktaService) APISupplementClient() (context.Context, *sdk.APISupplement
 	orgURL := fmt.Sprintf("https://%v.%v", orgName, baseURL)
 
 	ctx, client, err := oktaV2.NewClient(
-		context.Background(),
+		s.Context(),
 		oktaV2.WithOrgUrl(orgURL),
 		oktaV2.WithToken(apiToken),
 	)
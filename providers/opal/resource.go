@@ -1,7 +1,6 @@
 package opal
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
@@ -18,7 +17,7 @@ func (g *ResourceGenerator) InitResources() error {
 		return fmt.Errorf("unable to list opal resources: %v", err)
 	}
 
-	resources, _, err := client.ResourcesApi.GetResources(context.TODO()).Execute()
+	resources, _, err := client.ResourcesApi.GetResources(g.Context()).Execute()
 	if err != nil {
 		return fmt.Errorf("unable to list opal resources: %v", err)
 	}
@@ -34,7 +33,7 @@ func (g *ResourceGenerator) InitResources() error {
 			break
 		}
 
-		resources, _, err = client.ResourcesApi.GetResources(context.TODO()).Cursor(*resources.Next.Get()).Execute()
+		resources, _, err = client.ResourcesApi.GetResources(g.Context()).Cursor(*resources.Next.Get()).Execute()
 		if err != nil {
 			return fmt.Errorf("unable to list opal resources: %v", err)
 		}
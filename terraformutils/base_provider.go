@@ -15,6 +15,10 @@
 package terraformutils
 
 import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/httpfixture"
+
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -36,9 +40,50 @@ type ProviderWithSource interface {
 	GetSource() string
 }
 
+// ProviderWithIdentityAttributes is implemented by providers whose resources are referenced by attributes
+// other than id, self_link and name, e.g. the email of a service account. Keys are resource types.
+type ProviderWithIdentityAttributes interface {
+	GetIdentityAttributes() map[string][]string
+}
+
+// ProviderWithContext is implemented by providers embedding Provider, the context is set before Init
+type ProviderWithContext interface {
+	SetContext(ctx context.Context)
+}
+
+// ProviderWithAPIMiddleware is implemented by providers whose API clients can send their calls through an
+// httpfixture.Middleware, e.g. to record them or replay recorded responses. The middleware is passed on to the
+// providers of the services.
+type ProviderWithAPIMiddleware interface {
+	SetAPIMiddleware(middleware httpfixture.Middleware)
+	APIMiddleware() httpfixture.Middleware
+}
+
+// ProviderWithSharedConfig is implemented by providers whose scopes can share a provider plugin although their
+// configs differ, e.g. the regions of a project. GetSharedConfig is the config of the shared plugin, the
+// resources must then hold the attributes it leaves out, e.g. their region.
+type ProviderWithSharedConfig interface {
+	GetSharedConfig() cty.Value
+}
+
 type Provider struct {
 	Service ServiceGenerator
 	Config  cty.Value
+
+	ctx context.Context
+}
+
+// SetContext sets the context of the import, Init stops its API calls when it is canceled
+func (p *Provider) SetContext(ctx context.Context) {
+	p.ctx = ctx
+}
+
+// Context returns the context of the import, context.Background() until SetContext is called
+func (p *Provider) Context() context.Context {
+	if p.ctx == nil {
+		return context.Background()
+	}
+	return p.ctx
 }
 
 func (p *Provider) Init(args []string) error {
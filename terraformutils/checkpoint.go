@@ -0,0 +1,389 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform/providers"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+const (
+	// CheckpointVersion is the version of the checkpoint format, checkpoints of other versions are discarded
+	CheckpointVersion = 2
+	// DefaultCheckpointMaxAge is the age after which listed and refreshed resources are read again
+	DefaultCheckpointMaxAge = 24 * time.Hour
+
+	checkpointFile = "checkpoint.json"
+	refreshedFile  = "refreshed.jsonl"
+)
+
+// Checkpoint records the progress of an import of a provider scope, e.g. a project and region, so that an
+// interrupted import can be resumed. Listed resources are written per service to <service>.listed.json when the
+// service was initialized, refreshed states are appended to refreshed.jsonl as the refresh goes.
+//
+// Files are replaced by renaming a synced temporary file and states are appended with a single write per line,
+// a killed process leaves at most a truncated last line, which is ignored. A nil Checkpoint records nothing.
+type Checkpoint struct {
+	dir    string
+	maxAge time.Duration
+	header checkpointHeader
+
+	// sensitive are the paths of the sensitive attributes by resource type, left out of the checkpointed states
+	sensitive map[string][][]string
+
+	mu        sync.Mutex
+	refreshed map[string]*terraform.InstanceState
+	log       *os.File
+}
+
+// CheckpointScope is what an import of a provider scope reads. A checkpoint written for another provider version,
+// other services or other filters is discarded. The listed resources are filtered by Where when they are
+// restored, so a checkpoint is kept when it changes, only the scope isn't skipped as completed.
+type CheckpointScope struct {
+	ProviderVersion string
+	Services        []string `json:",omitempty"`
+	Filters         []string `json:",omitempty"`
+	Where           []string `json:",omitempty"`
+}
+
+type checkpointHeader struct {
+	Version int
+	CheckpointScope
+	Created time.Time
+	// Completed is set when all files of the scope were written
+	Completed *time.Time `json:",omitempty"`
+}
+
+type listedResources struct {
+	Created   time.Time
+	Resources []Resource
+}
+
+type refreshedState struct {
+	Time  time.Time
+	Type  string
+	ID    string
+	State *terraform.InstanceState
+}
+
+// OpenCheckpoint opens the checkpoint in dir. Unless resume is set, or when the checkpoint was written for another
+// scope, see CheckpointScope, it is cleared. Entries older than maxAge are ignored.
+func OpenCheckpoint(dir string, scope CheckpointScope, maxAge time.Duration, resume bool) (*Checkpoint, error) {
+	c := &Checkpoint{
+		dir:       dir,
+		maxAge:    maxAge,
+		refreshed: map[string]*terraform.InstanceState{},
+	}
+	if resume {
+		reason := c.readHeader(scope)
+		if reason != "" {
+			log.Printf("discarding checkpoint %s, %s", dir, reason)
+			resume = false
+		}
+	}
+	if !resume {
+		if err := os.RemoveAll(dir); err != nil {
+			return nil, err
+		}
+		c.header = checkpointHeader{Version: CheckpointVersion, CheckpointScope: scope, Created: time.Now()}
+	}
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, err
+	}
+	if err := c.writeHeader(); err != nil {
+		return nil, err
+	}
+	if err := c.readRefreshed(); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(filepath.Join(dir, refreshedFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	c.log = f
+	if err := c.terminateTruncatedLine(); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// terminateTruncatedLine ends a truncated last line of refreshed.jsonl, so that appended states start on a new line
+func (c *Checkpoint) terminateTruncatedLine() error {
+	info, err := c.log.Stat()
+	if err != nil || info.Size() == 0 {
+		return err
+	}
+	f, err := os.Open(c.log.Name())
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	last := make([]byte, 1)
+	if _, err := f.ReadAt(last, info.Size()-1); err != nil {
+		return err
+	}
+	if last[0] != '\n' {
+		_, err = c.log.Write([]byte{'\n'})
+	}
+	return err
+}
+
+// readHeader reads the header of a checkpoint, it returns why the checkpoint can't be resumed
+func (c *Checkpoint) readHeader(scope CheckpointScope) string {
+	data, err := os.ReadFile(filepath.Join(c.dir, checkpointFile))
+	if os.IsNotExist(err) {
+		return "no checkpoint found"
+	}
+	if err != nil {
+		return err.Error()
+	}
+	if err := json.Unmarshal(data, &c.header); err != nil {
+		return err.Error()
+	}
+	switch {
+	case c.header.Version != CheckpointVersion:
+		return fmt.Sprintf("checkpoint version %d is not supported", c.header.Version)
+	case c.header.ProviderVersion != scope.ProviderVersion:
+		return fmt.Sprintf("provider version changed from %q to %q", c.header.ProviderVersion, scope.ProviderVersion)
+	case !sameStrings(c.header.Services, scope.Services):
+		return fmt.Sprintf("services changed from %q to %q", c.header.Services, scope.Services)
+	case !sameStrings(c.header.Filters, scope.Filters):
+		return fmt.Sprintf("filters changed from %q to %q", c.header.Filters, scope.Filters)
+	case c.header.Completed != nil && c.expired(*c.header.Completed):
+		return "the import completed " + time.Since(*c.header.Completed).Round(time.Second).String() + " ago"
+	}
+	if !sameStrings(c.header.Where, scope.Where) {
+		// the files were written with other --where expressions
+		c.header.Completed = nil
+		c.header.Where = scope.Where
+	}
+	return ""
+}
+
+// sameStrings reports whether a and b hold the same strings, in any order
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = append([]string{}, a...), append([]string{}, b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Checkpoint) writeHeader() error {
+	data, err := json.MarshalIndent(c.header, "", "  ")
+	if err != nil {
+		return err
+	}
+	return WriteFileAtomic(filepath.Join(c.dir, checkpointFile), data)
+}
+
+func (c *Checkpoint) expired(t time.Time) bool {
+	return c.maxAge > 0 && time.Since(t) > c.maxAge
+}
+
+func (c *Checkpoint) readRefreshed() error {
+	f, err := os.Open(filepath.Join(c.dir, refreshedFile))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		state := refreshedState{}
+		if err := json.Unmarshal(scanner.Bytes(), &state); err != nil || state.State == nil {
+			// the last line of a killed import
+			continue
+		}
+		if !c.expired(state.Time) {
+			c.refreshed[state.Type+"."+state.ID] = state.State
+		}
+	}
+	return scanner.Err()
+}
+
+// Completed reports whether all files of the scope were written
+func (c *Checkpoint) Completed() bool {
+	if c == nil {
+		return false
+	}
+	return c.header.Completed != nil
+}
+
+// Complete records that all files of the scope were written
+func (c *Checkpoint) Complete() error {
+	if c == nil {
+		return nil
+	}
+	now := time.Now()
+	c.header.Completed = &now
+	return c.writeHeader()
+}
+
+// Listed returns the resources of a service listed by a previous run
+func (c *Checkpoint) Listed(service string) ([]Resource, bool) {
+	if c == nil {
+		return nil, false
+	}
+	data, err := os.ReadFile(c.listedPath(service))
+	if err != nil {
+		return nil, false
+	}
+	listed := listedResources{}
+	if err := json.Unmarshal(data, &listed); err != nil {
+		log.Printf("ignoring checkpoint of %s: %v", service, err)
+		return nil, false
+	}
+	if c.expired(listed.Created) {
+		return nil, false
+	}
+	return listed.Resources, true
+}
+
+// RedactSensitive leaves the attributes schema marks as sensitive out of the states checkpointed from now on.
+// Resources restored by a resumed import then lack their values, so it is meant for imports which don't write
+// them, see SecretsOmit and SecretsVariables.
+func (c *Checkpoint) RedactSensitive(schema *providers.GetSchemaResponse) {
+	if c == nil || schema == nil {
+		return
+	}
+	c.sensitive = map[string][][]string{}
+	for resourceType, resourceSchema := range schema.ResourceTypes {
+		for _, path := range sensitiveAttributes(resourceSchema.Block, "") {
+			c.sensitive[resourceType] = append(c.sensitive[resourceType], strings.Split(path, "."))
+		}
+	}
+}
+
+// redacted returns a state without the sensitive attributes of its type, see RedactSensitive
+func (c *Checkpoint) redacted(resourceType string, state *terraform.InstanceState) *terraform.InstanceState {
+	paths := c.sensitive[resourceType]
+	if state == nil || len(paths) == 0 {
+		return state
+	}
+	redacted := state.DeepCopy()
+	for _, path := range paths {
+		redactStateSecret(redacted.Attributes, path)
+	}
+	return redacted
+}
+
+// SaveListed records the resources of a service after they were listed
+func (c *Checkpoint) SaveListed(service string, resources []Resource) error {
+	if c == nil {
+		return nil
+	}
+	if c.sensitive != nil {
+		resources = append([]Resource{}, resources...)
+		for i := range resources {
+			resources[i].InstanceState = c.redacted(resources[i].InstanceInfo.Type, resources[i].InstanceState)
+		}
+	}
+	data, err := json.Marshal(listedResources{Created: time.Now(), Resources: resources})
+	if err != nil {
+		return err
+	}
+	return WriteFileAtomic(c.listedPath(service), data)
+}
+
+func (c *Checkpoint) listedPath(service string) string {
+	return filepath.Join(c.dir, service+".listed.json")
+}
+
+// Restore sets the state of a resource refreshed by a previous run
+func (c *Checkpoint) Restore(r *Resource) bool {
+	if r.InstanceState == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	state, ok := c.refreshed[r.InstanceInfo.Type+"."+r.InstanceState.ID]
+	if ok {
+		r.InstanceState = state
+	}
+	return ok
+}
+
+// SaveRefreshed records the refreshed state of a resource
+func (c *Checkpoint) SaveRefreshed(r *Resource, id string) error {
+	state := c.redacted(r.InstanceInfo.Type, r.InstanceState)
+	data, err := json.Marshal(refreshedState{Time: time.Now(), Type: r.InstanceInfo.Type, ID: id, State: state})
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.refreshed[r.InstanceInfo.Type+"."+id] = r.InstanceState
+	// one write per line, so that a line is either written or truncated
+	_, err = c.log.Write(append(data, '\n'))
+	return err
+}
+
+// Close flushes the refreshed states
+func (c *Checkpoint) Close() error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.log.Sync(); err != nil {
+		c.log.Close()
+		return err
+	}
+	return c.log.Close()
+}
+
+// WriteFileAtomic replaces a file with data, readers see either the previous or the new content
+func WriteFileAtomic(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
@@ -0,0 +1,212 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/configs/configschema"
+	"github.com/hashicorp/terraform/providers"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func openTestCheckpoint(t *testing.T, dir string, scope CheckpointScope, maxAge time.Duration, resume bool) *Checkpoint {
+	c, err := OpenCheckpoint(dir, scope, maxAge, resume)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+var testScope = CheckpointScope{ProviderVersion: "~> 4.0.0", Services: []string{"networks", "firewall"}, Filters: []string{"compute_network=default"}}
+
+func TestCheckpointResume(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "google", "my-project")
+	c := openTestCheckpoint(t, dir, testScope, time.Hour, false)
+	listed := []Resource{prepareNoAttrs("ID1", "type1"), prepareNoAttrs("ID2", "type1")}
+	if err := c.SaveListed("networks", listed); err != nil {
+		t.Fatal(err)
+	}
+
+	// ID1 is refreshed, the process is killed while writing the state of ID2
+	refreshed := prepare("ID1", "type1", map[string]string{"name": "refreshed"}, map[string]interface{}{})
+	if err := c.SaveRefreshed(&refreshed, "ID1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.log.WriteString(`{"Time":"2026-01-01T00:00:00Z","Type":"type1","ID":"ID2","State":{"id":`); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	c = openTestCheckpoint(t, dir, testScope, time.Hour, true)
+	if c.Completed() {
+		t.Errorf("unexpected completed checkpoint")
+	}
+	resources, ok := c.Listed("networks")
+	if !ok || len(resources) != 2 || resources[1].InstanceState.ID != "ID2" {
+		t.Fatalf("unexpected listed resources %v", resources)
+	}
+	if _, ok := c.Listed("firewall"); ok {
+		t.Errorf("unexpected listed resources of an unlisted service")
+	}
+	if !c.Restore(&resources[0]) || resources[0].InstanceState.Attributes["name"] != "refreshed" {
+		t.Errorf("expected ID1 to be restored, got %v", resources[0].InstanceState)
+	}
+	if c.Restore(&resources[1]) {
+		t.Errorf("unexpected restored state of ID2 %v", resources[1].InstanceState)
+	}
+
+	// states appended after a truncated line are read
+	refreshed = prepare("ID2", "type1", map[string]string{"name": "refreshed"}, map[string]interface{}{})
+	if err := c.SaveRefreshed(&refreshed, "ID2"); err != nil {
+		t.Fatal(err)
+	}
+	c.Close()
+	c = openTestCheckpoint(t, dir, testScope, time.Hour, true)
+	if !c.Restore(&resources[1]) {
+		t.Errorf("expected ID2 to be restored")
+	}
+
+	if err := c.Complete(); err != nil {
+		t.Fatal(err)
+	}
+	if c = openTestCheckpoint(t, dir, testScope, time.Hour, true); !c.Completed() {
+		t.Errorf("expected the checkpoint to be completed")
+	}
+}
+
+func TestCheckpointRedactSensitive(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "google", "my-project")
+	c := openTestCheckpoint(t, dir, testScope, time.Hour, false)
+	c.RedactSensitive(&providers.GetSchemaResponse{ResourceTypes: map[string]providers.Schema{
+		"google_sql_user": {Block: &configschema.Block{
+			Attributes: map[string]*configschema.Attribute{
+				"name":     {Type: cty.String, Required: true},
+				"password": {Type: cty.String, Optional: true, Sensitive: true},
+			},
+		}},
+	}})
+	user := prepare("admin", "google_sql_user", map[string]string{"name": "admin", "password": "hunter2"}, map[string]interface{}{})
+	if err := c.SaveListed("sql", []Resource{user}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SaveRefreshed(&user, "admin"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if user.InstanceState.Attributes["password"] != "hunter2" {
+		t.Error("expected the state of the import to be kept")
+	}
+
+	for _, name := range []string{refreshedFile, "sql.listed.json"} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(data), "hunter2") || !strings.Contains(string(data), "admin") {
+			t.Errorf("expected %s without the password: %s", name, data)
+		}
+	}
+}
+
+func TestCheckpointInvalidation(t *testing.T) {
+	dir := t.TempDir()
+	for _, test := range []struct {
+		name   string
+		scope  CheckpointScope
+		maxAge time.Duration
+		resume bool
+	}{
+		{"expired", testScope, time.Nanosecond, true},
+		{"provider version", CheckpointScope{ProviderVersion: "~> 5.0.0", Services: testScope.Services, Filters: testScope.Filters}, time.Hour, true},
+		{"services", CheckpointScope{ProviderVersion: testScope.ProviderVersion, Services: []string{"networks"}, Filters: testScope.Filters}, time.Hour, true},
+		{"filters", CheckpointScope{ProviderVersion: testScope.ProviderVersion, Services: testScope.Services}, time.Hour, true},
+		{"no resume", testScope, time.Hour, false},
+	} {
+		c := openTestCheckpoint(t, dir, testScope, time.Hour, false)
+		if err := c.SaveListed("networks", []Resource{prepareNoAttrs("ID1", "type1")}); err != nil {
+			t.Fatal(err)
+		}
+		c.Close()
+		c = openTestCheckpoint(t, dir, test.scope, test.maxAge, test.resume)
+		if _, ok := c.Listed("networks"); ok {
+			t.Errorf("%s: expected the checkpoint to be invalidated", test.name)
+		}
+		c.Close()
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if filepath.Ext(entry.Name()) == ".tmp" {
+			t.Errorf("temporary file %s left behind", entry.Name())
+		}
+	}
+}
+
+func TestCheckpointWhereChanged(t *testing.T) {
+	dir := t.TempDir()
+	c := openTestCheckpoint(t, dir, testScope, time.Hour, false)
+	if err := c.SaveListed("networks", []Resource{prepareNoAttrs("ID1", "type1")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Complete(); err != nil {
+		t.Fatal(err)
+	}
+	c.Close()
+
+	// the services are listed in another order, the files were written without --where
+	scope := CheckpointScope{ProviderVersion: testScope.ProviderVersion, Services: []string{"firewall", "networks"}, Filters: testScope.Filters, Where: []string{"labels.env == prod"}}
+	c = openTestCheckpoint(t, dir, scope, time.Hour, true)
+	if c.Completed() {
+		t.Error("expected the scope to be imported again with another --where")
+	}
+	if _, ok := c.Listed("networks"); !ok {
+		t.Error("expected the listed resources to be kept")
+	}
+}
+
+func TestRefreshSchedulerCheckpoint(t *testing.T) {
+	c := openTestCheckpoint(t, t.TempDir(), CheckpointScope{}, 0, false)
+	options := DefaultRefreshOptions()
+	options.Checkpoint = c
+	resources := refreshTestResources("a", 2)
+	refreshed := *resources[0]
+	refreshed.InstanceState.Attributes = map[string]string{"restored": "true"}
+	if err := c.SaveRefreshed(&refreshed, "a-0"); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := newFakeReader(nil)
+	NewRefreshScheduler(reader, options).Refresh(context.Background(), resources)
+	if reader.reads["a-0"] != 0 || resources[0].InstanceState.Attributes["restored"] != "true" {
+		t.Errorf("expected a-0 to be restored, got %v after %d reads", resources[0].InstanceState, reader.reads["a-0"])
+	}
+	if reader.reads["a-1"] != 1 || !c.Restore(&Resource{InstanceInfo: resources[1].InstanceInfo, InstanceState: resources[1].InstanceState}) {
+		t.Errorf("expected a-1 to be refreshed and recorded")
+	}
+}
@@ -0,0 +1,354 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/configs/configschema"
+	"github.com/hashicorp/terraform/configs/hcl2shim"
+	"github.com/hashicorp/terraform/providers"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// StateResource is a managed resource read from an existing state file, attributes are flattened like InstanceState.Attributes
+type StateResource struct {
+	// Module is the address of the module managing the resource, e.g. module.networks, empty for the root module
+	Module     string
+	Type       string
+	Name       string
+	Attributes map[string]string
+}
+
+func (r StateResource) Address() string {
+	if r.Module != "" {
+		return r.Module + "." + r.Type + "." + r.Name
+	}
+	return r.Type + "." + r.Name
+}
+
+// Resource returns the resource of provider the state entry manages, with the attributes of the state
+func (r StateResource) Resource(provider string) Resource {
+	return NewResource(r.Attributes["id"], r.Name, r.Type, provider, r.Attributes, []string{}, map[string]interface{}{})
+}
+
+// DriftReport compares the resources found in the cloud with an existing state
+type DriftReport struct {
+	// Unmanaged resources are in the cloud but not in the state
+	Unmanaged []DriftResource `json:"unmanaged"`
+	// Orphaned resources are in the state but not in the cloud anymore
+	Orphaned []DriftResource `json:"orphaned"`
+	// Drifted resources are in both with different attribute values
+	Drifted []DriftResource `json:"drifted"`
+	// Unknown resources are in the state but the import did not see them, e.g. they failed to refresh or were
+	// left out by filters, they are not drift
+	Unknown []DriftResource `json:"unknown"`
+}
+
+type DriftResource struct {
+	Address    string           `json:"address"`
+	Type       string           `json:"type"`
+	ID         string           `json:"id"`
+	Attributes []AttributeDrift `json:"attributes,omitempty"`
+}
+
+type AttributeDrift struct {
+	Attribute string `json:"attribute"`
+	State     string `json:"state"`
+	Live      string `json:"live"`
+}
+
+func (r *DriftReport) HasDrift() bool {
+	return len(r.Unmanaged) > 0 || len(r.Orphaned) > 0 || len(r.Drifted) > 0
+}
+
+func (r *DriftReport) String() string {
+	return fmt.Sprintf("%d unmanaged, %d orphaned, %d drifted, %d unknown", len(r.Unmanaged), len(r.Orphaned), len(r.Drifted), len(r.Unknown))
+}
+
+// ReadStateResources reads the managed resources of a v3 or v4 state file. Typed v4 attributes are
+// flattened with the provider schema when it knows the resource type.
+func ReadStateResources(data []byte, schema *providers.GetSchemaResponse) ([]StateResource, error) {
+	var state struct {
+		Version   int `json:"version"`
+		Resources []struct {
+			Module    string `json:"module"`
+			Mode      string `json:"mode"`
+			Type      string `json:"type"`
+			Name      string `json:"name"`
+			Instances []struct {
+				IndexKey       interface{}       `json:"index_key"`
+				Attributes     json.RawMessage   `json:"attributes"`
+				AttributesFlat map[string]string `json:"attributes_flat"`
+			} `json:"instances"`
+		} `json:"resources"`
+		Modules []struct {
+			Resources map[string]struct {
+				Type    string `json:"type"`
+				Primary struct {
+					Attributes map[string]string `json:"attributes"`
+				} `json:"primary"`
+			} `json:"resources"`
+		} `json:"modules"`
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to read state: %v", err)
+	}
+	resources := []StateResource{}
+	for _, r := range state.Resources {
+		if r.Mode != "managed" {
+			continue
+		}
+		for _, instance := range r.Instances {
+			name := r.Name
+			if instance.IndexKey != nil {
+				name = fmt.Sprintf("%s[%v]", r.Name, instance.IndexKey)
+			}
+			attributes := instance.AttributesFlat
+			if len(instance.Attributes) > 0 {
+				var err error
+				if attributes, err = flattenStateAttributes(r.Type, instance.Attributes, schema); err != nil {
+					return nil, fmt.Errorf("failed to read %s.%s: %v", r.Type, name, err)
+				}
+			}
+			resources = append(resources, StateResource{Module: r.Module, Type: r.Type, Name: name, Attributes: attributes})
+		}
+	}
+	for _, module := range state.Modules {
+		for address, r := range module.Resources {
+			if strings.HasPrefix(address, "data.") {
+				continue
+			}
+			resources = append(resources, StateResource{
+				Type:       r.Type,
+				Name:       strings.TrimPrefix(address, r.Type+"."),
+				Attributes: r.Primary.Attributes,
+			})
+		}
+	}
+	return resources, nil
+}
+
+func flattenStateAttributes(resourceType string, attributes json.RawMessage, schema *providers.GetSchemaResponse) (map[string]string, error) {
+	if schema != nil {
+		if resourceSchema, ok := schema.ResourceTypes[resourceType]; ok && resourceSchema.Block != nil {
+			// a state written with an older schema version falls back to the generic flattening
+			if value, err := ctyjson.Unmarshal(attributes, resourceSchema.Block.ImpliedType()); err == nil {
+				return hcl2shim.FlatmapValueFromHCL2(value), nil
+			}
+		}
+	}
+	var value map[string]interface{}
+	if err := json.Unmarshal(attributes, &value); err != nil {
+		return nil, err
+	}
+	flat := map[string]string{}
+	flattenJSON("", value, flat)
+	return flat, nil
+}
+
+// flattenJSON flattens decoded JSON into flatmap keys, null values are skipped
+func flattenJSON(prefix string, value interface{}, flat map[string]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if prefix != "" {
+			flat[prefix+"%"] = strconv.Itoa(len(v))
+		}
+		for k, e := range v {
+			flattenJSON(prefix+k+".", e, flat)
+		}
+	case []interface{}:
+		flat[prefix+"#"] = strconv.Itoa(len(v))
+		for i, e := range v {
+			flattenJSON(prefix+strconv.Itoa(i)+".", e, flat)
+		}
+	case string:
+		flat[strings.TrimSuffix(prefix, ".")] = v
+	case bool:
+		flat[strings.TrimSuffix(prefix, ".")] = strconv.FormatBool(v)
+	case float64:
+		flat[strings.TrimSuffix(prefix, ".")] = strconv.FormatFloat(v, 'f', -1, 64)
+	}
+}
+
+// CompareState compares refreshed resources with the resources of a state, matching them by type and ID.
+// Only state entries of the resource types found in the cloud are considered, the state may also manage
+// services which were not imported. State entries missing from live are orphaned, unless unknown reports that
+// the import did not see them. unknown may be nil. Attributes the schema marks as computed only are ignored.
+func CompareState(live []Resource, state []StateResource, schema *providers.GetSchemaResponse, unknown func(StateResource) bool) *DriftReport {
+	report := &DriftReport{
+		Unmanaged: []DriftResource{},
+		Orphaned:  []DriftResource{},
+		Drifted:   []DriftResource{},
+		Unknown:   []DriftResource{},
+	}
+	liveTypes := map[string]bool{}
+	liveByID := map[string]Resource{}
+	for _, r := range live {
+		if r.InstanceState == nil || r.InstanceState.ID == "" {
+			continue
+		}
+		liveTypes[r.InstanceInfo.Type] = true
+		liveByID[r.InstanceInfo.Type+"/"+r.InstanceState.ID] = r
+	}
+	matched := map[string]bool{}
+	for _, s := range state {
+		if !liveTypes[s.Type] {
+			continue
+		}
+		key := s.Type + "/" + s.Attributes["id"]
+		r, exist := liveByID[key]
+		if !exist && unknown != nil && unknown(s) {
+			report.Unknown = append(report.Unknown, DriftResource{Address: s.Address(), Type: s.Type, ID: s.Attributes["id"]})
+			continue
+		}
+		if !exist {
+			report.Orphaned = append(report.Orphaned, DriftResource{Address: s.Address(), Type: s.Type, ID: s.Attributes["id"]})
+			continue
+		}
+		matched[key] = true
+		var block *configschema.Block
+		if schema != nil {
+			block = schema.ResourceTypes[s.Type].Block
+		}
+		if attributes := compareAttributes(s.Attributes, r.InstanceState.Attributes, block); len(attributes) > 0 {
+			report.Drifted = append(report.Drifted, DriftResource{Address: s.Address(), Type: s.Type, ID: s.Attributes["id"], Attributes: attributes})
+		}
+	}
+	for key, r := range liveByID {
+		if !matched[key] {
+			report.Unmanaged = append(report.Unmanaged, DriftResource{Address: r.ImportAddress(), Type: r.InstanceInfo.Type, ID: r.InstanceState.ID})
+		}
+	}
+	for _, resources := range [][]DriftResource{report.Unmanaged, report.Orphaned, report.Drifted, report.Unknown} {
+		sort.Slice(resources, func(i, j int) bool {
+			return resources[i].Address < resources[j].Address
+		})
+	}
+	return report
+}
+
+func compareAttributes(state, live map[string]string, block *configschema.Block) []AttributeDrift {
+	keys := map[string]struct{}{}
+	for k := range state {
+		keys[k] = struct{}{}
+	}
+	for k := range live {
+		keys[k] = struct{}{}
+	}
+	drift := []AttributeDrift{}
+	for k := range keys {
+		if k == "id" || isDriftIgnored(block, k) {
+			continue
+		}
+		stateValue, liveValue := state[k], live[k]
+		// an empty collection is stored either with a zero count or without any key
+		if isCountKey(k) && stateValue == "" {
+			stateValue = "0"
+		}
+		if isCountKey(k) && liveValue == "" {
+			liveValue = "0"
+		}
+		if stateValue != liveValue {
+			drift = append(drift, AttributeDrift{Attribute: k, State: stateValue, Live: liveValue})
+		}
+	}
+	sort.Slice(drift, func(i, j int) bool {
+		return drift[i].Attribute < drift[j].Attribute
+	})
+	return drift
+}
+
+func isCountKey(key string) bool {
+	return strings.HasSuffix(key, ".#") || strings.HasSuffix(key, ".%")
+}
+
+// isDriftIgnored reports whether the flatmap key is computed only or unknown to the schema
+func isDriftIgnored(block *configschema.Block, key string) bool {
+	if block == nil {
+		return false
+	}
+	parts := strings.Split(key, ".")
+	for i := 0; i < len(parts); i++ {
+		if attribute, ok := block.Attributes[parts[i]]; ok {
+			return attribute.Computed && !attribute.Optional
+		}
+		nested, ok := block.BlockTypes[parts[i]]
+		if !ok || parts[i] == "timeouts" {
+			return true
+		}
+		if nested.Nesting != configschema.NestingSingle && nested.Nesting != configschema.NestingGroup {
+			// skip the element index, or compare the element count
+			i++
+		}
+		if i+1 >= len(parts) {
+			return false
+		}
+		block = &nested.Block
+	}
+	return false
+}
+
+// WriteDriftText writes a human readable drift report
+func WriteDriftText(report *DriftReport, w io.Writer) error {
+	var b strings.Builder
+	if !report.HasDrift() {
+		b.WriteString("No drift detected.\n")
+	}
+	if len(report.Unmanaged) > 0 {
+		b.WriteString("Unmanaged resources, found in the cloud but not in the state:\n")
+		for _, r := range report.Unmanaged {
+			fmt.Fprintf(&b, "  + %s (id: %s)\n", r.Address, r.ID)
+		}
+	}
+	if len(report.Orphaned) > 0 {
+		b.WriteString("Orphaned resources, found in the state but not in the cloud:\n")
+		for _, r := range report.Orphaned {
+			fmt.Fprintf(&b, "  - %s (id: %s)\n", r.Address, r.ID)
+		}
+	}
+	if len(report.Drifted) > 0 {
+		b.WriteString("Drifted resources:\n")
+		for _, r := range report.Drifted {
+			fmt.Fprintf(&b, "  ~ %s (id: %s)\n", r.Address, r.ID)
+			for _, a := range r.Attributes {
+				fmt.Fprintf(&b, "      %s: %q => %q\n", a.Attribute, a.State, a.Live)
+			}
+		}
+	}
+	if len(report.Unknown) > 0 {
+		b.WriteString("Unknown resources, found in the state but not refreshed or left out by filters:\n")
+		for _, r := range report.Unknown {
+			fmt.Fprintf(&b, "  ? %s (id: %s)\n", r.Address, r.ID)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteDriftJSON writes the drift report as indented JSON
+func WriteDriftJSON(report *DriftReport, w io.Writer) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/configs/configschema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestReadStateResources(t *testing.T) {
+	for _, golden := range []string{"state_v4_schema.golden.json", "state_v4_flat.golden.json"} {
+		data, err := os.ReadFile(filepath.Join("test_data", golden))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resources, err := ReadStateResources(data, testProviderSchema())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resources) != 2 {
+			t.Fatalf("%s: expected 2 resources, got %d", golden, len(resources))
+		}
+		firewall := resources[0]
+		if firewall.Address() != "google_compute_firewall.tfer--resource-name" {
+			t.Errorf("%s: unexpected address %s", golden, firewall.Address())
+		}
+		for k, v := range map[string]string{"id": "resource-id", "priority": "1000", "allow.0.ports.1": "80", "labels.env": "prod"} {
+			if firewall.Attributes[k] != v {
+				t.Errorf("%s: expected %s=%s, got %v", golden, k, v, firewall.Attributes)
+			}
+		}
+	}
+}
+
+func TestCompareState(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("test_data", "state_v4_schema.golden.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := testProviderSchema()
+	schema.ResourceTypes["google_compute_firewall"].Block.Attributes["creation_timestamp"] = &configschema.Attribute{Type: cty.String, Computed: true}
+	state, err := ReadStateResources(data, schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state = append(state, StateResource{Type: "google_compute_firewall", Name: "deleted", Attributes: map[string]string{"id": "deleted-id"}})
+	state = append(state, StateResource{Type: "google_storage_bucket", Name: "not-imported", Attributes: map[string]string{"id": "bucket"}})
+	state = append(state, StateResource{Type: "google_compute_firewall", Name: "filtered", Attributes: map[string]string{"id": "filtered-id"}})
+
+	firewall := testStateResources()[1]
+	firewall.InstanceState.Attributes["priority"] = "900"
+	firewall.InstanceState.Attributes["creation_timestamp"] = "now"
+	unmanaged := NewResource("new-id", "new", "google_compute_firewall", "google", map[string]string{"id": "new-id"}, []string{}, map[string]interface{}{})
+
+	report := CompareState([]Resource{firewall, unmanaged}, state, schema, func(s StateResource) bool {
+		return s.Attributes["id"] == "filtered-id"
+	})
+	expected := &DriftReport{
+		Unmanaged: []DriftResource{{Address: "google_compute_firewall.tfer--new", Type: "google_compute_firewall", ID: "new-id"}},
+		Orphaned:  []DriftResource{{Address: "google_compute_firewall.deleted", Type: "google_compute_firewall", ID: "deleted-id"}},
+		Drifted: []DriftResource{{
+			Address:    "google_compute_firewall.tfer--resource-name",
+			Type:       "google_compute_firewall",
+			ID:         "resource-id",
+			Attributes: []AttributeDrift{{Attribute: "priority", State: "1000", Live: "900"}},
+		}},
+		Unknown: []DriftResource{{Address: "google_compute_firewall.filtered", Type: "google_compute_firewall", ID: "filtered-id"}},
+	}
+	if !reflect.DeepEqual(report, expected) {
+		t.Errorf("unexpected report %+v", report)
+	}
+	if !report.HasDrift() {
+		t.Errorf("expected drift")
+	}
+
+	var text bytes.Buffer
+	if err := WriteDriftText(report, &text); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(text.String(), `priority: "1000" => "900"`) || !strings.Contains(text.String(), "? google_compute_firewall.filtered") {
+		t.Errorf("unexpected text report:\n%s", text.String())
+	}
+}
+
+func TestCompareStateNoDrift(t *testing.T) {
+	resources := testStateResources()[:2]
+	state, err := PrintTfStateV4(resources, testProviderSchema(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stateResources, err := ReadStateResources(state, testProviderSchema())
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := CompareState(resources, stateResources, testProviderSchema(), nil)
+	if report.HasDrift() {
+		t.Errorf("unexpected drift %+v", report)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"context"
+	"sync"
+)
+
+// FanOut runs tasks concurrently, at most parallelism at once, starting them in order. Once a task failed or ctx
+// is done no more tasks are started. The first error, or the cause of ctx, is returned after the running tasks
+// returned.
+func FanOut(ctx context.Context, parallelism int, tasks []func(ctx context.Context) error) error {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	failed := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return firstErr != nil
+	}
+	running := make(chan struct{}, parallelism)
+	for _, task := range tasks {
+		select {
+		case running <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			fail(context.Cause(ctx))
+			break
+		}
+		if failed() {
+			break
+		}
+		wg.Add(1)
+		go func(task func(ctx context.Context) error) {
+			defer wg.Done()
+			defer func() { <-running }()
+			if err := task(ctx); err != nil {
+				fail(err)
+			}
+		}(task)
+	}
+	wg.Wait()
+	return firstErr
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFanOutParallelism(t *testing.T) {
+	var mu sync.Mutex
+	running, maxRunning, ran := 0, 0, 0
+	tasks := []func(ctx context.Context) error{}
+	for i := 0; i < 10; i++ {
+		tasks = append(tasks, func(ctx context.Context) error {
+			mu.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			mu.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			running--
+			ran++
+			mu.Unlock()
+			return nil
+		})
+	}
+	if err := FanOut(context.Background(), 3, tasks); err != nil {
+		t.Fatal(err)
+	}
+	if ran != 10 {
+		t.Errorf("ran %d tasks, want 10", ran)
+	}
+	if maxRunning != 3 {
+		t.Errorf("ran %d tasks at once, want 3", maxRunning)
+	}
+}
+
+func TestFanOutFailure(t *testing.T) {
+	failure := errors.New("failure")
+	var mu sync.Mutex
+	started := []int{}
+	tasks := []func(ctx context.Context) error{}
+	for i := 0; i < 5; i++ {
+		i := i
+		tasks = append(tasks, func(ctx context.Context) error {
+			mu.Lock()
+			started = append(started, i)
+			mu.Unlock()
+			if i == 1 {
+				return failure
+			}
+			return nil
+		})
+	}
+	if err := FanOut(context.Background(), 1, tasks); !errors.Is(err, failure) {
+		t.Fatalf("got %v, want %v", err, failure)
+	}
+	if len(started) != 2 {
+		t.Errorf("started tasks %v after a failure, want [0 1]", started)
+	}
+}
+
+func TestFanOutCanceled(t *testing.T) {
+	cause := errors.New("timed out")
+	ctx, cancel := context.WithCancelCause(context.Background())
+	ran := 0
+	tasks := []func(ctx context.Context) error{
+		func(ctx context.Context) error {
+			ran++
+			cancel(cause)
+			return nil
+		},
+		func(ctx context.Context) error {
+			ran++
+			return nil
+		},
+	}
+	if err := FanOut(ctx, 1, tasks); !errors.Is(err, cause) {
+		t.Fatalf("got %v, want %v", err, cause)
+	}
+	if ran != 1 {
+		t.Errorf("ran %d tasks after the cancellation, want 1", ran)
+	}
+}
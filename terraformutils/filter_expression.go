@@ -0,0 +1,714 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// FilterExpression is a --where expression selecting the resources to import, e.g.
+//
+//	type == "google_storage_bucket" && labels.env == "prod"
+//
+// Operands are string, number and boolean literals, lists like ["a", "b"] and paths. The paths type, id and
+// service are the resource type, import ID and terraformer service, other paths are refreshed attributes in
+// flatmap notation, e.g. labels.env or labels["app.kubernetes.io/name"]. attributes.type reads the attribute
+// type. Operators are, by precedence:
+//
+//	||
+//	&&
+//	== != < <= > >= =~ !~ in
+//	!
+//
+// =~ and !~ match a regular expression, in checks if a value is in a list or a key in a map. Numbers are compared
+// numerically. glob(path, "pattern") matches * and ? wildcards, has(path) checks if an attribute is set. A path
+// alone is true when it is set and not empty, false or 0.
+//
+// Before the refresh only type, id and service are known, attributes are unknown. A resource is dropped when the
+// expression is false whatever the attributes, otherwise it is refreshed and the expression is evaluated again.
+type FilterExpression struct {
+	source string
+	root   filterNode
+}
+
+// ParseFilterExpression parses a --where expression
+func ParseFilterExpression(source string) (*FilterExpression, error) {
+	tokens, err := lexFilter(source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter %q: %v", source, err)
+	}
+	p := &filterParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err == nil && p.peek().kind != tokenEOF {
+		err = fmt.Errorf("unexpected %s", p.peek())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter %q: %v", source, err)
+	}
+	return &FilterExpression{source: source, root: root}, nil
+}
+
+func (e *FilterExpression) String() string {
+	return e.source
+}
+
+// Match reports whether the resource of service is selected. Unless refreshed, attributes are unknown and Match
+// returns false only when the expression is false for any attributes.
+func (e *FilterExpression) Match(service string, r Resource, refreshed bool) bool {
+	return e.root.eval(&filterContext{service: service, resource: r, refreshed: refreshed}).truth() != triFalse
+}
+
+// FilterResources returns the resources of service selected by all expressions
+func FilterResources(expressions []*FilterExpression, service string, resources []Resource, refreshed bool) []Resource {
+	if len(expressions) == 0 {
+		return resources
+	}
+	filtered := []Resource{}
+	for _, r := range resources {
+		selected := true
+		for _, e := range expressions {
+			if !e.Match(service, r, refreshed) {
+				selected = false
+				break
+			}
+		}
+		if selected {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
+// tri is a boolean which may be unknown before the refresh
+type tri int
+
+const (
+	triFalse tri = iota
+	triTrue
+	triUnknown
+)
+
+func toTri(b bool) tri {
+	if b {
+		return triTrue
+	}
+	return triFalse
+}
+
+type filterKind int
+
+const (
+	kindNull filterKind = iota
+	kindUnknown
+	kindBool
+	kindString
+	kindList
+	kindMap
+)
+
+type filterValue struct {
+	kind    filterKind
+	boolean bool
+	str     string
+	list    []filterValue
+	keys    map[string]string
+}
+
+var (
+	nullValue    = filterValue{kind: kindNull}
+	unknownValue = filterValue{kind: kindUnknown}
+)
+
+func boolValue(t tri) filterValue {
+	if t == triUnknown {
+		return unknownValue
+	}
+	return filterValue{kind: kindBool, boolean: t == triTrue}
+}
+
+func (v filterValue) truth() tri {
+	switch v.kind {
+	case kindUnknown:
+		return triUnknown
+	case kindBool:
+		return toTri(v.boolean)
+	case kindString:
+		return toTri(v.str != "" && v.str != "false" && v.str != "0")
+	case kindList:
+		return toTri(len(v.list) > 0)
+	case kindMap:
+		return toTri(len(v.keys) > 0)
+	}
+	return triFalse
+}
+
+// scalar returns the value as a string, booleans are compared to attributes as "true" and "false"
+func (v filterValue) scalar() (string, bool) {
+	switch v.kind {
+	case kindString:
+		return v.str, true
+	case kindBool:
+		return strconv.FormatBool(v.boolean), true
+	}
+	return "", false
+}
+
+func equalValues(a, b filterValue) bool {
+	if a.kind == kindNull || b.kind == kindNull {
+		return a.kind == b.kind
+	}
+	as, aok := a.scalar()
+	bs, bok := b.scalar()
+	if !aok || !bok {
+		return false
+	}
+	if af, err := strconv.ParseFloat(as, 64); err == nil {
+		if bf, err := strconv.ParseFloat(bs, 64); err == nil {
+			return af == bf
+		}
+	}
+	return as == bs
+}
+
+// compareValues returns -1, 0 or 1, numerically when both values are numbers, false when they can't be compared
+func compareValues(a, b filterValue) (int, bool) {
+	as, aok := a.scalar()
+	bs, bok := b.scalar()
+	if !aok || !bok {
+		return 0, false
+	}
+	af, aerr := strconv.ParseFloat(as, 64)
+	bf, berr := strconv.ParseFloat(bs, 64)
+	if aerr == nil && berr == nil {
+		switch {
+		case af < bf:
+			return -1, true
+		case af > bf:
+			return 1, true
+		}
+		return 0, true
+	}
+	return strings.Compare(as, bs), true
+}
+
+type filterContext struct {
+	service   string
+	resource  Resource
+	refreshed bool
+}
+
+// lookup returns the value of a path, see FilterExpression
+func (c *filterContext) lookup(segments []string) filterValue {
+	if len(segments) == 1 {
+		switch segments[0] {
+		case "type":
+			return filterValue{kind: kindString, str: c.resource.InstanceInfo.Type}
+		case "id":
+			if c.resource.InstanceState == nil {
+				return nullValue
+			}
+			return filterValue{kind: kindString, str: c.resource.InstanceState.ID}
+		case "service":
+			return filterValue{kind: kindString, str: c.service}
+		}
+	}
+	if segments[0] == "attributes" && len(segments) > 1 {
+		segments = segments[1:]
+	}
+	if !c.refreshed {
+		return unknownValue
+	}
+	if c.resource.InstanceState == nil {
+		return nullValue
+	}
+	attributes := c.resource.InstanceState.Attributes
+	key := strings.Join(segments, ".")
+	if value, ok := attributes[key]; ok {
+		return filterValue{kind: kindString, str: value}
+	}
+	if count, ok := attributes[key+".#"]; ok {
+		n, _ := strconv.Atoi(count)
+		list := filterValue{kind: kindList}
+		for i := 0; i < n; i++ {
+			if value, ok := attributes[key+"."+strconv.Itoa(i)]; ok {
+				list.list = append(list.list, filterValue{kind: kindString, str: value})
+			}
+		}
+		return list
+	}
+	if _, ok := attributes[key+".%"]; ok {
+		m := filterValue{kind: kindMap, keys: map[string]string{}}
+		for k, value := range attributes {
+			if strings.HasPrefix(k, key+".") && k != key+".%" {
+				m.keys[strings.TrimPrefix(k, key+".")] = value
+			}
+		}
+		return m
+	}
+	return nullValue
+}
+
+type filterNode interface {
+	eval(c *filterContext) filterValue
+}
+
+type literalNode struct {
+	value filterValue
+}
+
+func (n literalNode) eval(*filterContext) filterValue {
+	return n.value
+}
+
+type listNode struct {
+	items []filterNode
+}
+
+func (n listNode) eval(c *filterContext) filterValue {
+	list := filterValue{kind: kindList}
+	for _, item := range n.items {
+		list.list = append(list.list, item.eval(c))
+	}
+	return list
+}
+
+type pathNode struct {
+	segments []string
+}
+
+func (n pathNode) eval(c *filterContext) filterValue {
+	return c.lookup(n.segments)
+}
+
+type notNode struct {
+	operand filterNode
+}
+
+func (n notNode) eval(c *filterContext) filterValue {
+	switch n.operand.eval(c).truth() {
+	case triTrue:
+		return boolValue(triFalse)
+	case triFalse:
+		return boolValue(triTrue)
+	}
+	return unknownValue
+}
+
+type logicalNode struct {
+	and         bool
+	left, right filterNode
+}
+
+func (n logicalNode) eval(c *filterContext) filterValue {
+	// an operand deciding the result wins over an unknown one
+	decisive, other := triTrue, triFalse
+	if n.and {
+		decisive, other = triFalse, triTrue
+	}
+	left := n.left.eval(c).truth()
+	if left == decisive {
+		return boolValue(decisive)
+	}
+	right := n.right.eval(c).truth()
+	if right == decisive {
+		return boolValue(decisive)
+	}
+	if left == triUnknown || right == triUnknown {
+		return unknownValue
+	}
+	return boolValue(other)
+}
+
+type compareNode struct {
+	op          string
+	left, right filterNode
+}
+
+func (n compareNode) eval(c *filterContext) filterValue {
+	left, right := n.left.eval(c), n.right.eval(c)
+	if left.kind == kindUnknown || right.kind == kindUnknown {
+		return unknownValue
+	}
+	switch n.op {
+	case "==":
+		return boolValue(toTri(equalValues(left, right)))
+	case "!=":
+		return boolValue(toTri(!equalValues(left, right)))
+	case "in":
+		return boolValue(toTri(contains(right, left)))
+	}
+	cmp, ok := compareValues(left, right)
+	if !ok {
+		return boolValue(triFalse)
+	}
+	switch n.op {
+	case "<":
+		return boolValue(toTri(cmp < 0))
+	case "<=":
+		return boolValue(toTri(cmp <= 0))
+	case ">":
+		return boolValue(toTri(cmp > 0))
+	}
+	return boolValue(toTri(cmp >= 0))
+}
+
+// contains reports whether value is an element of a list or a key of a map
+func contains(collection, value filterValue) bool {
+	switch collection.kind {
+	case kindList:
+		for _, item := range collection.list {
+			if equalValues(item, value) {
+				return true
+			}
+		}
+	case kindMap:
+		if key, ok := value.scalar(); ok {
+			_, exist := collection.keys[key]
+			return exist
+		}
+	}
+	return false
+}
+
+// matchNode matches a regular expression, glob patterns are compiled to regular expressions
+type matchNode struct {
+	operand filterNode
+	re      *regexp.Regexp
+	negate  bool
+}
+
+func (n matchNode) eval(c *filterContext) filterValue {
+	value := n.operand.eval(c)
+	if value.kind == kindUnknown {
+		return unknownValue
+	}
+	s, ok := value.scalar()
+	return boolValue(toTri(ok && n.re.MatchString(s) != n.negate))
+}
+
+type hasNode struct {
+	path pathNode
+}
+
+func (n hasNode) eval(c *filterContext) filterValue {
+	value := n.path.eval(c)
+	if value.kind == kindUnknown {
+		return unknownValue
+	}
+	return boolValue(toTri(value.kind != kindNull))
+}
+
+// globRegexp compiles a glob pattern, * matches any characters including /, ? a single character
+func globRegexp(pattern string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenOperator
+)
+
+type filterToken struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t filterToken) String() string {
+	if t.kind == tokenEOF {
+		return "end of filter"
+	}
+	return fmt.Sprintf("%q at %d", t.text, t.pos+1)
+}
+
+var filterOperators = []string{"&&", "||", "==", "!=", "<=", ">=", "=~", "!~", "<", ">", "!", "(", ")", "[", "]", ",", "."}
+
+func lexFilter(source string) ([]filterToken, error) {
+	tokens := []filterToken{}
+	runes := []rune(source)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"' || r == '\'':
+			start := i
+			var b strings.Builder
+			for i++; i < len(runes) && runes[i] != r; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				b.WriteRune(runes[i])
+			}
+			if i == len(runes) {
+				return nil, fmt.Errorf("unterminated string at %d", start+1)
+			}
+			i++
+			tokens = append(tokens, filterToken{kind: tokenString, text: b.String(), pos: start})
+		case unicode.IsDigit(r) || r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1]):
+			start := i
+			// a dot not followed by a digit separates path segments, e.g. retention_policy.0.retention_period
+			for i++; i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])); i++ {
+			}
+			if _, err := strconv.ParseFloat(string(runes[start:i]), 64); err != nil {
+				return nil, fmt.Errorf("invalid number %q at %d", string(runes[start:i]), start+1)
+			}
+			tokens = append(tokens, filterToken{kind: tokenNumber, text: string(runes[start:i]), pos: start})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i++; i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '-'); i++ {
+			}
+			tokens = append(tokens, filterToken{kind: tokenIdent, text: string(runes[start:i]), pos: start})
+		default:
+			operator := ""
+			for _, op := range filterOperators {
+				if strings.HasPrefix(string(runes[i:]), op) {
+					operator = op
+					break
+				}
+			}
+			if operator == "" {
+				return nil, fmt.Errorf("unexpected %q at %d", string(r), i+1)
+			}
+			tokens = append(tokens, filterToken{kind: tokenOperator, text: operator, pos: i})
+			i += len([]rune(operator))
+		}
+	}
+	return append(tokens, filterToken{kind: tokenEOF, pos: len(runes)}), nil
+}
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) peek() filterToken {
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) next() filterToken {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *filterParser) accept(operator string) bool {
+	if t := p.peek(); (t.kind == tokenOperator || t.kind == tokenIdent) && t.text == operator {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) expect(operator string) error {
+	if !p.accept(operator) {
+		return fmt.Errorf("expected %q, got %s", operator, p.peek())
+	}
+	return nil
+}
+
+func (p *filterParser) parseOr() (filterNode, error) {
+	left, err := p.parseAnd()
+	for err == nil && p.accept("||") {
+		var right filterNode
+		if right, err = p.parseAnd(); err == nil {
+			left = logicalNode{and: false, left: left, right: right}
+		}
+	}
+	return left, err
+}
+
+func (p *filterParser) parseAnd() (filterNode, error) {
+	left, err := p.parseComparison()
+	for err == nil && p.accept("&&") {
+		var right filterNode
+		if right, err = p.parseComparison(); err == nil {
+			left = logicalNode{and: true, left: left, right: right}
+		}
+	}
+	return left, err
+}
+
+func (p *filterParser) parseComparison() (filterNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	t := p.peek()
+	switch {
+	case t.kind == tokenOperator && (t.text == "=~" || t.text == "!~"):
+		p.next()
+		pattern := p.next()
+		if pattern.kind != tokenString {
+			return nil, fmt.Errorf("expected a regular expression string after %s, got %s", t.text, pattern)
+		}
+		re, err := regexp.Compile(pattern.text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression %q: %v", pattern.text, err)
+		}
+		return matchNode{operand: left, re: re, negate: t.text == "!~"}, nil
+	case t.kind == tokenOperator && isComparison(t.text), t.kind == tokenIdent && t.text == "in":
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return compareNode{op: t.text, left: left, right: right}, nil
+	}
+	return left, nil
+}
+
+func isComparison(operator string) bool {
+	switch operator {
+	case "==", "!=", "<", "<=", ">", ">=":
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) parseUnary() (filterNode, error) {
+	if p.accept("!") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *filterParser) parsePrimary() (filterNode, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenString:
+		return literalNode{value: filterValue{kind: kindString, str: t.text}}, nil
+	case tokenNumber:
+		return literalNode{value: filterValue{kind: kindString, str: t.text}}, nil
+	case tokenOperator:
+		switch t.text {
+		case "(":
+			node, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			return node, p.expect(")")
+		case "[":
+			list := listNode{}
+			for !p.accept("]") {
+				if len(list.items) > 0 {
+					if err := p.expect(","); err != nil {
+						return nil, err
+					}
+				}
+				item, err := p.parsePrimary()
+				if err != nil {
+					return nil, err
+				}
+				list.items = append(list.items, item)
+			}
+			return list, nil
+		}
+	case tokenIdent:
+		switch t.text {
+		case "true", "false":
+			return literalNode{value: filterValue{kind: kindBool, boolean: t.text == "true"}}, nil
+		case "has", "glob":
+			if p.peek().text == "(" {
+				return p.parseFunction(t.text)
+			}
+		}
+		return p.parsePath(t)
+	}
+	return nil, fmt.Errorf("unexpected %s", t)
+}
+
+func (p *filterParser) parsePath(first filterToken) (pathNode, error) {
+	path := pathNode{segments: []string{first.text}}
+	for {
+		switch {
+		case p.accept("."):
+			t := p.next()
+			if t.kind != tokenIdent && t.kind != tokenNumber {
+				return path, fmt.Errorf("expected an attribute name after %q, got %s", strings.Join(path.segments, "."), t)
+			}
+			path.segments = append(path.segments, t.text)
+		case p.accept("["):
+			t := p.next()
+			if t.kind != tokenString && t.kind != tokenNumber {
+				return path, fmt.Errorf("expected a key in brackets after %q, got %s", strings.Join(path.segments, "."), t)
+			}
+			path.segments = append(path.segments, t.text)
+			if err := p.expect("]"); err != nil {
+				return path, err
+			}
+		default:
+			return path, nil
+		}
+	}
+}
+
+func (p *filterParser) parseFunction(name string) (filterNode, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	t := p.next()
+	if t.kind != tokenIdent {
+		return nil, fmt.Errorf("expected a path as the first argument of %s, got %s", name, t)
+	}
+	path, err := p.parsePath(t)
+	if err != nil {
+		return nil, err
+	}
+	var node filterNode = hasNode{path: path}
+	if name == "glob" {
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+		pattern := p.next()
+		if pattern.kind != tokenString {
+			return nil, fmt.Errorf("expected a pattern string as the second argument of glob, got %s", pattern)
+		}
+		re, err := globRegexp(pattern.text)
+		if err != nil {
+			return nil, err
+		}
+		node = matchNode{operand: path, re: re}
+	}
+	return node, p.expect(")")
+}
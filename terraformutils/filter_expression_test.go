@@ -0,0 +1,124 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"testing"
+)
+
+func filterTestBucket() Resource {
+	return NewResource("prod-logs", "prod-logs", "google_storage_bucket", "google", map[string]string{
+		"id":                                  "prod-logs",
+		"name":                                "prod-logs",
+		"location":                            "EU",
+		"type":                                "regional",
+		"labels.%":                            "2",
+		"labels.env":                          "prod",
+		"labels.app.kubernetes.io/name":       "logs",
+		"cors.#":                              "0",
+		"retention_policy.0.retention_period": "86400",
+		"versioning.#":                        "1",
+		"versioning.0.enabled":                "true",
+		"default_event_based_hold":            "false",
+		"tags.#":                              "2",
+		"tags.0":                              "web",
+		"tags.1":                              "internal",
+	}, []string{}, map[string]interface{}{})
+}
+
+func TestFilterExpression(t *testing.T) {
+	for _, test := range []struct {
+		expression string
+		// before and after the refresh
+		initial, refreshed bool
+	}{
+		{`type == "google_storage_bucket" && labels.env == "prod"`, true, true},
+		{`type == "google_storage_bucket" && labels.env == "dev"`, true, false},
+		{`type == "google_compute_network" && labels.env == "prod"`, false, false},
+		{`type == "google_compute_network" || labels.env == "prod"`, true, true},
+		{`type == "google_storage_bucket" || labels.env == "dev"`, true, true},
+		{`!(id =~ "^prod-")`, false, false},
+		{`id !~ "^dev-"`, true, true},
+		{`glob(id, "prod-*")`, true, true},
+		{`glob(name, "dev-*")`, true, false},
+		{`service in ["gcs", "logging"]`, true, true},
+		{`location in ["US", "ASIA"]`, true, false},
+		{`"env" in labels && !("team" in labels)`, true, true},
+		{`"web" in tags`, true, true},
+		{`labels["app.kubernetes.io/name"] == "logs"`, true, true},
+		{`retention_policy.0.retention_period >= 3600`, true, true},
+		{`retention_policy.0.retention_period < 3600.5`, true, false},
+		{`retention_policy.0.retention_period == 86400.0`, true, true},
+		{`versioning.0.enabled == true && !default_event_based_hold`, true, true},
+		{`has(labels.env) && !has(labels.team)`, true, true},
+		{`attributes.type == "regional" && type != "regional"`, true, true},
+		{`labels.team != "ops"`, true, true},
+		{`cors`, true, false},
+		{`!id`, false, false},
+	} {
+		expression, err := ParseFilterExpression(test.expression)
+		if err != nil {
+			t.Errorf("%s: %v", test.expression, err)
+			continue
+		}
+		bucket := filterTestBucket()
+		if match := expression.Match("gcs", bucket, false); match != test.initial {
+			t.Errorf("%s: expected %t before the refresh, got %t", test.expression, test.initial, match)
+		}
+		if match := expression.Match("gcs", bucket, true); match != test.refreshed {
+			t.Errorf("%s: expected %t after the refresh, got %t", test.expression, test.refreshed, match)
+		}
+	}
+}
+
+func TestFilterExpressionErrors(t *testing.T) {
+	for _, expression := range []string{
+		``,
+		`type ==`,
+		`type == "a`,
+		`(type == "a"`,
+		`id =~ labels.env`,
+		`id =~ "("`,
+		`glob(id)`,
+		`has("id")`,
+		`labels.`,
+		`type == "a" type`,
+		`id == #`,
+	} {
+		if _, err := ParseFilterExpression(expression); err == nil {
+			t.Errorf("expected an error for %s", expression)
+		}
+	}
+}
+
+func TestFilterResources(t *testing.T) {
+	network := prepareNoAttrs("networks/default", "google_compute_network")
+	resources := []Resource{filterTestBucket(), network}
+	expressions := []*FilterExpression{}
+	for _, source := range []string{`type == "google_storage_bucket" || id == "networks/default"`, `labels.env == "prod"`} {
+		expression, err := ParseFilterExpression(source)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expressions = append(expressions, expression)
+	}
+	if filtered := FilterResources(expressions, "gcs", resources, false); len(filtered) != 2 {
+		t.Errorf("expected both resources to be refreshed, got %d", len(filtered))
+	}
+	filtered := FilterResources(expressions, "gcs", resources, true)
+	if len(filtered) != 1 || filtered[0].InstanceInfo.Type != "google_storage_bucket" {
+		t.Errorf("expected the bucket only, got %v", filtered)
+	}
+}
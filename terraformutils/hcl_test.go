@@ -15,8 +15,15 @@
 package terraformutils
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform/configs/configschema"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func TestPrintResource(t *testing.T) {
@@ -52,3 +59,195 @@ func TestPrintResource(t *testing.T) {
 		t.Errorf("failed to parse data %s", string(data))
 	}
 }
+
+// testHclCorpusSchema describes the awkward resources of the HCL corpus
+func testHclCorpusSchema() map[string]*configschema.Block {
+	return map[string]*configschema.Block{
+		"google_cloudbuild_trigger": {
+			Attributes: map[string]*configschema.Attribute{
+				"name":          {Type: cty.String, Optional: true},
+				"substitutions": {Type: cty.Map(cty.String), Optional: true},
+				"tags":          {Type: cty.List(cty.String), Optional: true},
+			},
+			BlockTypes: map[string]*configschema.NestedBlock{
+				"build": {
+					Nesting: configschema.NestingList,
+					Block: configschema.Block{
+						Attributes: map[string]*configschema.Attribute{
+							"images": {Type: cty.List(cty.String), Optional: true},
+						},
+						BlockTypes: map[string]*configschema.NestedBlock{
+							"step": {
+								Nesting: configschema.NestingList,
+								Block: configschema.Block{
+									Attributes: map[string]*configschema.Attribute{
+										"name": {Type: cty.String, Required: true},
+										"args": {Type: cty.List(cty.String), Optional: true},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"google_container_node_pool": {
+			Attributes: map[string]*configschema.Attribute{
+				"name":           {Type: cty.String, Optional: true},
+				"node_count":     {Type: cty.Number, Optional: true},
+				"taint":          {Type: cty.List(cty.Object(map[string]cty.Type{"key": cty.String, "value": cty.String})), Optional: true},
+				"node_locations": {Type: cty.Set(cty.String), Optional: true},
+			},
+			BlockTypes: map[string]*configschema.NestedBlock{
+				"management": {
+					Nesting: configschema.NestingSingle,
+					Block: configschema.Block{
+						Attributes: map[string]*configschema.Attribute{
+							"auto_repair": {Type: cty.Bool, Optional: true},
+						},
+					},
+				},
+				"setting": {
+					Nesting: configschema.NestingMap,
+					Block: configschema.Block{
+						Attributes: map[string]*configschema.Attribute{
+							"value": {Type: cty.String, Optional: true},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func testHclCorpus() []Resource {
+	glueJob := prepare("job", "aws_glue_job", map[string]string{"default_arguments.%": "2"}, map[string]interface{}{
+		"name": "job",
+		"default_arguments": map[string]interface{}{
+			"--job-language":   "python",
+			"--extra-py-files": "s3://bucket/lib.zip",
+		},
+		"command": []interface{}{map[string]interface{}{"script_location": "s3://bucket/script.py"}},
+	})
+	policy := prepare("policy", "aws_iam_policy", map[string]string{}, map[string]interface{}{
+		"name":        "policy",
+		"policy":      "<<POLICY\n{\"Statement\":[{\"Action\":\"s3:GetObject\",\"Effect\":\"Allow\",\"Resource\":\"arn:aws:s3:::$${aws:username}/*\"}],\"Version\":\"2012-10-17\"}\nPOLICY",
+		"description": "quotes \" backslash \\ tab \t newline \n directive %{if} unicode é",
+		"path":        "${data.terraform_remote_state.iam.outputs.path}",
+		"user_data":   "<<EOF\n#!/bin/bash\necho hello\nEOF",
+	})
+	bucket := prepare("bucket", "google_storage_bucket_iam_policy", map[string]string{}, map[string]interface{}{
+		"policy_data": "<<POLICY\n{\"version\":1,\"bindings\":[{\"role\":\"roles/viewer\",\"condition\":{\"expression\":\"a < b && c > d ${x} %{y}\"}}],\"etag\":12345678901234567890}\nPOLICY",
+		"script":      "<<-EOT\n  echo ${HOME}\n  EOT",
+	})
+	trigger := prepare("trigger", "google_cloudbuild_trigger", map[string]string{}, map[string]interface{}{
+		"name": "trigger",
+		"substitutions": map[string]interface{}{
+			"_REGION":            "europe-west1",
+			"kubernetes.io/name": "app",
+		},
+		"tags": []interface{}{"b", "a", "c"},
+		"build": []interface{}{map[string]interface{}{
+			"images": []interface{}{"gcr.io/p/b", "gcr.io/p/a"},
+			"step": []interface{}{
+				map[string]interface{}{"name": "gcr.io/cloud-builders/docker", "args": []interface{}{"build", "-t", "gcr.io/p/a", "."}},
+				map[string]interface{}{"name": "gcr.io/cloud-builders/docker", "args": []interface{}{"push", "gcr.io/p/a"}},
+				map[string]interface{}{"name": "alpine", "args": []interface{}{"echo", "done"}},
+			},
+		}},
+	})
+	trigger.PreserveOrder = []string{"build.step", "build.step.args"}
+	trigger.schema = testHclCorpusSchema()["google_cloudbuild_trigger"]
+	nodePool := prepare("pool", "google_container_node_pool", map[string]string{}, map[string]interface{}{
+		"name":           "pool",
+		"node_count":     12345678901234567,
+		"node_locations": []interface{}{},
+		"taint": []interface{}{
+			map[string]interface{}{"key": "dedicated", "value": "gpu"},
+		},
+		"management": map[string]interface{}{"auto_repair": true},
+		"setting": map[string]interface{}{
+			"b": map[string]interface{}{"value": "2"},
+			"a": map[string]interface{}{"value": "1"},
+		},
+		"removed": nil,
+	})
+	nodePool.schema = testHclCorpusSchema()["google_container_node_pool"]
+	return []Resource{glueJob, policy, bucket, trigger, nodePool}
+}
+
+func TestPrintResourceCorpus(t *testing.T) {
+	data, err := HclPrintResource(testHclCorpus(), map[string]interface{}{}, "hcl", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, diags := hclsyntax.ParseConfig(data, "corpus.tf", hcl.InitialPos); diags.HasErrors() {
+		t.Fatalf("invalid HCL: %s\n%s", diags.Error(), data)
+	}
+	goldenPath := filepath.Join("test_data", "hcl_corpus.golden.tf")
+	if *updateGolden {
+		if err := os.WriteFile(goldenPath, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(expected) != string(data) {
+		t.Errorf("HCL does not match %s, got:\n%s", goldenPath, data)
+	}
+}
+
+func TestHeredocValues(t *testing.T) {
+	for heredoc, want := range map[string]string{
+		"<<-EOT\n  echo ${HOME}\n  EOT":                  "echo ${HOME}\n",
+		"<<POLICY\n{\"a\":\"x < y & ${z}\"}\nPOLICY":     "{\n  \"a\": \"x < y & ${z}\"\n}\n",
+		"<<POLICY\n{\"a\":\"$${aws:username}\"}\nPOLICY": "{\n  \"a\": \"${aws:username}\"\n}\n",
+	} {
+		data := append([]byte("value = "), stringTokens(heredoc, true).Bytes()...)
+		file, diags := hclsyntax.ParseConfig(append(data, '\n'), "heredoc.tf", hcl.InitialPos)
+		if diags.HasErrors() {
+			t.Fatalf("invalid HCL: %s\n%s", diags.Error(), data)
+		}
+		attributes, _ := file.Body.JustAttributes()
+		value, diags := attributes["value"].Expr.Value(nil)
+		if diags.HasErrors() {
+			t.Fatalf("%s: %s", data, diags.Error())
+		}
+		if value.AsString() != want {
+			t.Errorf("%q evaluates to %q, want %q", heredoc, value.AsString(), want)
+		}
+	}
+}
+
+func TestTemplateSequences(t *testing.T) {
+	policy := "${aws:username} and %{ if x }"
+	stateReference := "${google_compute_network.a.self_link}"
+	r := prepare("subnetwork", "google_compute_subnetwork", map[string]string{
+		"description": policy,
+		"network":     stateReference,
+	}, map[string]interface{}{
+		"description": policy,
+		"network":     stateReference,
+		"region":      "${google_compute_region.b.name}",
+		"name":        "prefix-${data.terraform_remote_state.dns.outputs.zone}",
+	})
+	data, err := HclPrintResource([]Resource{r}, map[string]interface{}{}, "hcl", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, diags := hclsyntax.ParseConfig(data, "resources.tf", hcl.InitialPos); diags.HasErrors() {
+		t.Fatalf("invalid HCL: %s\n%s", diags.Error(), data)
+	}
+	for _, s := range []string{
+		`description = "$${aws:username} and %%{ if x }"`,
+		`network     = "$${google_compute_network.a.self_link}"`,
+		`region      = google_compute_region.b.name`,
+		`name        = "prefix-${data.terraform_remote_state.dns.outputs.zone}"`,
+	} {
+		if !strings.Contains(string(data), s) {
+			t.Errorf("expected %s in:\n%s", s, data)
+		}
+	}
+}
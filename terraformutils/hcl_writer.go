@@ -0,0 +1,432 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform/configs/configschema"
+)
+
+// topLevelLabels is the number of labels of top level blocks, e.g. resource "type" "name"
+var topLevelLabels = map[string]int{
+	"resource": 2,
+	"data":     2,
+	"provider": 1,
+	"output":   1,
+	"variable": 1,
+	"module":   1,
+}
+
+// heredocRe matches strings the providers turn into heredocs, e.g. "<<POLICY\n{...}\nPOLICY"
+// or indented heredocs like "<<-EOT\n  ...\n  EOT"
+var heredocRe = regexp.MustCompile(`(?s)^<<(-?)([A-Za-z_][A-Za-z0-9_]*)\n(.*)\n[ \t]*([A-Za-z_][A-Za-z0-9_]*)$`)
+
+// hclWriter renders Terraform configuration with hclwrite. Resource bodies follow the provider schema when it
+// is known: schema attributes are written as attributes, with object values for maps and objects, and
+// schema block types as nested blocks. Without a schema, maps are written as attributes when their
+// flatmap key was a map (mapsObjects) and as nested blocks otherwise. The strings of a resource found in
+// literalsByResource are provider values, their template sequences are escaped.
+type hclWriter struct {
+	sort               bool
+	mapsObjects        map[string]struct{}
+	hintsByResource    map[string]map[string][]string
+	schemas            map[string]*configschema.Block
+	literalsByResource map[string]map[string]map[string]bool
+}
+
+func (w *hclWriter) write(data interface{}) ([]byte, error) {
+	// the JSON round trip normalizes typed maps, slices and numbers
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling terraform data to json: %v", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(dataJSON))
+	dec.UseNumber()
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+	root, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("error writing HCL: expected an object, got %T", value)
+	}
+
+	file := hclwrite.NewEmptyFile()
+	for i, blockType := range sortedKeys(root) {
+		if i > 0 {
+			file.Body().AppendNewline()
+		}
+		if blockType == "terraform" {
+			w.writeTerraformBlocks(file.Body(), root[blockType])
+			continue
+		}
+		w.writeTopLevelBlocks(file.Body(), blockType, nil, topLevelLabels[blockType], root[blockType])
+	}
+	return hclwrite.Format(file.Bytes()), nil
+}
+
+// writeTopLevelBlocks walks down the label levels of a top level block type and writes its blocks
+func (w *hclWriter) writeTopLevelBlocks(body *hclwrite.Body, blockType string, labels []string, remaining int, value interface{}) {
+	if list, ok := value.([]interface{}); ok && remaining > 0 {
+		for i, e := range list {
+			if i > 0 {
+				body.AppendNewline()
+			}
+			w.writeTopLevelBlocks(body, blockType, labels, remaining, e)
+		}
+		return
+	}
+	if remaining > 0 {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return
+		}
+		for i, label := range sortedKeys(m) {
+			if i > 0 {
+				body.AppendNewline()
+			}
+			w.writeTopLevelBlocks(body, blockType, append(append([]string{}, labels...), label), remaining-1, m[label])
+		}
+		return
+	}
+	var schema *configschema.Block
+	var hints []string
+	var literals map[string]bool
+	if blockType == "resource" && len(labels) == 2 {
+		schema = w.schemas[labels[0]]
+		hints = w.hintsByResource[labels[0]][labels[1]]
+		literals = w.literalsByResource[labels[0]][labels[1]]
+	}
+	for _, item := range blockBodies(value) {
+		block := body.AppendNewBlock(blockType, labels)
+		w.writeBody(block.Body(), item, schema, "", hints, literals)
+	}
+}
+
+// writeTerraformBlocks writes the terraform block, its required_providers entries are object attributes and
+// its backend is a labeled block
+func (w *hclWriter) writeTerraformBlocks(body *hclwrite.Body, value interface{}) {
+	for _, item := range blockBodies(value) {
+		terraform := body.AppendNewBlock("terraform", nil)
+		for _, key := range sortedKeys(item) {
+			switch key {
+			case "required_providers":
+				for _, providers := range blockBodies(item[key]) {
+					requiredProviders := terraform.Body().AppendNewBlock("required_providers", nil)
+					for _, name := range sortedKeys(providers) {
+						requiredProviders.Body().SetAttributeRaw(name, w.valueTokens(providers[name], name, nil))
+					}
+				}
+			case "backend":
+				for _, backends := range blockBodies(item[key]) {
+					for _, name := range sortedKeys(backends) {
+						for _, config := range blockBodies(backends[name]) {
+							backend := terraform.Body().AppendNewBlock("backend", []string{name})
+							w.writeBody(backend.Body(), config, nil, "", nil, nil)
+						}
+					}
+				}
+			default:
+				w.writeBody(terraform.Body(), map[string]interface{}{key: item[key]}, nil, "", nil, nil)
+			}
+		}
+	}
+}
+
+// blockBodies returns the bodies of the blocks value stands for, a single object or a list of objects
+func blockBodies(value interface{}) []map[string]interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return []map[string]interface{}{v}
+	case []interface{}:
+		bodies := []map[string]interface{}{}
+		for _, e := range v {
+			if m, ok := e.(map[string]interface{}); ok {
+				bodies = append(bodies, m)
+			}
+		}
+		return bodies
+	}
+	return nil
+}
+
+// isBlockList reports whether value can only be written as nested blocks in a body without schema
+func isBlockList(value interface{}) bool {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k := range v {
+			if !hclsyntax.ValidIdentifier(k) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		if len(v) == 0 {
+			return false
+		}
+		for _, e := range v {
+			if !isBlockList(e) {
+				return false
+			}
+			if _, ok := e.(map[string]interface{}); !ok {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func (w *hclWriter) writeBody(body *hclwrite.Body, item map[string]interface{}, schema *configschema.Block, path string, hints []string, literals map[string]bool) {
+	attributes := []string{}
+	blocks := []string{}
+	for _, key := range sortedKeys(item) {
+		if item[key] == nil {
+			continue
+		}
+		if schema != nil {
+			if _, ok := schema.Attributes[key]; ok {
+				attributes = append(attributes, key)
+				continue
+			}
+			if _, ok := schema.BlockTypes[key]; ok {
+				blocks = append(blocks, key)
+				continue
+			}
+		}
+		if _, isMap := w.mapsObjects[path+key]; isMap || !hclsyntax.ValidIdentifier(key) || !isBlockList(item[key]) {
+			attributes = append(attributes, key)
+		} else {
+			blocks = append(blocks, key)
+		}
+	}
+
+	for _, key := range attributes {
+		if !hclsyntax.ValidIdentifier(key) {
+			log.Printf("[ERR]: %s%s is not a valid argument name, skipping it", path, key)
+			continue
+		}
+		body.SetAttributeRaw(key, w.valueTokens(item[key], w.orderedPath(path+key, hints), literals))
+	}
+
+	if len(attributes) > 0 && len(blocks) > 0 {
+		body.AppendNewline()
+	}
+	for _, key := range blocks {
+		var nested *configschema.NestedBlock
+		if schema != nil {
+			nested = schema.BlockTypes[key]
+		}
+		var nestedSchema *configschema.Block
+		if nested != nil {
+			nestedSchema = &nested.Block
+		}
+		type labeledBody struct {
+			labels []string
+			body   map[string]interface{}
+		}
+		var bodies []labeledBody
+		if nested != nil && nested.Nesting == configschema.NestingMap {
+			if m, ok := item[key].(map[string]interface{}); ok {
+				for _, label := range sortedKeys(m) {
+					for _, b := range blockBodies(m[label]) {
+						bodies = append(bodies, labeledBody{labels: []string{label}, body: b})
+					}
+				}
+			}
+		} else {
+			for _, b := range blockBodies(item[key]) {
+				bodies = append(bodies, labeledBody{body: b})
+			}
+		}
+		rendered := make([]*hclwrite.Block, len(bodies))
+		for i, b := range bodies {
+			rendered[i] = hclwrite.NewBlock(key, b.labels)
+			w.writeBody(rendered[i].Body(), b.body, nestedSchema, path+key+".", hints, literals)
+		}
+		if w.sort && w.orderedPath(path+key, hints) == "" {
+			sort.SliceStable(rendered, func(i, j int) bool {
+				return string(rendered[i].BuildTokens(nil).Bytes()) < string(rendered[j].BuildTokens(nil).Bytes())
+			})
+		}
+		for _, block := range rendered {
+			body.AppendBlock(block)
+		}
+	}
+}
+
+// orderedPath returns path when the resource asked to preserve the order of its elements, "" otherwise
+func (w *hclWriter) orderedPath(path string, hints []string) string {
+	for _, hint := range hints {
+		if hint == path {
+			return path
+		}
+	}
+	return ""
+}
+
+// valueTokens renders an attribute value, list elements are sorted unless ordered is set. The strings found in
+// literals are written as plain strings.
+func (w *hclWriter) valueTokens(value interface{}, ordered string, literals map[string]bool) hclwrite.Tokens {
+	switch v := value.(type) {
+	case nil:
+		return hclwrite.Tokens{{Type: hclsyntax.TokenIdent, Bytes: []byte("null")}}
+	case bool:
+		return hclwrite.Tokens{{Type: hclsyntax.TokenIdent, Bytes: []byte(fmt.Sprint(v))}}
+	case json.Number:
+		return hclwrite.Tokens{{Type: hclsyntax.TokenNumberLit, Bytes: []byte(v.String())}}
+	case string:
+		return stringTokens(v, literals[v])
+	case []interface{}:
+		elements := make([]hclwrite.Tokens, len(v))
+		multiline := false
+		for i, e := range v {
+			elements[i] = w.valueTokens(e, "", literals)
+			if _, isObject := e.(map[string]interface{}); isObject {
+				multiline = true
+			}
+		}
+		if w.sort && ordered == "" {
+			sort.SliceStable(elements, func(i, j int) bool {
+				return string(elements[i].Bytes()) < string(elements[j].Bytes())
+			})
+		}
+		tokens := hclwrite.Tokens{{Type: hclsyntax.TokenOBrack, Bytes: []byte("[")}}
+		for i, e := range elements {
+			if multiline {
+				tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")})
+			}
+			tokens = append(tokens, e...)
+			if multiline || i < len(elements)-1 {
+				tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenComma, Bytes: []byte(",")})
+			}
+		}
+		if multiline {
+			tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")})
+		}
+		return append(tokens, &hclwrite.Token{Type: hclsyntax.TokenCBrack, Bytes: []byte("]")})
+	case map[string]interface{}:
+		tokens := hclwrite.Tokens{{Type: hclsyntax.TokenOBrace, Bytes: []byte("{")}}
+		if len(v) == 0 {
+			return append(tokens, &hclwrite.Token{Type: hclsyntax.TokenCBrace, Bytes: []byte("}")})
+		}
+		tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")})
+		for _, key := range sortedKeys(v) {
+			if hclsyntax.ValidIdentifier(key) {
+				tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenIdent, Bytes: []byte(key)})
+			} else {
+				tokens = append(tokens, quotedTokens(key)...)
+			}
+			tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenEqual, Bytes: []byte("=")})
+			tokens = append(tokens, w.valueTokens(v[key], "", literals)...)
+			tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")})
+		}
+		return append(tokens, &hclwrite.Token{Type: hclsyntax.TokenCBrace, Bytes: []byte("}")})
+	}
+	return stringTokens(fmt.Sprint(value), false)
+}
+
+// stringTokens renders a string as a quoted template. The interpolations terraformer generates, like
+// ${data.terraform_remote_state...} or the references resolved by --resolve-references, are kept, the template
+// sequences of literal provider values are escaped. Provider heredocs are written as real heredocs, with their JSON
+// content indented.
+func stringTokens(s string, literal bool) hclwrite.Tokens {
+	// a lone interpolation of a reference, like "${google_compute_network.default.self_link}", is written bare
+	if !literal && strings.HasPrefix(s, "${") && strings.HasSuffix(s, "}") {
+		if traversal, diags := hclsyntax.ParseTraversalAbs([]byte(s[2:len(s)-1]), "", hcl.Pos{Line: 1, Column: 1}); !diags.HasErrors() {
+			return hclwrite.TokensForTraversal(traversal)
+		}
+	}
+	if m := heredocRe.FindStringSubmatch(s); m != nil && m[2] == m[4] {
+		content := m[3]
+		// indenting the JSON as is keeps the order of keys, the numbers and the escapes of the provider
+		if json.Valid([]byte(content)) {
+			indented := bytes.Buffer{}
+			if err := json.Indent(&indented, []byte(content), "", "  "); err == nil {
+				content = indented.String()
+			}
+		}
+		return hclwrite.Tokens{
+			{Type: hclsyntax.TokenOHeredoc, Bytes: []byte("<<" + m[1] + m[2] + "\n")},
+			{Type: hclsyntax.TokenStringLit, Bytes: []byte(escapeHeredoc(content) + "\n")},
+			{Type: hclsyntax.TokenCHeredoc, Bytes: []byte(m[2])},
+		}
+	}
+	if literal {
+		return quotedTokens(s)
+	}
+	// terraformer generates no template directives
+	return templateTokens(strings.ReplaceAll(s, "%{", "%%{"))
+}
+
+// escapeHeredoc escapes the template sequences ${ and %{ of heredoc content and literal strings, e.g. the
+// ${aws:username} policy variables of IAM policies. Sequences escaped by the provider, like $${aws:username}, are kept.
+func escapeHeredoc(s string) string {
+	escaped := strings.Builder{}
+	for i := 0; i < len(s); i++ {
+		switch {
+		case strings.HasPrefix(s[i:], "$${") || strings.HasPrefix(s[i:], "%%{"):
+			escaped.WriteString(s[i : i+3])
+			i += 2
+		case strings.HasPrefix(s[i:], "${") || strings.HasPrefix(s[i:], "%{"):
+			escaped.WriteByte(s[i])
+			escaped.WriteByte(s[i])
+		default:
+			escaped.WriteByte(s[i])
+		}
+	}
+	return escaped.String()
+}
+
+var quotedEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`"`, `\"`,
+	"\n", `\n`,
+	"\r", `\r`,
+	"\t", `\t`,
+)
+
+// quotedTokens renders a literal string, its template sequences are escaped
+func quotedTokens(s string) hclwrite.Tokens {
+	return templateTokens(escapeHeredoc(s))
+}
+
+// templateTokens renders a quoted template, its template sequences are kept
+func templateTokens(s string) hclwrite.Tokens {
+	return hclwrite.Tokens{
+		{Type: hclsyntax.TokenOQuote, Bytes: []byte(`"`)},
+		{Type: hclsyntax.TokenQuotedLit, Bytes: []byte(quotedEscaper.Replace(s))},
+		{Type: hclsyntax.TokenCQuote, Bytes: []byte(`"`)},
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
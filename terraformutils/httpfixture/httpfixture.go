@@ -0,0 +1,294 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package httpfixture records and replays the HTTP requests and unary gRPC calls of the API clients of a
+// provider, e.g. to reproduce an import offline or in tests
+//
+//	fixture, err := httpfixture.Load("testdata/project.json")
+//	replayer, err := httpfixture.NewReplayer(fixture)
+//	provider.SetAPIMiddleware(replayer)
+package httpfixture
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Interaction is a recorded HTTP request and its response
+type Interaction struct {
+	Method string `json:"method"`
+	// URL is the URL of the request, its query parameters in any order
+	URL string `json:"url"`
+	// Request is the request body, see Body
+	Request json.RawMessage `json:"request,omitempty"`
+	Status  int             `json:"status"`
+	Header  http.Header     `json:"header,omitempty"`
+	// Body is the response body, a JSON object or array, or a JSON string holding the text of any other body
+	Body json.RawMessage `json:"body,omitempty"`
+}
+
+// Call is a recorded unary gRPC call, its request and response messages in the JSON mapping of protobuf
+type Call struct {
+	Method   string          `json:"method"`
+	Request  json.RawMessage `json:"request"`
+	Response json.RawMessage `json:"response,omitempty"`
+	// Code and Message are the status of a failed call
+	Code    uint32 `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// Fixture is the interactions and calls of a recording, in the format of the files of Load
+type Fixture struct {
+	Interactions []Interaction `json:"interactions"`
+	Calls        []Call        `json:"calls,omitempty"`
+}
+
+// Middleware routes the API calls of the clients of a provider through a Recorder or a Replayer
+type Middleware interface {
+	// RoundTripper returns the transport of HTTP clients, base sends authenticated requests. A Replayer
+	// ignores base.
+	RoundTripper(base http.RoundTripper) http.RoundTripper
+	// UnaryClientInterceptor returns the interceptor of gRPC clients
+	UnaryClientInterceptor() grpc.UnaryClientInterceptor
+	// Offline is true when the calls are not sent, the clients need no credentials
+	Offline() bool
+}
+
+// ignoredParameters are query parameters the API clients add to every request, requests match regardless
+var ignoredParameters = []string{"alt", "prettyPrint"}
+
+// Load returns the interactions of JSON fixture files, in the order of the files
+func Load(paths ...string) (*Fixture, error) {
+	fixture := &Fixture{}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var file Fixture
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("httpfixture: %s: %w", path, err)
+		}
+		fixture.Interactions = append(fixture.Interactions, file.Interactions...)
+		fixture.Calls = append(fixture.Calls, file.Calls...)
+	}
+	return fixture, nil
+}
+
+// Write writes a fixture to a JSON file
+func (f *Fixture) Write(path string) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0600)
+}
+
+// key returns the key matching a request to an interaction, the method, the scrubbed URL with sorted query
+// parameters and the request body as recorded
+func key(method string, u *url.URL, body json.RawMessage) string {
+	u = scrubURL(u)
+	query := u.Query()
+	for _, parameter := range ignoredParameters {
+		query.Del(parameter)
+	}
+	u.RawQuery = query.Encode()
+	u.Fragment = ""
+	if len(body) == 0 {
+		return method + " " + u.String()
+	}
+	return method + " " + u.String() + " " + string(body)
+}
+
+// callKey returns the key matching a gRPC call to a recorded call, the method and the request as recorded
+func callKey(method string, request json.RawMessage) string {
+	return method + " " + string(request)
+}
+
+// encodeMessage returns a gRPC message as recorded, scrubbed JSON with sorted keys
+func encodeMessage(message interface{}) (json.RawMessage, error) {
+	var data []byte
+	var err error
+	if protoMessage, ok := message.(proto.Message); ok {
+		data, err = protojson.Marshal(protoMessage)
+	} else {
+		data, err = json.Marshal(message)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return canonicalJSON(data), nil
+}
+
+// readBody reads the body of a request and replaces it, so the request can still be sent
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// Replayer is a Middleware and an http.RoundTripper serving the responses of a Fixture. The response of an
+// interaction is served to every matching request, the first interaction wins. A request without an interaction
+// fails, as do gRPC calls.
+type Replayer struct {
+	interactions map[string]Interaction
+	calls        map[string]Call
+
+	mu        sync.Mutex
+	unmatched []string
+}
+
+var _ Middleware = (*Replayer)(nil)
+
+// NewReplayer returns a Replayer serving the interactions of a fixture
+func NewReplayer(fixture *Fixture) (*Replayer, error) {
+	r := &Replayer{interactions: map[string]Interaction{}, calls: map[string]Call{}}
+	for _, interaction := range fixture.Interactions {
+		u, err := url.Parse(interaction.URL)
+		if err != nil {
+			return nil, fmt.Errorf("httpfixture: %w", err)
+		}
+		if !u.IsAbs() {
+			return nil, fmt.Errorf("httpfixture: URL %s is not absolute", interaction.URL)
+		}
+		method := interaction.Method
+		if method == "" {
+			method = http.MethodGet
+		}
+		request := canonicalJSON(interaction.Request)
+		if _, exists := r.interactions[key(method, u, request)]; !exists {
+			r.interactions[key(method, u, request)] = interaction
+		}
+	}
+	for _, call := range fixture.Calls {
+		request := canonicalJSON(call.Request)
+		if _, exists := r.calls[callKey(call.Method, request)]; !exists {
+			r.calls[callKey(call.Method, request)] = call
+		}
+	}
+	return r, nil
+}
+
+// RoundTripper returns the Replayer, base is not used
+func (r *Replayer) RoundTripper(base http.RoundTripper) http.RoundTripper {
+	return r
+}
+
+// Offline returns true, the Replayer sends no requests
+func (r *Replayer) Offline() bool {
+	return true
+}
+
+// UnaryClientInterceptor returns an interceptor serving the responses of recorded gRPC calls, the calls are not
+// sent
+func (r *Replayer) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		request, err := encodeMessage(req)
+		if err != nil {
+			return err
+		}
+		call, exists := r.calls[callKey(method, request)]
+		if !exists {
+			r.addUnmatched("GRPC " + method + " " + string(request))
+			return status.Errorf(codes.FailedPrecondition, "httpfixture: no recorded response to %s", method)
+		}
+		if call.Code != 0 {
+			return status.Error(codes.Code(call.Code), call.Message)
+		}
+		if protoMessage, ok := reply.(proto.Message); ok {
+			return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(call.Response, protoMessage)
+		}
+		return json.Unmarshal(call.Response, reply)
+	}
+}
+
+func (r *Replayer) addUnmatched(request string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.unmatched = append(r.unmatched, request)
+}
+
+// Client returns an http.Client sending its requests to the Replayer
+func (r *Replayer) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Unmatched returns the requests which had no interaction, as method and URL, and the gRPC calls which had no
+// recorded call, as GRPC, method and request
+func (r *Replayer) Unmatched() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	unmatched := append([]string{}, r.unmatched...)
+	sort.Strings(unmatched)
+	return unmatched
+}
+
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestBody, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	interaction, exists := r.interactions[key(req.Method, req.URL, encodeBody(requestBody))]
+	if !exists {
+		r.addUnmatched(req.Method + " " + req.URL.String())
+		return nil, fmt.Errorf("httpfixture: no recorded response to %s %s", req.Method, req.URL)
+	}
+
+	body := decodeBody(interaction.Body)
+	header := http.Header{}
+	for name, values := range interaction.Header {
+		header[http.CanonicalHeaderKey(name)] = append([]string{}, values...)
+	}
+	if header.Get("Content-Type") == "" && json.Valid(body) {
+		header.Set("Content-Type", "application/json; charset=UTF-8")
+	}
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+	status := interaction.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	return &http.Response{
+		Status:        strconv.Itoa(status) + " " + http.StatusText(status),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpfixture
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReplayer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	err := os.WriteFile(path, []byte(`{
+  "interactions": [
+    {"method": "GET", "url": "https://storage.googleapis.com/storage/v1/b?project=p&pageToken=t", "status": 200, "body": {"items": [{"name": "logs"}]}},
+    {"method": "GET", "url": "https://storage.googleapis.com/storage/v1/b/logs/iam", "status": 403, "header": {"Content-Type": ["text/plain"]}, "body": "forbidden"}
+  ]
+}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fixture, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayer, err := NewReplayer(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := replayer.Client()
+
+	// parameters in another order and the parameters added by the API clients match
+	resp, err := client.Get("https://storage.googleapis.com/storage/v1/b?alt=json&pageToken=t&prettyPrint=false&project=p")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body struct {
+		Items []struct{ Name string }
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil || len(body.Items) != 1 || body.Items[0].Name != "logs" {
+		t.Errorf("unexpected body %+v, %v", body, err)
+	}
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "application/json; charset=UTF-8" {
+		t.Errorf("unexpected response %d %v", resp.StatusCode, resp.Header)
+	}
+
+	resp, err = client.Get("https://storage.googleapis.com/storage/v1/b/logs/iam")
+	if err != nil {
+		t.Fatal(err)
+	}
+	text, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusForbidden || string(text) != "forbidden" || resp.Header.Get("Content-Type") != "text/plain" {
+		t.Errorf("unexpected response %d %v %q", resp.StatusCode, resp.Header, text)
+	}
+
+	if _, err := client.Get("https://storage.googleapis.com/storage/v1/b?project=other"); err == nil {
+		t.Error("expected an error for a request without an interaction")
+	}
+	if _, err := client.Post("https://storage.googleapis.com/storage/v1/b/logs/iam", "application/json", nil); err == nil {
+		t.Error("expected an error for a request with another method")
+	}
+	expected := []string{
+		"GET https://storage.googleapis.com/storage/v1/b?project=other",
+		"POST https://storage.googleapis.com/storage/v1/b/logs/iam",
+	}
+	if unmatched := replayer.Unmatched(); !reflect.DeepEqual(unmatched, expected) {
+		t.Errorf("expected unmatched %v, got %v", expected, unmatched)
+	}
+}
+
+func TestNewReplayerRelativeURL(t *testing.T) {
+	if _, err := NewReplayer(&Fixture{Interactions: []Interaction{{URL: "/storage/v1/b"}}}); err == nil {
+		t.Error("expected an error for a relative URL")
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpfixture
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Recorder is a Middleware recording the HTTP requests and unary gRPC calls sent through it, with their
+// credentials scrubbed. Repeated requests are recorded once, as the Replayer serves the first interaction.
+type Recorder struct {
+	mu           sync.Mutex
+	recorded     map[string]bool
+	interactions []Interaction
+	calls        []Call
+}
+
+var _ Middleware = (*Recorder)(nil)
+
+// NewRecorder returns an empty Recorder
+func NewRecorder() *Recorder {
+	return &Recorder{recorded: map[string]bool{}}
+}
+
+// Offline returns false, the Recorder sends the requests
+func (r *Recorder) Offline() bool {
+	return false
+}
+
+// RoundTripper returns a transport sending requests with base and recording them
+func (r *Recorder) RoundTripper(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &recordingTransport{recorder: r, base: base}
+}
+
+// UnaryClientInterceptor returns an interceptor sending calls and recording them
+func (r *Recorder) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		request, encodeErr := encodeMessage(req)
+		if encodeErr != nil {
+			return err
+		}
+		call := Call{Method: method, Request: request}
+		if err != nil {
+			s, ok := status.FromError(err)
+			if !ok || s.Code() == 0 {
+				// errors without a status, e.g. a canceled context, are not worth replaying
+				return err
+			}
+			call.Code = uint32(s.Code())
+			call.Message = s.Message()
+		} else if call.Response, encodeErr = encodeMessage(reply); encodeErr != nil {
+			return nil
+		}
+		r.add(callKey(method, request), func() { r.calls = append(r.calls, call) })
+		return err
+	}
+}
+
+// add runs record under the lock of the Recorder, unless key was already recorded
+func (r *Recorder) add(key string, record func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.recorded[key] {
+		return
+	}
+	r.recorded[key] = true
+	record()
+}
+
+// Fixture returns the interactions and calls recorded so far, in the order of their responses
+func (r *Recorder) Fixture() *Fixture {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return &Fixture{
+		Interactions: append([]Interaction{}, r.interactions...),
+		Calls:        append([]Call{}, r.calls...),
+	}
+}
+
+// Write writes the recorded fixture to a JSON file, see Load
+func (r *Recorder) Write(path string) error {
+	return r.Fixture().Write(path)
+}
+
+type recordingTransport struct {
+	recorder *Recorder
+	base     http.RoundTripper
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestBody, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	request := encodeBody(requestBody)
+	interaction := Interaction{
+		Method:  req.Method,
+		URL:     scrubURL(req.URL).String(),
+		Request: request,
+		Status:  resp.StatusCode,
+		Header:  scrubHeader(resp.Header),
+		Body:    encodeBody(body),
+	}
+	t.recorder.add(key(req.Method, req.URL, request), func() {
+		t.recorder.interactions = append(t.recorder.interactions, interaction)
+	})
+	return resp, nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpfixture
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestRecorderReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.Header().Set("X-Goog-Api-Key", "secret")
+		_, _ = w.Write([]byte(`{"name": "logs", "request": ` + string(body) + `, "credentials": {"access_token": "secret", "private_key": "secret"}}`))
+	}))
+	defer server.Close()
+
+	recorder := NewRecorder()
+	client := &http.Client{Transport: recorder.RoundTripper(nil)}
+	for i := 0; i < 2; i++ {
+		resp, err := client.Post(server.URL+"/v1/b?key=secret&project=p", "application/json", strings.NewReader(`{"size": 1}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if body, _ := io.ReadAll(resp.Body); !strings.Contains(string(body), `"access_token": "secret"`) {
+			t.Errorf("the response was changed: %s", body)
+		}
+	}
+
+	fixture := recorder.Fixture()
+	if len(fixture.Interactions) != 1 {
+		t.Fatalf("expected the repeated request to be recorded once, got %+v", fixture.Interactions)
+	}
+	interaction := fixture.Interactions[0]
+	if strings.Contains(interaction.URL, "secret") || strings.Contains(string(interaction.Body), "secret") {
+		t.Errorf("credentials were recorded: %s %s", interaction.URL, interaction.Body)
+	}
+	if interaction.Header.Get("Set-Cookie") != "" || interaction.Header.Get("X-Goog-Api-Key") != "" || interaction.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("unexpected headers %v", interaction.Header)
+	}
+	if string(interaction.Request) != `{"size":1}` {
+		t.Errorf("unexpected request body %s", interaction.Request)
+	}
+
+	path := filepath.Join(t.TempDir(), "api.json")
+	if err := recorder.Write(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayer, err := NewReplayer(loaded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the key of the replayed request is redacted as well
+	resp, err := replayer.Client().Post(server.URL+"/v1/b?project=p&key=other", "application/json", strings.NewReader(`{ "size":1 }`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if string(canonicalJSON(body)) != string(interaction.Body) {
+		t.Errorf("expected %s, got %s", interaction.Body, body)
+	}
+	if _, err := replayer.Client().Post(server.URL+"/v1/b?project=p", "application/json", strings.NewReader(`{"size": 2}`)); err == nil {
+		t.Error("expected an error for a request with another body")
+	}
+}
+
+func TestRecorderReplayGRPC(t *testing.T) {
+	recorder := NewRecorder()
+	interceptor := recorder.UnaryClientInterceptor()
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		if req.(*wrapperspb.StringValue).Value == "missing" {
+			return status.Error(codes.NotFound, "missing was not found")
+		}
+		reply.(*wrapperspb.StringValue).Value = "hello " + req.(*wrapperspb.StringValue).Value
+		return nil
+	}
+	reply := &wrapperspb.StringValue{}
+	if err := interceptor(context.Background(), "/test.Greeter/Greet", wrapperspb.String("world"), reply, nil, invoker); err != nil {
+		t.Fatal(err)
+	}
+	if err := interceptor(context.Background(), "/test.Greeter/Greet", wrapperspb.String("missing"), &wrapperspb.StringValue{}, nil, invoker); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected the error of the call, got %v", err)
+	}
+
+	replayer, err := NewReplayer(recorder.Fixture())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !replayer.Offline() || recorder.Offline() {
+		t.Error("only the Replayer is offline")
+	}
+	interceptor = replayer.UnaryClientInterceptor()
+	fail := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		t.Error("the replayed call was sent")
+		return nil
+	}
+	reply = &wrapperspb.StringValue{}
+	if err := interceptor(context.Background(), "/test.Greeter/Greet", wrapperspb.String("world"), reply, nil, fail); err != nil || reply.Value != "hello world" {
+		t.Errorf("unexpected reply %v, %v", reply, err)
+	}
+	err = interceptor(context.Background(), "/test.Greeter/Greet", wrapperspb.String("missing"), &wrapperspb.StringValue{}, nil, fail)
+	if status.Code(err) != codes.NotFound || status.Convert(err).Message() != "missing was not found" {
+		t.Errorf("expected the recorded error, got %v", err)
+	}
+	err = interceptor(context.Background(), "/test.Greeter/Greet", wrapperspb.String("other"), &wrapperspb.StringValue{}, nil, fail)
+	if status.Code(err) != codes.FailedPrecondition || len(replayer.Unmatched()) != 1 {
+		t.Errorf("expected an unmatched call, got %v %v", err, replayer.Unmatched())
+	}
+}
+
+func TestEncodeBody(t *testing.T) {
+	for _, test := range []struct {
+		body     string
+		expected string
+	}{
+		{
+			body:     `{"name": "projects/p/serviceAccounts/a/keys/k", "privateKeyData": "a2V5", "keyAlgorithm": "KEY_ALG_RSA_2048"}`,
+			expected: `{"keyAlgorithm":"KEY_ALG_RSA_2048","name":"projects/p/serviceAccounts/a/keys/k","privateKeyData":"REDACTED"}`,
+		},
+		{
+			body:     `{"clients": [{"clientId": "c", "secret": "s"}], "settings": {"rootPassword": "p", "oauthClientSecret": "s"}, "nextPageToken": "page2"}`,
+			expected: `{"clients":[{"clientId":"c","secret":"REDACTED"}],"nextPageToken":"page2","settings":{"oauthClientSecret":"REDACTED","rootPassword":"REDACTED"}}`,
+		},
+		{
+			body:     `grant_type=refresh_token&client_id=c&client_secret=s&refresh_token=r`,
+			expected: `client_id=c&client_secret=REDACTED&grant_type=refresh_token&refresh_token=REDACTED`,
+		},
+		{
+			body:     `grant_type=client_credentials&scope=all`,
+			expected: `grant_type=client_credentials&scope=all`,
+		},
+		{
+			body:     `plain text`,
+			expected: `plain text`,
+		},
+	} {
+		if encoded := string(decodeBody(encodeBody([]byte(test.body)))); encoded != test.expected {
+			t.Errorf("%s: expected %s, got %s", test.body, test.expected, encoded)
+		}
+	}
+}
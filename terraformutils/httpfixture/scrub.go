@@ -0,0 +1,203 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpfixture
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Redacted replaces the credentials of recorded URLs and bodies
+const Redacted = "REDACTED"
+
+// sensitiveParameters are query parameters holding credentials besides the ones of sensitiveKeyParts, e.g. API keys
+// and presigned URL signatures
+var sensitiveParameters = map[string]bool{
+	"key":             true,
+	"signature":       true,
+	"sig":             true,
+	"x-amz-signature": true,
+}
+
+// sensitiveHeaderParts are parts of the names of headers holding credentials, e.g. Authorization and Set-Cookie
+var sensitiveHeaderParts = []string{"authorization", "cookie", "token", "secret", "api-key", "apikey", "signature", "credential", "session", "password"}
+
+// omittedHeaders are response headers which are not recorded, the Replayer sets the length of the body
+var omittedHeaders = []string{"Content-Length", "Transfer-Encoding", "Connection"}
+
+// sensitiveKeyParts are parts of the keys of JSON and form-encoded bodies holding credentials, e.g. privateKeyData,
+// client_secret or password, lower case without _ and -
+var sensitiveKeyParts = []string{"token", "secret", "password", "privatekey", "credential", "apikey", "authorization"}
+
+// paginationKeyParts are parts of the keys of page tokens, which a replay sends back as recorded
+var paginationKeyParts = []string{"pagetoken", "nexttoken", "continuationtoken"}
+
+func isSensitiveKey(name string) bool {
+	if sensitiveParameters[strings.ToLower(name)] {
+		return true
+	}
+	key := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(name))
+	for _, part := range paginationKeyParts {
+		if strings.Contains(key, part) {
+			return false
+		}
+	}
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// scrubURL returns a URL with the values of sensitive query parameters redacted
+func scrubURL(u *url.URL) *url.URL {
+	query := u.Query()
+	redacted := false
+	for name := range query {
+		if isSensitiveKey(name) {
+			query[name] = []string{Redacted}
+			redacted = true
+		}
+	}
+	scrubbed := *u
+	scrubbed.User = nil
+	if redacted {
+		scrubbed.RawQuery = query.Encode()
+	}
+	return &scrubbed
+}
+
+// scrubHeader returns the response headers worth recording, without the ones holding credentials
+func scrubHeader(header http.Header) http.Header {
+	scrubbed := http.Header{}
+	for name, values := range header {
+		if isSensitiveHeader(name) {
+			continue
+		}
+		scrubbed[name] = append([]string{}, values...)
+	}
+	for _, name := range omittedHeaders {
+		scrubbed.Del(name)
+	}
+	if len(scrubbed) == 0 {
+		return nil
+	}
+	return scrubbed
+}
+
+func isSensitiveHeader(name string) bool {
+	name = strings.ToLower(name)
+	for _, part := range sensitiveHeaderParts {
+		if strings.Contains(name, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// scrubJSON redacts the values of sensitive keys of a decoded JSON value. Objects and arrays below a sensitive key
+// are scrubbed in turn, so e.g. the list of secrets of an API keeps its names.
+func scrubJSON(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for k, v := range value {
+			switch v.(type) {
+			case map[string]interface{}, []interface{}:
+				value[k] = scrubJSON(v)
+			default:
+				if v != nil && isSensitiveKey(k) {
+					value[k] = Redacted
+				}
+			}
+		}
+	case []interface{}:
+		for i, v := range value {
+			value[i] = scrubJSON(v)
+		}
+	}
+	return value
+}
+
+// scrubForm returns a form-encoded body with the values of sensitive keys redacted, or false when the body isn't
+// form-encoded or holds no credentials
+func scrubForm(body []byte) ([]byte, bool) {
+	if !bytes.Contains(body, []byte("=")) || bytes.ContainsAny(body, " \t\r\n{}\"") {
+		return nil, false
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, false
+	}
+	redacted := false
+	for name := range form {
+		if isSensitiveKey(name) {
+			form[name] = []string{Redacted}
+			redacted = true
+		}
+	}
+	if !redacted {
+		return nil, false
+	}
+	return []byte(form.Encode()), true
+}
+
+// encodeBody returns a body as recorded: scrubbed JSON with sorted keys, or a JSON string holding a body which
+// isn't a JSON object or array, form-encoded bodies scrubbed
+func encodeBody(body []byte) json.RawMessage {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return nil
+	}
+	if (trimmed[0] == '{' || trimmed[0] == '[') && json.Valid(trimmed) {
+		return canonicalJSON(trimmed)
+	}
+	if form, ok := scrubForm(trimmed); ok {
+		body = form
+	}
+	data, _ := json.Marshal(string(body))
+	return data
+}
+
+// canonicalJSON returns a JSON value scrubbed, with sorted keys and without spaces, so recorded values match
+// regardless of their formatting. Invalid JSON is returned as is.
+func canonicalJSON(data json.RawMessage) json.RawMessage {
+	if len(data) == 0 {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return data
+	}
+	canonical, err := json.Marshal(scrubJSON(value))
+	if err != nil {
+		return data
+	}
+	return canonical
+}
+
+// decodeBody returns a recorded body, see encodeBody
+func decodeBody(body json.RawMessage) []byte {
+	var text string
+	if json.Unmarshal(body, &text) == nil {
+		return []byte(text)
+	}
+	return body
+}
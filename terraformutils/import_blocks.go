@@ -0,0 +1,158 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ImportAddress returns the Terraform address of a resource, e.g. google_compute_network.tfer--default
+func (r Resource) ImportAddress() string {
+	return r.InstanceInfo.Type + "." + r.ResourceName
+}
+
+// importProvider returns the provider local name set on the resource config (e.g. google-beta), if any
+func (r Resource) importProvider() string {
+	if r.Item == nil {
+		return ""
+	}
+	if provider, ok := r.Item["provider"].(string); ok {
+		return provider
+	}
+	return ""
+}
+
+// HclPrintImportBlocks prints Terraform 1.5+ import blocks, one per resource, so the generated
+// configuration can be adopted with a regular terraform plan/apply instead of a prebuilt tfstate
+func HclPrintImportBlocks(resources []Resource, output string, sort bool) ([]byte, error) {
+	return printImportBlocks(importableResources("", resources, sort), output)
+}
+
+// HclPrintModuleImportBlocks prints the import blocks of the root module for resources grouped by the
+// name of the child module they are written to
+func HclPrintModuleImportBlocks(resourcesByModule map[string][]Resource, output string, sortResources bool) ([]byte, error) {
+	modules := make([]string, 0, len(resourcesByModule))
+	for module := range resourcesByModule {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+	blocks := []importBlock{}
+	for _, module := range modules {
+		blocks = append(blocks, importableResources(module, resourcesByModule[module], sortResources)...)
+	}
+	return printImportBlocks(blocks, output)
+}
+
+// importBlock is a resource to import into the module named module, the root module when empty
+type importBlock struct {
+	module   string
+	resource Resource
+}
+
+func (b importBlock) address() string {
+	if b.module == "" {
+		return b.resource.ImportAddress()
+	}
+	return "module." + b.module + "." + b.resource.ImportAddress()
+}
+
+func (b importBlock) traversal() hcl.Traversal {
+	traversal := hcl.Traversal{}
+	if b.module != "" {
+		traversal = append(traversal, hcl.TraverseRoot{Name: "module"}, hcl.TraverseAttr{Name: b.module}, hcl.TraverseAttr{Name: b.resource.InstanceInfo.Type})
+	} else {
+		traversal = append(traversal, hcl.TraverseRoot{Name: b.resource.InstanceInfo.Type})
+	}
+	return append(traversal, hcl.TraverseAttr{Name: b.resource.ResourceName})
+}
+
+func printImportBlocks(blocks []importBlock, output string) ([]byte, error) {
+	switch output {
+	case "hcl":
+		return hclPrintImportBlocks(blocks)
+	case "json":
+		return jsonPrintImportBlocks(blocks)
+	}
+	return []byte{}, errors.New("error: unknown output format")
+}
+
+func importableResources(module string, resources []Resource, sortResources bool) []importBlock {
+	importable := []importBlock{}
+	seen := map[string]struct{}{}
+	for _, r := range resources {
+		if r.InstanceState == nil || r.InstanceState.ID == "" {
+			continue
+		}
+		// the same resource listed twice is imported once
+		if _, exist := seen[r.ImportAddress()]; exist {
+			continue
+		}
+		seen[r.ImportAddress()] = struct{}{}
+		importable = append(importable, importBlock{module: module, resource: r})
+	}
+	if sortResources {
+		sort.SliceStable(importable, func(i, j int) bool {
+			return importable[i].address() < importable[j].address()
+		})
+	}
+	return importable
+}
+
+func hclPrintImportBlocks(blocks []importBlock) ([]byte, error) {
+	f := hclwrite.NewEmptyFile()
+	body := f.Body()
+	for i, b := range blocks {
+		if i > 0 {
+			body.AppendNewline()
+		}
+		block := body.AppendNewBlock("import", nil).Body()
+		block.SetAttributeTraversal("to", b.traversal())
+		block.SetAttributeValue("id", cty.StringVal(b.resource.InstanceState.ID))
+		if provider := b.resource.importProvider(); provider != "" {
+			block.SetAttributeTraversal("provider", hcl.Traversal{
+				hcl.TraverseRoot{Name: provider},
+			})
+		}
+	}
+	return hclwrite.Format(f.Bytes()), nil
+}
+
+func jsonPrintImportBlocks(blocks []importBlock) ([]byte, error) {
+	imports := []map[string]interface{}{}
+	for _, b := range blocks {
+		block := map[string]interface{}{
+			"to": b.address(),
+			"id": escapeTemplate(b.resource.InstanceState.ID),
+		}
+		if provider := b.resource.importProvider(); provider != "" {
+			block["provider"] = provider
+		}
+		imports = append(imports, block)
+	}
+	return jsonPrint(map[string]interface{}{
+		"import": imports,
+	})
+}
+
+// escapeTemplate escapes template sequences, JSON syntax evaluates every string as a template
+func escapeTemplate(s string) string {
+	return strings.NewReplacer("${", "$${", "%{", "%%{").Replace(s)
+}
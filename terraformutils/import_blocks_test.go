@@ -0,0 +1,68 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestHclPrintImportBlocks(t *testing.T) {
+	network := prepare("projects/p/global/networks/default", "google_compute_network", map[string]string{}, map[string]interface{}{})
+	bucket := prepare("my.bucket", "google_storage_bucket", map[string]string{}, mapI("provider", "google-beta"))
+	notRefreshed := prepareNoAttrs("", "google_compute_firewall")
+
+	data, err := HclPrintImportBlocks([]Resource{network, bucket, notRefreshed}, "hcl", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `import {
+  to = google_compute_network.tfer--name-google_compute_network
+  id = "projects/p/global/networks/default"
+}
+
+import {
+  to       = google_storage_bucket.tfer--name-google_storage_bucket
+  id       = "my.bucket"
+  provider = google-beta
+}
+`
+	if string(data) != expected {
+		t.Errorf("unexpected import blocks:\n%s", string(data))
+	}
+}
+
+func TestJSONPrintImportBlocks(t *testing.T) {
+	network := prepare("${interpolated}", "google_compute_network", map[string]string{}, map[string]interface{}{})
+
+	data, err := HclPrintImportBlocks([]Resource{network, network}, "json", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var imports map[string][]map[string]string
+	if err := json.Unmarshal(data, &imports); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string][]map[string]string{
+		"import": {{
+			"to": "google_compute_network.tfer--name-google_compute_network",
+			"id": "$${interpolated}",
+		}},
+	}
+	if !reflect.DeepEqual(imports, expected) {
+		t.Errorf("unexpected import blocks: %v", imports)
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"bytes"
+	_ "embed" // the published schema of the import config
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"gopkg.in/yaml.v3"
+)
+
+// ImportConfigSchema is the JSON schema of import configs, see import_config.schema.json
+//
+//go:embed import_config.schema.json
+var ImportConfigSchema []byte
+
+const importConfigSchemaURL = "import_config.schema.json"
+
+// ImportConfig is a terraformer.yaml of terraformer apply-config, e.g.
+//
+//	version: 1
+//	defaults:
+//	  path-output: generated
+//	  naming: snake
+//	imports:
+//	  - provider: google
+//	    projects: [my-project]
+//	    regions: [europe-west1]
+//	    resources: [gcs, networks]
+//	    where: ['labels.env == "prod"']
+//
+// Settings are named after the flags of terraformer import <provider>, so that an import maps onto the same
+// ImportOptions as its command line. The settings of an import override the defaults.
+type ImportConfig struct {
+	Version int
+	Imports []ProviderImport
+}
+
+// ProviderImport is an import of a provider in an ImportConfig
+type ProviderImport struct {
+	Provider string
+	// Settings are the settings of the import merged with the defaults, by flag name
+	Settings map[string]interface{}
+}
+
+// ReadImportConfig reads and validates the import config at path
+func ReadImportConfig(path string) (*ImportConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config, err := ParseImportConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return config, nil
+}
+
+// ParseImportConfig parses an import config in YAML or JSON and validates it against ImportConfigSchema
+func ParseImportConfig(data []byte) (*ImportConfig, error) {
+	var document interface{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	// validate the document as JSON, YAML has types JSON doesn't, e.g. timestamps
+	jsonData, err := json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.UseNumber()
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+	schema, err := importConfigSchema()
+	if err != nil {
+		return nil, err
+	}
+	if err := schema.Validate(document); err != nil {
+		return nil, importConfigError(err)
+	}
+
+	root := document.(map[string]interface{})
+	defaults, _ := root["defaults"].(map[string]interface{})
+	version, _ := root["version"].(json.Number).Int64()
+	config := &ImportConfig{Version: int(version)}
+	for _, item := range root["imports"].([]interface{}) {
+		settings := mergeImportSettings(defaults, item.(map[string]interface{}))
+		provider := settings["provider"].(string)
+		delete(settings, "provider")
+		config.Imports = append(config.Imports, ProviderImport{Provider: provider, Settings: settings})
+	}
+	return config, nil
+}
+
+func importConfigSchema() (*jsonschema.Schema, error) {
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft2020
+	if err := compiler.AddResource(importConfigSchemaURL, bytes.NewReader(ImportConfigSchema)); err != nil {
+		return nil, err
+	}
+	return compiler.Compile(importConfigSchemaURL)
+}
+
+// importConfigError lists the invalid settings of a schema validation error, e.g. /imports/0/regions: expected array
+func importConfigError(err error) error {
+	validationError, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		return err
+	}
+	messages := []string{}
+	var walk func(*jsonschema.ValidationError)
+	walk = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) == 0 {
+			location := e.InstanceLocation
+			if location == "" {
+				location = "/"
+			}
+			messages = append(messages, location+": "+e.Message)
+		}
+		for _, cause := range e.Causes {
+			walk(cause)
+		}
+	}
+	walk(validationError)
+	sort.Strings(messages)
+	return fmt.Errorf("invalid import config:\n  %s", strings.Join(messages, "\n  "))
+}
+
+// mergeImportSettings returns the defaults overridden by the settings of an import, flags are merged by flag
+func mergeImportSettings(defaults, settings map[string]interface{}) map[string]interface{} {
+	merged := map[string]interface{}{}
+	for k, v := range defaults {
+		merged[k] = v
+	}
+	for k, v := range settings {
+		merged[k] = v
+	}
+	if defaultFlags, ok := defaults["flags"].(map[string]interface{}); ok {
+		if flags, ok := settings["flags"].(map[string]interface{}); ok {
+			merged["flags"] = mergeImportSettings(defaultFlags, flags)
+		}
+	}
+	return merged
+}
+
+// Args returns the command line flags of the import, e.g. --regions=europe-west1. Lists repeat their flag and
+// key=value settings like backend-config are sorted by key.
+func (i ProviderImport) Args() []string {
+	settings := map[string]interface{}{}
+	for k, v := range i.Settings {
+		settings[k] = v
+	}
+	if flags, ok := settings["flags"].(map[string]interface{}); ok {
+		delete(settings, "flags")
+		for k, v := range flags {
+			settings[k] = v
+		}
+	}
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	args := []string{}
+	for _, name := range names {
+		switch value := settings[name].(type) {
+		case []interface{}:
+			for _, item := range value {
+				args = append(args, fmt.Sprintf("--%s=%v", name, item))
+			}
+		case map[string]interface{}:
+			keys := make([]string, 0, len(value))
+			for k := range value {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				args = append(args, fmt.Sprintf("--%s=%s=%v", name, k, value[k]))
+			}
+		default:
+			args = append(args, fmt.Sprintf("--%s=%v", name, value))
+		}
+	}
+	return args
+}
@@ -0,0 +1,123 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseImportConfig(t *testing.T) {
+	config, err := ParseImportConfig([]byte(`
+version: 1
+defaults:
+  path-output: generated
+  naming: snake
+  retry-number: 3
+  where: ['labels.env == "prod"']
+  flags:
+    provider-type: beta
+imports:
+  - provider: google
+    projects: [project-a, project-b]
+    regions: [europe-west1]
+    resources: [gcs, networks]
+    backend-config:
+      prefix: terraformer
+      bucket: state
+    state: gcs
+  - provider: aws
+    profile: prod
+    regions: [eu-west-1]
+    resources: ["*"]
+    excludes: [iam]
+    naming: legacy
+    where: []
+    parallelism: 20
+    flags:
+      verbose: true
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Version != 1 || len(config.Imports) != 2 {
+		t.Fatalf("unexpected config %v", config)
+	}
+	for i, expected := range []struct {
+		provider string
+		args     []string
+	}{
+		{"google", []string{
+			"--backend-config=bucket=state",
+			"--backend-config=prefix=terraformer",
+			"--naming=snake",
+			"--path-output=generated",
+			"--projects=project-a",
+			"--projects=project-b",
+			"--provider-type=beta",
+			"--regions=europe-west1",
+			"--resources=gcs",
+			"--resources=networks",
+			"--retry-number=3",
+			"--state=gcs",
+			`--where=labels.env == "prod"`,
+		}},
+		{"aws", []string{
+			"--excludes=iam",
+			"--naming=legacy",
+			"--parallelism=20",
+			"--path-output=generated",
+			"--profile=prod",
+			"--provider-type=beta",
+			"--regions=eu-west-1",
+			"--resources=*",
+			"--retry-number=3",
+			"--verbose=true",
+		}},
+	} {
+		if config.Imports[i].Provider != expected.provider {
+			t.Errorf("expected provider %s, got %s", expected.provider, config.Imports[i].Provider)
+		}
+		if args := config.Imports[i].Args(); !reflect.DeepEqual(args, expected.args) {
+			t.Errorf("unexpected args of %s:\n%s", expected.provider, strings.Join(args, "\n"))
+		}
+	}
+}
+
+func TestParseImportConfigErrors(t *testing.T) {
+	for _, test := range []struct {
+		name, config, expected string
+	}{
+		{"version", "version: 2\nimports: [{provider: google, resources: [gcs]}]", "/version"},
+		{"no imports", "version: 1\nimports: []", "/imports"},
+		{"no provider", "version: 1\nimports: [{resources: [gcs]}]", "/imports/0"},
+		{"no resources", "version: 1\nimports: [{provider: google}]", "/imports/0"},
+		{"unknown setting", "version: 1\nimports: [{provider: google, resources: [gcs], region: europe-west1}]", "/imports/0"},
+		{"list", "version: 1\nimports: [{provider: google, resources: [gcs], regions: europe-west1}]", "/imports/0/regions"},
+		{"enum", "version: 1\ndefaults: {layout: flat}\nimports: [{provider: google, resources: [gcs]}]", "/defaults/layout"},
+		{"duration", "version: 1\nimports: [{provider: google, resources: [gcs], checkpoint-max-age: 1 day}]", "/imports/0/checkpoint-max-age"},
+		{"yaml", "version: 1\nimports: [", "yaml"},
+	} {
+		_, err := ParseImportConfig([]byte(test.config))
+		if err == nil {
+			t.Errorf("%s: expected an error", test.name)
+			continue
+		}
+		if !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("%s: expected an error at %s, got %v", test.name, test.expected, err)
+		}
+	}
+}
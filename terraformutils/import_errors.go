@@ -0,0 +1,144 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Error categories of --fail-on
+const (
+	// FailOnService fails an import when a service could not be listed
+	FailOnService = "service"
+	// FailOnRefresh fails an import when a resource could not be refreshed
+	FailOnRefresh = "refresh"
+	// FailOnConvert fails an import when a state could not be converted or a PostConvertHook failed
+	FailOnConvert = "convert"
+)
+
+// FailOnCategories are the error categories in the order they are reported, --strict fails on all of them
+var FailOnCategories = []string{FailOnService, FailOnRefresh, FailOnConvert}
+
+// ParseFailOn parses the categories of --fail-on, strict adds all categories
+func ParseFailOn(values []string, strict bool) (map[string]bool, error) {
+	failOn := map[string]bool{}
+	if strict {
+		for _, category := range FailOnCategories {
+			failOn[category] = true
+		}
+	}
+	for _, value := range values {
+		switch value {
+		case FailOnService, FailOnRefresh, FailOnConvert:
+			failOn[value] = true
+		default:
+			return nil, fmt.Errorf("unsupported --fail-on category %q, expected %s", value, strings.Join(FailOnCategories, ", "))
+		}
+	}
+	return failOn, nil
+}
+
+// ImportError is a failed step of a service or resource
+type ImportError struct {
+	Category string
+	// Subject is the service or resource which failed
+	Subject string
+	Message string
+}
+
+func (e ImportError) Error() string {
+	return fmt.Sprintf("%s %s: %s", e.Category, e.Subject, e.Message)
+}
+
+// ImportErrors is the error of an import which lost services or resources, returned for the categories of --fail-on
+type ImportErrors struct {
+	Scope  string
+	Errors []ImportError
+}
+
+func (e *ImportErrors) Error() string {
+	counts := []string{}
+	for _, category := range FailOnCategories {
+		n := 0
+		for _, err := range e.Errors {
+			if err.Category == category {
+				n++
+			}
+		}
+		if n > 0 {
+			counts = append(counts, fmt.Sprintf("%d %s", n, category))
+		}
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %d errors (%s)", e.Scope, len(e.Errors), strings.Join(counts, ", "))
+	for _, err := range e.Errors {
+		b.WriteString("\n  ")
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+// Unwrap returns the errors, so that errors.As finds an ImportError
+func (e *ImportErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// Errors returns the errors of the categories in failOn by category, service and resource, nil without errors.
+// It is called after Finish.
+func (s *ScopeReport) Errors(failOn map[string]bool) error {
+	if s == nil || len(failOn) == 0 {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	errs := map[string][]ImportError{}
+	add := func(category, subject, message string) {
+		if failOn[category] {
+			errs[category] = append(errs[category], ImportError{Category: category, Subject: subject, Message: message})
+		}
+	}
+	for _, service := range s.Services {
+		if service.List == StatusFailed {
+			add(FailOnService, service.Name, service.Error)
+		}
+		if service.PostConvert != nil && service.PostConvert.Status == StatusFailed {
+			add(FailOnConvert, service.Name, service.PostConvert.Error)
+		}
+		for _, resource := range service.Resources {
+			subject := resource.Type + " " + resource.ID
+			switch {
+			case resource.Refresh == nil:
+				add(FailOnRefresh, subject, "not refreshed")
+			case resource.Refresh.Status == StatusFailed:
+				add(FailOnRefresh, subject, resource.Refresh.Error)
+			case resource.Convert != nil && resource.Convert.Status == StatusFailed:
+				add(FailOnConvert, subject, resource.Convert.Error)
+			}
+		}
+	}
+	importErrors := &ImportErrors{Scope: strings.TrimSpace(s.Provider + " " + strings.Join(s.Args, " "))}
+	for _, category := range FailOnCategories {
+		importErrors.Errors = append(importErrors.Errors, errs[category]...)
+	}
+	if len(importErrors.Errors) == 0 {
+		return nil
+	}
+	return importErrors
+}
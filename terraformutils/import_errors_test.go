@@ -0,0 +1,90 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func failedTestScope() *ScopeReport {
+	scope := NewImportReport().NewScope("google", []string{"global", "my-project"})
+	listed := []Resource{prepareNoAttrs("ID1", "type1"), prepareNoAttrs("ID2", "type1"), prepareNoAttrs("ID3", "type1")}
+	scope.Listed("networks", listed, 0, false)
+	scope.ServiceFailed("firewall", errors.New("permission denied"))
+	scope.Refreshed(&listed[0], "ID1", RefreshReport{Status: StatusOK, Attempts: 1})
+	scope.Refreshed(&listed[1], "ID2", RefreshReport{Status: StatusFailed, Attempts: 5, Error: "timeout"})
+	scope.Refreshed(&listed[2], "ID3", RefreshReport{Status: StatusOK, Attempts: 1})
+	scope.Converted(&listed[0], nil)
+	scope.Converted(&listed[2], errors.New("unsupported attribute"))
+	scope.PostConverted("networks", errors.New("hook failed"))
+	scope.Finish(nil)
+	return scope
+}
+
+func TestScopeReportErrors(t *testing.T) {
+	scope := failedTestScope()
+	for _, test := range []struct {
+		failOn   []string
+		strict   bool
+		expected []ImportError
+	}{
+		{nil, false, nil},
+		{[]string{FailOnService}, false, []ImportError{
+			{FailOnService, "firewall", "permission denied"},
+		}},
+		{nil, true, []ImportError{
+			{FailOnService, "firewall", "permission denied"},
+			{FailOnRefresh, "type1 ID2", "timeout"},
+			{FailOnConvert, "networks", "hook failed"},
+			{FailOnConvert, "type1 ID3", "unsupported attribute"},
+		}},
+	} {
+		failOn, err := ParseFailOn(test.failOn, test.strict)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = scope.Errors(failOn)
+		if test.expected == nil {
+			if err != nil {
+				t.Errorf("%v: unexpected error %v", test.failOn, err)
+			}
+			continue
+		}
+		importErrors := &ImportErrors{}
+		if !errors.As(err, &importErrors) || !reflect.DeepEqual(importErrors.Errors, test.expected) {
+			t.Errorf("%v: unexpected errors %v", test.failOn, err)
+		}
+	}
+
+	failOn, _ := ParseFailOn(nil, true)
+	err := scope.Errors(failOn)
+	expected := `google global my-project: 4 errors (1 service, 1 refresh, 2 convert)
+  service firewall: permission denied
+  refresh type1 ID2: timeout
+  convert networks: hook failed
+  convert type1 ID3: unsupported attribute`
+	if err.Error() != expected {
+		t.Errorf("unexpected message:\n%s", err)
+	}
+	var importError ImportError
+	if !errors.As(err, &importError) || importError.Category != FailOnService {
+		t.Errorf("expected errors.As to find an ImportError, got %v", importError)
+	}
+	if _, err := ParseFailOn([]string{"write"}, false); err == nil {
+		t.Errorf("expected an error for an unsupported category")
+	}
+}
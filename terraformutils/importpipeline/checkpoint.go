@@ -0,0 +1,70 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package importpipeline
+
+import (
+	"path/filepath"
+	"regexp"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/providerwrapper"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/terraformoutput"
+)
+
+// CheckpointDir is the directory of checkpoints in --path-output
+const CheckpointDir = ".terraformer/checkpoints"
+
+var nonPathSegment = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// checkpointPath returns the checkpoint directory of a provider scope, e.g. google/europe-west1/my-project
+func checkpointPath(providerName string, options ImportOptions, args []string) string {
+	segments := []string{options.PathOutput, CheckpointDir, providerName}
+	for _, arg := range args {
+		if arg = nonPathSegment.ReplaceAllString(arg, "_"); arg != "" && arg != "." && arg != ".." {
+			segments = append(segments, arg)
+		}
+	}
+	return filepath.Join(segments...)
+}
+
+// openCheckpoint opens the checkpoint of a provider scope, imports to file systems other than the local file
+// system have no checkpoint. The checkpoint directory is added to the .gitignore of --path-output, and the states
+// are checkpointed without their sensitive attributes unless the import writes them.
+func openCheckpoint(provider terraformutils.ProviderGenerator, providerWrapper *providerwrapper.ProviderWrapper, options ImportOptions, args []string) (*terraformutils.Checkpoint, error) {
+	fsys, ok := options.FileSystem().(terraformoutput.DirFileSystem)
+	if !ok {
+		return nil, nil
+	}
+	if err := terraformoutput.IgnoreFile(fsys, options.PathOutput, filepath.Dir(CheckpointDir)+"/"); err != nil {
+		return nil, err
+	}
+	checkpoint, err := terraformutils.OpenCheckpoint(
+		fsys.Path(checkpointPath(provider.GetName(), options, args)),
+		terraformutils.CheckpointScope{
+			ProviderVersion: providerwrapper.GetProviderVersion(provider.GetName()),
+			Services:        options.Resources,
+			Filters:         options.Filter,
+			Where:           options.Where,
+		},
+		options.CheckpointMaxAge,
+		options.Resume,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if options.Secrets == terraformutils.SecretsOmit || options.Secrets == terraformutils.SecretsVariables {
+		checkpoint.RedactSensitive(providerWrapper.GetSchema())
+	}
+	return checkpoint, nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package importpipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/providerwrapper"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/terraformoutput"
+	"github.com/hashicorp/terraform/providers"
+)
+
+const DriftFormatText = "text"
+
+const DriftFormatJSON = "json"
+
+// reportDrift compares the refreshed resources with the state read from --tfstate, or from the state backend
+// at the paths import would have written to, and returns an error when drift is found. refreshed are the keys of
+// the resources refreshed before the filters, see resourceKeys.
+func reportDrift(ctx context.Context, providerMapping *terraformutils.ProvidersMapping, options ImportOptions, providerWrapper *providerwrapper.ProviderWrapper, refreshed map[string]string) error {
+	if options.DriftFormat != DriftFormatText && options.DriftFormat != DriftFormatJSON {
+		return fmt.Errorf("unsupported drift report format: %s", options.DriftFormat)
+	}
+	providerName := providerMapping.GetBaseProvider().GetName()
+	schema := providerWrapper.GetSchema()
+	state, err := readDriftState(ctx, providerName, options, schema)
+	if err != nil {
+		return err
+	}
+	live := []terraformutils.Resource{}
+	for _, resources := range providerMapping.GetResourcesByService() {
+		live = append(live, resources...)
+	}
+	report := terraformutils.CompareState(live, state, schema, driftUnknown(providerName, options, refreshed))
+
+	w := options.DriftWriter
+	if w == nil {
+		w = os.Stdout
+	}
+	if options.DriftFormat == DriftFormatJSON {
+		err = terraformutils.WriteDriftJSON(report, w)
+	} else {
+		err = terraformutils.WriteDriftText(report, w)
+	}
+	if err != nil {
+		return err
+	}
+	if options.DriftOutput != "" {
+		f, err := os.Create(options.DriftOutput)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if err := terraformutils.WriteDriftJSON(report, f); err != nil {
+			return err
+		}
+	}
+	if report.HasDrift() {
+		return fmt.Errorf("drift detected: %s", report)
+	}
+	return nil
+}
+
+// resourceKeys returns the services of the resources of providerMapping by their type and ID
+func resourceKeys(providerMapping *terraformutils.ProvidersMapping) map[string]string {
+	keys := map[string]string{}
+	for service, resources := range providerMapping.GetResourcesByService() {
+		for _, r := range resources {
+			if r.InstanceState != nil {
+				keys[r.InstanceInfo.Type+"/"+r.InstanceState.ID] = service
+			}
+		}
+	}
+	return keys
+}
+
+// driftUnknown returns whether the import did not see the resource of a state entry, because it failed to
+// refresh, it was refreshed and then dropped by --filter or --where, or the filters leave it out
+func driftUnknown(providerName string, options ImportOptions, refreshed map[string]string) func(terraformutils.StateResource) bool {
+	filters := terraformutils.Service{}
+	filters.ParseFilters(options.Filter)
+	services := map[string]string{}
+	for key, service := range refreshed {
+		services[strings.Split(key, "/")[0]] = service
+	}
+	return func(s terraformutils.StateResource) bool {
+		id := s.Attributes["id"]
+		if _, ok := refreshed[s.Type+"/"+id]; ok || options.report.RefreshFailed(s.Type, id) {
+			return true
+		}
+		r := s.Resource(providerName)
+		for _, filter := range filters.Filter {
+			if !filter.Filter(r) {
+				return true
+			}
+		}
+		return len(terraformutils.FilterResources(options.where, services[s.Type], []terraformutils.Resource{r}, true)) == 0
+	}
+}
+
+func readDriftState(ctx context.Context, providerName string, options ImportOptions, schema *providers.GetSchemaResponse) ([]terraformutils.StateResource, error) {
+	if options.DriftState != "" {
+		data, err := readStateFile(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+		return terraformutils.ReadStateResources(data, schema)
+	}
+	backend, err := stateBackend(options)
+	if err != nil {
+		return nil, err
+	}
+	state := []terraformutils.StateResource{}
+	paths := map[string]bool{}
+	for _, service := range options.Resources {
+		path := Path(options.PathPattern, providerName, service, options.PathOutput)
+		if options.Layout == LayoutModules {
+			// a single state is owned by the root module
+			path = modulesRootPath(options, providerName)
+		}
+		if paths[path] {
+			continue
+		}
+		paths[path] = true
+		data, err := backend.Download(ctx, path)
+		if errors.Is(err, os.ErrNotExist) {
+			options.logf("no %s state found for %s", backend.Name(), path)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read state of %s: %v", path, err)
+		}
+		resources, err := terraformutils.ReadStateResources(data, schema)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read state of %s: %v", path, err)
+		}
+		state = append(state, resources...)
+	}
+	return state, nil
+}
+
+// readStateFile reads --tfstate from a local path or, for gs://, s3://, azurerm:// and http(s):// URLs, from the
+// backend with the credentials of --backend-config
+func readStateFile(ctx context.Context, options ImportOptions) ([]byte, error) {
+	if !terraformoutput.IsStateURL(options.DriftState) {
+		return os.ReadFile(options.DriftState)
+	}
+	config, err := terraformoutput.ParseBackendConfig(options.BackendConfig)
+	if err != nil {
+		return nil, err
+	}
+	return terraformoutput.DownloadStateURL(ctx, options.DriftState, config)
+}
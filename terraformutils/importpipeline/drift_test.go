@@ -0,0 +1,61 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package importpipeline
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestDriftUnknown(t *testing.T) {
+	where, err := parseWhere([]string{`labels.env == "prod"`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	options := ImportOptions{
+		Filter: []string{"compute_firewall=allow-ssh:allow-http:failed"},
+		where:  where,
+		report: terraformutils.NewImportReport().NewScope("google", nil),
+	}
+	failed := terraformutils.NewResource("failed", "failed", "google_compute_firewall", "google", map[string]string{}, []string{}, map[string]interface{}{})
+	options.report.Listed("firewall", []terraformutils.Resource{failed}, 0, false)
+	options.report.Refreshed(&failed, "failed", terraformutils.RefreshReport{Status: terraformutils.StatusFailed, Error: "timeout"})
+	refreshed := map[string]string{"google_compute_firewall/allow-http": "firewall"}
+	unknown := driftUnknown("google", options, refreshed)
+
+	for _, test := range []struct {
+		id      string
+		env     string
+		unknown bool
+	}{
+		{id: "allow-ssh", env: "prod", unknown: false},
+		{id: "failed", env: "prod", unknown: true},
+		// refreshed and then dropped by --where
+		{id: "allow-http", env: "prod", unknown: true},
+		// not listed because of --filter
+		{id: "allow-rdp", env: "prod", unknown: true},
+		// left out by --where
+		{id: "allow-ssh", env: "dev", unknown: true},
+	} {
+		s := terraformutils.StateResource{
+			Type:       "google_compute_firewall",
+			Name:       test.id,
+			Attributes: map[string]string{"id": test.id, "labels.%": "1", "labels.env": test.env},
+		}
+		if unknown(s) != test.unknown {
+			t.Errorf("driftUnknown(%s, env=%s) = %v, want %v", test.id, test.env, !test.unknown, test.unknown)
+		}
+	}
+}
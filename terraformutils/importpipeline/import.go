@@ -0,0 +1,812 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package importpipeline
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/terraformerstring"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/providerwrapper"
+
+	"github.com/hashicorp/terraform/providers"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/terraformoutput"
+)
+
+type ImportOptions struct {
+	Resources     []string
+	Excludes      []string
+	PathPattern   string
+	PathOutput    string
+	State         string
+	Bucket        string
+	Profile       string
+	Verbose       bool
+	Zone          string
+	Regions       []string
+	Projects      []string
+	ResourceGroup string
+	Connect       bool
+	Compact       bool
+	Filter        []string
+	// Where are filter expressions, see terraformutils.FilterExpression
+	Where         []string
+	Plan          bool `json:"-"`
+	Output        string
+	NoSort        bool
+	RetryCount    int
+	RetrySleepMs  int
+	LegacyState   bool
+	Update        bool
+	BackendConfig []string
+	// ResolveReferences replaces values identifying other imported resources with references
+	ResolveReferences bool
+	// Layout is LayoutServices or LayoutModules
+	Layout string
+	// Parallelism and RateLimits configure the refresh scheduler, see terraformutils.ParseParallelism and
+	// terraformutils.ParseRateLimits
+	Parallelism []string
+	RateLimits  []string
+	// Naming is the resource naming strategy, NamingTemplate the Go template of terraformutils.NamingTemplate
+	Naming         string
+	NamingTemplate string
+	// Resume skips the services and resources a previous run listed or refreshed, see terraformutils.Checkpoint
+	Resume           bool          `json:"-"`
+	CheckpointMaxAge time.Duration `json:"-"`
+	// Report is the path of the JSON report of the import, see terraformutils.ImportReport
+	Report string `json:"-"`
+	// Strict and FailOn fail an import which lost services or resources, see terraformutils.ParseFailOn
+	Strict      bool     `json:"-"`
+	FailOn      []string `json:"-"`
+	Drift       bool     `json:"-"`
+	DriftState  string   `json:"-"`
+	DriftFormat string   `json:"-"`
+	DriftOutput string   `json:"-"`
+	// DriftWriter receives the drift report in DriftFormat, os.Stdout when nil
+	DriftWriter io.Writer `json:"-"`
+	// Secrets is the mode of terraformutils.RedactSecrets, sensitive attributes are written in plaintext when empty
+	Secrets string
+	// ProviderVersion, LockFile and PluginMirrors select the provider plugin, see InstallPlugin
+	ProviderVersion string
+	LockFile        string
+	PluginMirrors   []string
+	// Timeout cancels the imports of a command after the duration, see NewContext
+	Timeout time.Duration `json:"-"`
+	// FS is the file system of the generated files and plan files, the local file system when nil. Imports to
+	// other file systems are not checkpointed.
+	FS terraformoutput.FileSystem `json:"-"`
+	// ImportReport collects the outcome of the import in place of the report of --report, if set
+	ImportReport *terraformutils.ImportReport `json:"-"`
+	// Imported is called with the resources by service before they are written, if set
+	Imported func(resources map[string][]terraformutils.Resource) `json:"-"`
+	// Plugin is used in place of the provider plugin if set, e.g. a providertest.Provider
+	Plugin providers.Interface `json:"-"`
+	// Record writes the API calls and the reads of the provider plugin to a directory, which Replay replays
+	// offline, see SetAPIMiddleware
+	Record string `json:"-"`
+	Replay string `json:"-"`
+	// ScopeParallelism is the number of scopes of a command imported at once, see ImportScopes
+	ScopeParallelism int `json:"-"`
+	// Logger receives the log of the import, the standard logger when nil. The refresh logs to it too, the
+	// provider plugins and the file writers log to the standard logger.
+	Logger *slog.Logger `json:"-"`
+
+	// report records the outcome of the import of the current scope
+	report *terraformutils.ScopeReport
+	// recording is the recording of --record shared by the scopes of a command, see CommandOptions
+	recording *recording
+	// plugins are the provider plugins shared by the scopes of a command, see ImportScopes
+	plugins *pluginPool
+	// refreshSlots are the slots of --parallelism shared by the scopes of a command, see ImportScopes
+	refreshSlots chan struct{}
+	// where are the parsed Where expressions
+	where []*terraformutils.FilterExpression
+}
+
+const DefaultPathPattern = "{output}/{provider}/{service}/"
+
+const DefaultPathOutput = "generated"
+
+const DefaultState = "local"
+
+const ImportBlocksState = "import-blocks"
+
+// Import imports the resources of a provider scope without cancellation, see ImportContext
+func Import(provider terraformutils.ProviderGenerator, options ImportOptions, args []string) error {
+	return ImportContext(context.Background(), provider, options, args)
+}
+
+// ImportContext imports the resources of a provider scope, e.g. a project and region, and adds its outcome to
+// --report. With --strict or --fail-on an import which lost services or resources returns a
+// terraformutils.ImportErrors. When ctx is canceled, the API calls of the provider and its services are
+// canceled, the provider plugin is killed and the report of what was imported so far is written. The scopes of
+// a command share the report and the recording of CommandOptions, without them the import has its own.
+func ImportContext(ctx context.Context, provider terraformutils.ProviderGenerator, options ImportOptions, args []string) error {
+	failOn, err := terraformutils.ParseFailOn(options.FailOn, options.Strict)
+	if err != nil {
+		return err
+	}
+	options = CommandOptions(options)
+	report := options.ImportReport
+	options.report = report.NewScope(provider.GetName(), args)
+	err = importScope(ctx, provider, options, args)
+	options.report.Finish(err)
+	if err == nil {
+		err = options.report.Errors(failOn)
+	}
+	writeReport(options, report)
+	writeRecording(options)
+	return err
+}
+
+// DefaultImportOptions returns the options of terraformer import <provider> without flags
+func DefaultImportOptions() ImportOptions {
+	return ImportOptions{
+		Connect:          true,
+		PathPattern:      DefaultPathPattern,
+		PathOutput:       DefaultPathOutput,
+		State:            DefaultState,
+		Output:           "hcl",
+		RetryCount:       5,
+		RetrySleepMs:     300,
+		CheckpointMaxAge: terraformutils.DefaultCheckpointMaxAge,
+		Layout:           LayoutServices,
+		Naming:           terraformutils.NamingLegacy,
+	}
+}
+
+// CommandOptions returns the options of the imports of a provider command, with a new report, see ImportReport,
+// and a new recording of --record, unless they have them. The scopes of the command share them.
+func CommandOptions(options ImportOptions) ImportOptions {
+	if options.ImportReport == nil {
+		options.ImportReport = terraformutils.NewImportReport()
+	}
+	return withRecording(options)
+}
+
+// FileSystem returns the file system of the generated files
+func (o ImportOptions) FileSystem() terraformoutput.FileSystem {
+	if o.FS == nil {
+		return terraformoutput.OS
+	}
+	return o.FS
+}
+
+// NewContext returns the context of the imports of a provider command, canceled after --timeout
+func NewContext(ctx context.Context, options ImportOptions) (context.Context, context.CancelFunc) {
+	if options.Timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeoutCause(ctx, options.Timeout, fmt.Errorf("import timed out after --timeout=%s", options.Timeout))
+}
+
+func importScope(ctx context.Context, provider terraformutils.ProviderGenerator, options ImportOptions, args []string) error {
+	if err := ctx.Err(); err != nil {
+		return context.Cause(ctx)
+	}
+	if _, err := stateBackend(options); err != nil {
+		return err
+	}
+	if options.Update && options.Output != "hcl" {
+		return fmt.Errorf("--update supports --output=hcl only")
+	}
+	if err := validateLayout(options); err != nil {
+		return err
+	}
+	if err := terraformutils.ValidateSecrets(options.Secrets); err != nil {
+		return err
+	}
+	if _, err := terraformutils.NewNamingStrategy(options.Naming, options.NamingTemplate); err != nil {
+		return err
+	}
+	refreshOptions, err := newRefreshOptions(options)
+	if err != nil {
+		return err
+	}
+	if options.where, err = parseWhere(options.Where); err != nil {
+		return err
+	}
+
+	providerWrapper, options, err := initOptionsAndWrapper(ctx, provider, options, args)
+	if err != nil {
+		return err
+	}
+	if options.plugins == nil {
+		// plugins shared by the scopes of a command are killed by ImportScopes
+		defer providerWrapper.Kill()
+	}
+	// calls to the plugin can't be canceled, killing it fails the calls in flight
+	stopKill := context.AfterFunc(ctx, func() {
+		options.logf("%s import canceled: %v", provider.GetName(), context.Cause(ctx))
+		providerWrapper.Kill()
+	})
+	defer stopKill()
+	providerMapping := terraformutils.NewProvidersMapping(provider)
+
+	checkpoint, err := openCheckpoint(provider, providerWrapper, options, args)
+	if err != nil {
+		return err
+	}
+	defer checkpoint.Close()
+	if checkpoint.Completed() && !options.Drift {
+		options.logf("%s %s was imported by a previous run, skipping", provider.GetName(), strings.Join(args, " "))
+		options.report.Skipped()
+		return nil
+	}
+	refreshOptions.Checkpoint = checkpoint
+	refreshOptions.Report = options.report
+
+	err = initAllServicesResources(ctx, providerMapping, options, args, providerWrapper, checkpoint)
+	if err != nil {
+		return err
+	}
+
+	err = terraformutils.RefreshResourcesByProvider(ctx, providerMapping, providerWrapper, refreshOptions)
+	if err != nil {
+		return err
+	}
+	// the drift report tells the resources left out by the filters from the deleted ones
+	refreshed := resourceKeys(providerMapping)
+
+	providerMapping.ConvertTFStates(providerWrapper, options.report)
+	providerMapping.FilterResources(options.where)
+	// change structs with additional data for each resource
+	providerMapping.CleanupProviders(options.report)
+
+	if options.Drift {
+		return reportDrift(ctx, providerMapping, options, providerWrapper, refreshed)
+	}
+
+	err = importFromPlan(ctx, providerMapping, options, args, providerWrapper)
+	if err != nil {
+		return err
+	}
+
+	options.logln("Performing cleanup of stale directories based on path pattern...")
+
+	// We iterate over the original list of services the user requested for this run.
+	for _, serviceName := range options.Resources {
+		resourcesForService := providerMapping.GetResourcesByService()[serviceName]
+
+		// If a requested service resulted in zero resources...
+		if len(resourcesForService) == 0 {
+			// ...we build its exact expected path using the SAME logic Terraformer uses.
+			servicePath := Path(options.PathPattern, provider.GetName(), serviceName, options.PathOutput)
+
+			// Check if that path actually exists on disk.
+			if _, err := options.FileSystem().ReadDir(servicePath); !os.IsNotExist(err) && options.Update {
+				// Keep manual edits, only the resources known to have been imported are removed.
+				options.logf("Removing deleted resources of service '%s': %s", serviceName, servicePath)
+				if err := printService(ctx, provider, serviceName, options, nil, nil, nil, nil, providerWrapper); err != nil {
+					return err
+				}
+			} else if !os.IsNotExist(err) {
+				// It exists but is now empty, so remove it.
+				options.logf("Removing stale directory for service '%s': %s", serviceName, servicePath)
+				if err := options.FileSystem().RemoveAll(servicePath); err != nil {
+					options.logf("! Failed to remove stale directory %s: %v", servicePath, err)
+				}
+			}
+		}
+	}
+	options.logln("Cleanup complete. ✅")
+
+	return checkpoint.Complete()
+}
+
+// parseWhere parses the --where expressions
+func parseWhere(values []string) ([]*terraformutils.FilterExpression, error) {
+	expressions := []*terraformutils.FilterExpression{}
+	for _, value := range values {
+		expression, err := terraformutils.ParseFilterExpression(value)
+		if err != nil {
+			return nil, err
+		}
+		expressions = append(expressions, expression)
+	}
+	return expressions, nil
+}
+
+// newRefreshOptions returns the refresh scheduler options of --parallelism, --rate-limit and the retry flags
+func newRefreshOptions(options ImportOptions) (terraformutils.RefreshOptions, error) {
+	refreshOptions := terraformutils.DefaultRefreshOptions()
+	refreshOptions.RetryCount = options.RetryCount
+	refreshOptions.RetryDelay = time.Duration(options.RetrySleepMs) * time.Millisecond
+	refreshOptions.Logger = options.Logger
+	refreshOptions.Slots = options.refreshSlots
+	if err := terraformutils.ParseParallelism(options.Parallelism, &refreshOptions); err != nil {
+		return refreshOptions, err
+	}
+	if err := terraformutils.ParseRateLimits(options.RateLimits, &refreshOptions); err != nil {
+		return refreshOptions, err
+	}
+	return refreshOptions, nil
+}
+
+func initOptionsAndWrapper(ctx context.Context, provider terraformutils.ProviderGenerator, options ImportOptions, args []string) (*providerwrapper.ProviderWrapper, ImportOptions, error) {
+	SetProviderContext(ctx, provider)
+	if err := SetAPIMiddleware(provider, options); err != nil {
+		return nil, options, err
+	}
+	err := provider.Init(args)
+	if err != nil {
+		return nil, options, err
+	}
+
+	if terraformerstring.ContainsString(options.Resources, "*") {
+		options.logln("Attempting an import of ALL resources in " + provider.GetName())
+		options.Resources = ProviderServices(provider)
+	}
+
+	if len(options.Excludes) > 0 {
+		localSlice := []string{}
+		for _, r := range options.Resources {
+			remove := false
+			for _, e := range options.Excludes {
+				if r == e {
+					remove = true
+					options.logln("Excluding resource " + e)
+				}
+			}
+			if !remove {
+				localSlice = append(localSlice, r)
+			}
+		}
+		options.Resources = localSlice
+	}
+
+	if err := InstallPlugin(ctx, provider, options); err != nil {
+		return nil, options, err
+	}
+	if options.plugins != nil {
+		providerWrapper, err := options.plugins.get(provider, func(config cty.Value) (*providerwrapper.ProviderWrapper, error) {
+			return newProviderWrapper(provider, config, options)
+		})
+		return providerWrapper, options, err
+	}
+	providerWrapper, err := newProviderWrapper(provider, provider.GetConfig(), options)
+	return providerWrapper, options, err
+}
+
+// newProviderWrapper starts the provider plugin configured with config, or wraps the plugin of options.Plugin or
+// --replay, and records it with --record
+func newProviderWrapper(provider terraformutils.ProviderGenerator, config cty.Value, options ImportOptions) (*providerwrapper.ProviderWrapper, error) {
+	if options.Plugin == nil && options.Replay != "" {
+		plugin, err := replayedPlugin(options)
+		if err != nil {
+			return nil, err
+		}
+		options.Plugin = plugin
+	}
+	wrapperOptions := map[string]int{"retryCount": options.RetryCount, "retrySleepMs": options.RetrySleepMs}
+	var providerWrapper *providerwrapper.ProviderWrapper
+	var err error
+	if options.Plugin != nil {
+		providerWrapper, err = providerwrapper.NewProviderWrapperWithProvider(provider.GetName(), options.Plugin, config, wrapperOptions)
+	} else {
+		providerWrapper, err = providerwrapper.NewProviderWrapper(provider.GetName(), config, options.Verbose, wrapperOptions)
+	}
+	if err != nil {
+		return nil, err
+	}
+	recordPlugin(providerWrapper, options)
+	return providerWrapper, nil
+}
+
+// InstallPlugin installs the provider plugin selected by --provider-version, --lock-file and --plugin-mirror, see
+// providerwrapper.InstallPlugin. Without them the plugin found in the plugin directories of terraform init is used.
+func InstallPlugin(ctx context.Context, provider terraformutils.ProviderGenerator, options ImportOptions) error {
+	if options.ProviderVersion == "" && options.LockFile == "" && len(options.PluginMirrors) == 0 {
+		return nil
+	}
+	installOptions := providerwrapper.InstallOptions{
+		Constraint: options.ProviderVersion,
+		Mirrors:    options.PluginMirrors,
+	}
+	if providerWithSource, ok := provider.(terraformutils.ProviderWithSource); ok {
+		installOptions.Source = providerWithSource.GetSource()
+	}
+	if options.LockFile != "" {
+		lock, err := providerwrapper.ReadLockFile(options.LockFile)
+		if err != nil {
+			return err
+		}
+		installOptions.Lock = lock
+	}
+	plugin, err := providerwrapper.InstallPlugin(ctx, provider.GetName(), installOptions)
+	if err != nil {
+		return err
+	}
+	options.logf("%s: using %s %s at %s", provider.GetName(), plugin.Source, plugin.Version, plugin.Path)
+	return nil
+}
+
+// SetProviderContext sets the context of a provider, see terraformutils.ProviderWithContext
+func SetProviderContext(ctx context.Context, provider terraformutils.ProviderGenerator) {
+	if providerWithContext, ok := provider.(terraformutils.ProviderWithContext); ok {
+		providerWithContext.SetContext(ctx)
+	}
+}
+
+func initAllServicesResources(ctx context.Context, providersMapping *terraformutils.ProvidersMapping, options ImportOptions, args []string, providerWrapper *providerwrapper.ProviderWrapper, checkpoint *terraformutils.Checkpoint) error {
+	numOfResources := len(options.Resources)
+	var wg sync.WaitGroup
+	wg.Add(numOfResources)
+
+	var failedServices []string
+
+	for _, service := range options.Resources {
+		if ctx.Err() != nil {
+			return context.Cause(ctx)
+		}
+		serviceProvider := providersMapping.AddServiceToProvider(service)
+		SetProviderContext(ctx, serviceProvider)
+		err := serviceProvider.Init(args)
+		if err != nil {
+			options.report.ServiceFailed(service, err)
+			return err
+		}
+		err = initServiceResources(ctx, service, serviceProvider, options, providerWrapper, checkpoint)
+		if ctx.Err() != nil {
+			// the service failed because it was canceled
+			return context.Cause(ctx)
+		}
+		if err != nil {
+			failedServices = append(failedServices, service)
+		}
+	}
+
+	// remove providers that failed to init their service
+	providersMapping.RemoveServices(failedServices)
+	providersMapping.ProcessResources(false)
+
+	return nil
+}
+
+func importFromPlan(ctx context.Context, providerMapping *terraformutils.ProvidersMapping, options ImportOptions, args []string, providerWrapper *providerwrapper.ProviderWrapper) error {
+	plan := &ImportPlan{
+		Provider:         providerMapping.GetBaseProvider().GetName(),
+		Options:          options,
+		Args:             args,
+		ImportedResource: map[string][]terraformutils.Resource{},
+	}
+
+	resourcesByService := providerMapping.GetResourcesByService()
+	for service := range resourcesByService {
+		plan.ImportedResource[service] = append(plan.ImportedResource[service], resourcesByService[service]...)
+	}
+
+	if options.Plan {
+		path := Path(options.PathPattern, providerMapping.GetBaseProvider().GetName(), "terraformer", options.PathOutput)
+		return exportPlanFile(options.FileSystem(), plan, path, "plan.json")
+	}
+
+	return ImportFromPlan(ctx, providerMapping.GetBaseProvider(), plan, providerWrapper)
+}
+
+func initServiceResources(ctx context.Context, service string, provider terraformutils.ProviderGenerator,
+	options ImportOptions, providerWrapper *providerwrapper.ProviderWrapper, checkpoint *terraformutils.Checkpoint) error {
+	options.logln(provider.GetName() + " importing... " + service)
+	start := time.Now()
+	err := provider.InitService(service, options.Verbose)
+	if err != nil {
+		options.logf("%s error importing %s, err: %s\n", provider.GetName(), service, err)
+		options.report.ServiceFailed(service, err)
+		return err
+	}
+	if serviceWithContext, ok := provider.GetService().(terraformutils.ServiceWithContext); ok {
+		serviceWithContext.SetContext(ctx)
+	}
+	provider.GetService().ParseFilters(options.Filter)
+	if resources, ok := checkpoint.Listed(service); ok {
+		resources = terraformutils.FilterResources(options.where, service, resources, false)
+		provider.GetService().SetResources(resources)
+		options.report.Listed(service, resources, time.Since(start), true)
+		options.logf("%s restored %d resources of %s from the checkpoint", provider.GetName(), len(resources), service)
+		return nil
+	}
+	err = provider.GetService().InitResources()
+	if err != nil {
+		options.logf("%s error initializing resources in service %s, err: %s\n", provider.GetName(), service, err)
+		options.report.ServiceFailed(service, err)
+		return err
+	}
+
+	provider.GetService().PopulateIgnoreKeys(providerWrapper)
+	provider.GetService().InitialCleanup()
+	// the checkpoint is filtered when it is restored, --where may change between runs
+	if err := checkpoint.SaveListed(service, provider.GetService().GetResources()); err != nil {
+		options.logf("%s failed to checkpoint %s: %v", provider.GetName(), service, err)
+	}
+	provider.GetService().SetResources(terraformutils.FilterResources(options.where, service, provider.GetService().GetResources(), false))
+	options.report.Listed(service, provider.GetService().GetResources(), time.Since(start), false)
+	options.logln(provider.GetName() + " done importing " + service)
+
+	return nil
+}
+
+// ImportFromPlan writes the planned resources. providerWrapper is used for provider schemas and may be nil,
+// e.g. when importing a planfile, in which case state attributes are written untyped. When ctx is canceled,
+// no further service is written.
+func ImportFromPlan(ctx context.Context, provider terraformutils.ProviderGenerator, plan *ImportPlan, providerWrapper *providerwrapper.ProviderWrapper) error {
+	options := plan.Options
+	if options.Layout == "" {
+		// plan files written before layouts were introduced
+		options.Layout = LayoutServices
+	}
+	if err := validateLayout(options); err != nil {
+		return err
+	}
+	if err := terraformutils.ValidateSecrets(options.Secrets); err != nil {
+		return err
+	}
+	importedResource := plan.ImportedResource
+	isServicePath := strings.Contains(options.PathPattern, "{service}")
+
+	naming, err := terraformutils.NewNamingStrategy(options.Naming, options.NamingTemplate)
+	if err != nil {
+		return err
+	}
+	// references and outputs are named after the resources, rename them first
+	if importedResource, err = terraformutils.RenameResources(importedResource, naming); err != nil {
+		return err
+	}
+
+	if options.Connect {
+		options.logln(provider.GetName() + " Connecting.... ")
+		importedResource = terraformutils.ConnectServices(importedResource, isServicePath, provider.GetResourceConnections())
+	}
+	remoteStates := map[string][]string{}
+	if options.ResolveReferences {
+		options.logln(provider.GetName() + " Resolving references.... ")
+		identityAttributes := map[string][]string{}
+		if providerWithIdentities, ok := provider.(terraformutils.ProviderWithIdentityAttributes); ok {
+			identityAttributes = providerWithIdentities.GetIdentityAttributes()
+		}
+		importedResource, remoteStates = terraformutils.ResolveReferences(importedResource, isServicePath, identityAttributes)
+	}
+	// secrets are the values of the sensitive input variables by service
+	secrets := map[string]map[string]interface{}{}
+	if options.Secrets != "" {
+		options.logln(provider.GetName() + " Redacting secrets.... ")
+		for service, resources := range importedResource {
+			secrets[service] = terraformutils.RedactSecrets(resources, options.Secrets)
+		}
+	} else {
+		for service, resources := range importedResource {
+			if count := terraformutils.PlaintextSecrets(resources); count > 0 {
+				options.logf("WARNING: %s: %d sensitive attributes of service %s are written in plaintext to the configuration and the state, use --secrets=omit, variables or tfvars to keep them out", provider.GetName(), count, service)
+			}
+		}
+	}
+
+	if ctx.Err() != nil {
+		return context.Cause(ctx)
+	}
+	if options.Imported != nil {
+		options.Imported(importedResource)
+	}
+	if options.Layout == LayoutModules {
+		return printModules(ctx, provider, options, importedResource, secrets, providerWrapper)
+	}
+
+	if !isServicePath {
+		var compactedResources []terraformutils.Resource
+		compactedSecrets := map[string]interface{}{}
+		for service, resources := range importedResource {
+			compactedResources = append(compactedResources, resources...)
+			for name, value := range secrets[service] {
+				compactedSecrets[name] = value
+			}
+		}
+		e := printService(ctx, provider, "", options, compactedResources, importedResource, nil, compactedSecrets, providerWrapper)
+		if e != nil {
+			return e
+		}
+	} else {
+		for serviceName, resources := range importedResource {
+			if len(resources) == 0 {
+				options.logf("%s: No resources found for service %s. Skipping file output.", provider.GetName(), serviceName)
+				continue // Go to the next service
+			}
+			if ctx.Err() != nil {
+				return context.Cause(ctx)
+			}
+			e := printService(ctx, provider, serviceName, options, resources, importedResource, remoteStates[serviceName], secrets[serviceName], providerWrapper)
+			if e != nil {
+				return e
+			}
+		}
+	}
+	return nil
+}
+
+// printService writes the files of a service, remoteStates lists the other services it reads resolved references from
+// and secrets are the values of the sensitive input variables of its resources
+func printService(ctx context.Context, provider terraformutils.ProviderGenerator, serviceName string, options ImportOptions, resources []terraformutils.Resource, importedResource map[string][]terraformutils.Resource, remoteStates []string, secrets map[string]interface{}, providerWrapper *providerwrapper.ProviderWrapper) error {
+	options.logln(provider.GetName() + " save " + serviceName)
+	// Print HCL files for Resources
+	path := Path(options.PathPattern, provider.GetName(), serviceName, options.PathOutput)
+	backend, err := stateBackend(options)
+	if err != nil {
+		return err
+	}
+	if options.Update {
+		// keep the existing files and labels, the state is written with the labels found in them
+		updated, summary, err := terraformoutput.UpdateHclFiles(ctx, options.Logger, options.FileSystem(), backend, resources, provider, path, serviceName, options.Compact, options.Output, !options.NoSort)
+		if err != nil {
+			return err
+		}
+		resources = updated
+		reportWritten(options, serviceName, path, resources, summary)
+	} else if err := terraformoutput.OutputHclFiles(options.FileSystem(), resources, provider, path, serviceName, options.Compact, options.Output, !options.NoSort); err != nil {
+		return err
+	} else {
+		reportWritten(options, serviceName, path, resources, nil)
+	}
+	if err := terraformoutput.OutputManifest(options.FileSystem(), map[string][]terraformutils.Resource{"": resources}, path, options.Output); err != nil {
+		return err
+	}
+	// print import blocks instead of a state file
+	if options.State == ImportBlocksState {
+		if serviceName == "" {
+			options.logln(provider.GetName() + " save import blocks")
+		} else {
+			options.logln(provider.GetName() + " save import blocks for " + serviceName)
+		}
+		if err := terraformoutput.OutputImportBlocks(options.FileSystem(), resources, path, options.Output, !options.NoSort); err != nil {
+			return err
+		}
+	} else if err := printTfState(ctx, provider, serviceName, options, resources, path, backend, providerWrapper); err != nil {
+		return err
+	}
+	// Print hcl variables.tf
+	variables := map[string]interface{}{}
+	if serviceName != "" {
+		services := map[string]bool{}
+		if options.Connect {
+			for k := range provider.GetResourceConnections()[serviceName] {
+				services[k] = true
+			}
+		}
+		for _, k := range remoteStates {
+			services[k] = true
+		}
+		remoteStateData := map[string]interface{}{}
+		for k := range services {
+			if _, exist := importedResource[k]; !exist {
+				continue
+			}
+			remoteStateData[k] = map[string]interface{}{
+				"backend": backend.Name(),
+				"config":  backend.RemoteStateConfig(path, strings.ReplaceAll(path, serviceName, k)),
+			}
+		}
+		if len(remoteStateData) > 0 {
+			variables["data"] = map[string]interface{}{"terraform_remote_state": remoteStateData}
+		}
+	} else if options.Connect {
+		variables["data"] = map[string]interface{}{"terraform_remote_state": map[string]interface{}{
+			"local": map[string]interface{}{
+				"backend": backend.Name(),
+				"config":  backend.RemoteStateConfig(path, path),
+			},
+		}}
+	}
+	if len(secrets) > 0 {
+		variables["variable"] = terraformoutput.SecretVariables(secrets)
+	}
+	// create variables file
+	if len(variables) > 0 {
+		variablesFile, err := terraformutils.Print(variables, map[string]struct{}{"config": {}}, options.Output, !options.NoSort, make(map[string]map[string][]string))
+		if err != nil {
+			return err
+		}
+		if err := terraformoutput.PrintFile(options.FileSystem(), path+"/variables."+terraformoutput.GetFileExtension(options.Output), variablesFile); err != nil {
+			return err
+		}
+	}
+	if options.Secrets == terraformutils.SecretsTfvars {
+		return terraformoutput.OutputSecrets(options.FileSystem(), secrets, path, options.Output)
+	}
+	return nil
+}
+
+// stateBackend returns the backend selected by --state and --backend-config
+func stateBackend(options ImportOptions) (terraformoutput.StateBackend, error) {
+	if options.State == ImportBlocksState {
+		// terraform apply writes a local state once the import blocks are applied
+		return terraformoutput.LocalBackend{FS: options.FS}, nil
+	}
+	config, err := terraformoutput.ParseBackendConfig(options.BackendConfig)
+	if err != nil {
+		return nil, err
+	}
+	backend, err := terraformoutput.NewStateBackend(options.State, options.Bucket, config)
+	if local, ok := backend.(terraformoutput.LocalBackend); ok {
+		local.FS = options.FS
+		return local, err
+	}
+	return backend, err
+}
+
+func printTfState(ctx context.Context, provider terraformutils.ProviderGenerator, serviceName string, options ImportOptions, resources []terraformutils.Resource, path string, backend terraformoutput.StateBackend, providerWrapper *providerwrapper.ProviderWrapper) error {
+	var tfStateFile []byte
+	var err error
+	if options.LegacyState {
+		tfStateFile, err = terraformutils.PrintTfState(resources)
+	} else {
+		var schema *providers.GetSchemaResponse
+		if providerWrapper != nil {
+			schema = providerWrapper.GetSchema()
+		}
+		tfStateFile, err = terraformutils.PrintTfStateV4(resources, schema, terraformutils.ProviderSources(provider))
+	}
+	if err != nil {
+		return err
+	}
+	return writeTfState(ctx, provider, serviceName, options, tfStateFile, path, backend)
+}
+
+// writeTfState uploads the state of the module at path to the backend and writes its backend file
+func writeTfState(ctx context.Context, provider terraformutils.ProviderGenerator, serviceName string, options ImportOptions, tfStateFile []byte, path string, backend terraformoutput.StateBackend) error {
+	// print or upload State file
+	if serviceName == "" {
+		options.logln(provider.GetName() + " save tfstate to " + backend.Name() + " backend")
+	} else {
+		options.logln(provider.GetName() + " save tfstate for " + serviceName + " to " + backend.Name() + " backend")
+	}
+	if err := backend.Upload(ctx, path, tfStateFile); err != nil {
+		return err
+	}
+	// create backend file
+	if backendData := terraformoutput.BackendTfData(backend, path); backendData != nil {
+		backendDataFile, err := terraformutils.Print(backendData, map[string]struct{}{}, options.Output, !options.NoSort, make(map[string]map[string][]string))
+		if err != nil {
+			return err
+		}
+		if err := terraformoutput.PrintFile(options.FileSystem(), path+"/backend."+terraformoutput.GetFileExtension(options.Output), backendDataFile); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func Path(pathPattern, providerName, serviceName, output string) string {
+	return strings.NewReplacer(
+		"{provider}", providerName,
+		"{service}", serviceName,
+		"{output}", output,
+	).Replace(pathPattern)
+}
+
+func ProviderServices(provider terraformutils.ProviderGenerator) []string {
+	var services []string
+	for k := range provider.GetSupportedService() {
+		services = append(services, k)
+	}
+	sort.Strings(services)
+	return services
+}
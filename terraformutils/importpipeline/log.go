@@ -0,0 +1,31 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package importpipeline
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+// logf logs a line of the import to Logger, or to the standard logger without one
+func (o ImportOptions) logf(format string, v ...interface{}) {
+	terraformutils.Logf(o.Logger, format, v...)
+}
+
+// logln logs the operands separated by spaces like log.Println, see logf
+func (o ImportOptions) logln(v ...interface{}) {
+	o.logf("%s", strings.TrimSuffix(fmt.Sprintln(v...), "\n"))
+}
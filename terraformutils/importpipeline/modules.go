@@ -0,0 +1,163 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package importpipeline
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/providerwrapper"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/terraformoutput"
+	"github.com/hashicorp/terraform/providers"
+)
+
+// LayoutServices writes every service as an independent root module with its own state
+const LayoutServices = "services"
+
+// LayoutModules writes a root module with a single state and one child module per service
+const LayoutModules = "modules"
+
+func validateLayout(options ImportOptions) error {
+	switch options.Layout {
+	case LayoutServices:
+		return nil
+	case LayoutModules:
+		if !strings.Contains(options.PathPattern, "{service}") {
+			return fmt.Errorf("--layout=%s requires {service} in --path-pattern", LayoutModules)
+		}
+		if options.Update || options.LegacyState {
+			return fmt.Errorf("--layout=%s does not support --update and --legacy-state", LayoutModules)
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported layout: %s", options.Layout)
+}
+
+// modulesRootPath returns the directory of the root module, the path pattern without the {service} segment.
+// Segments after {service} stay part of it, so scopes writing to e.g. <project>/{service}/<region> get a
+// root module per region.
+func modulesRootPath(options ImportOptions, providerName string) string {
+	return filepath.Clean(Path(options.PathPattern, providerName, "", options.PathOutput))
+}
+
+// moduleSource returns the source of the module in path called by the root module in rootPath
+func moduleSource(rootPath, path string) (string, error) {
+	source, err := filepath.Rel(rootPath, filepath.Clean(path))
+	if err != nil {
+		return "", err
+	}
+	source = filepath.ToSlash(source)
+	if strings.HasPrefix(source, "../") {
+		return source, nil
+	}
+	return "./" + source, nil
+}
+
+// printModules writes every service as a child module of a root module owning the state. Remote state
+// references between services become module inputs wired in the root main.tf.
+func printModules(ctx context.Context, provider terraformutils.ProviderGenerator, options ImportOptions, importedResource map[string][]terraformutils.Resource, secrets map[string]map[string]interface{}, providerWrapper *providerwrapper.ProviderWrapper) error {
+	importedResource, inputs := terraformutils.ModuleInputs(importedResource)
+	rootPath := modulesRootPath(options, provider.GetName())
+
+	services := []string{}
+	for serviceName, resources := range importedResource {
+		if len(resources) == 0 {
+			options.logf("%s: No resources found for service %s. Skipping file output.", provider.GetName(), serviceName)
+			continue
+		}
+		services = append(services, serviceName)
+	}
+	sort.Strings(services)
+
+	modules := []terraformoutput.ModuleCall{}
+	resourcesByModule := map[string][]terraformutils.Resource{}
+	// the values of the sensitive input variables of the modules are set in the root module
+	rootSecrets := map[string]interface{}{}
+	for _, serviceName := range terraformutils.ModuleOrder(services, inputs) {
+		if ctx.Err() != nil {
+			return context.Cause(ctx)
+		}
+		options.logln(provider.GetName() + " save module " + serviceName)
+		path := Path(options.PathPattern, provider.GetName(), serviceName, options.PathOutput)
+		if err := terraformoutput.OutputModuleHclFiles(options.FileSystem(), importedResource[serviceName], provider, path, serviceName, options.Compact, options.Output, !options.NoSort); err != nil {
+			return err
+		}
+		reportWritten(options, serviceName, path, importedResource[serviceName], nil)
+		moduleInputs := map[string]string{}
+		for input, source := range inputs[serviceName] {
+			if len(importedResource[source]) == 0 {
+				options.logf("%s: %s reads %s from service %s, which was not imported", provider.GetName(), serviceName, input, source)
+				continue
+			}
+			moduleInputs[input] = source
+		}
+		if err := terraformoutput.OutputModuleVariables(options.FileSystem(), moduleInputs, secrets[serviceName], path, options.Output, !options.NoSort); err != nil {
+			return err
+		}
+		moduleSecrets := []string{}
+		for name, value := range secrets[serviceName] {
+			moduleSecrets = append(moduleSecrets, name)
+			rootSecrets[name] = value
+		}
+		sort.Strings(moduleSecrets)
+		source, err := moduleSource(rootPath, path)
+		if err != nil {
+			return err
+		}
+		modules = append(modules, terraformoutput.ModuleCall{
+			Name:    serviceName,
+			Source:  source,
+			Inputs:  moduleInputs,
+			Secrets: moduleSecrets,
+		})
+		resourcesByModule[serviceName] = importedResource[serviceName]
+	}
+
+	options.logln(provider.GetName() + " save root module")
+	if err := terraformoutput.OutputRootModule(options.FileSystem(), provider, rootPath, modules, options.Output, !options.NoSort); err != nil {
+		return err
+	}
+	if options.Secrets == terraformutils.SecretsTfvars {
+		if err := terraformoutput.OutputSecrets(options.FileSystem(), rootSecrets, rootPath, options.Output); err != nil {
+			return err
+		}
+	}
+	if err := terraformoutput.OutputManifest(options.FileSystem(), resourcesByModule, rootPath, options.Output); err != nil {
+		return err
+	}
+	backend, err := stateBackend(options)
+	if err != nil {
+		return err
+	}
+	if options.State == ImportBlocksState {
+		importsFile, err := terraformutils.HclPrintModuleImportBlocks(resourcesByModule, options.Output, !options.NoSort)
+		if err != nil {
+			return err
+		}
+		return terraformoutput.PrintFile(options.FileSystem(), filepath.Join(rootPath, "imports."+terraformoutput.GetFileExtension(options.Output)), importsFile)
+	}
+	var schema *providers.GetSchemaResponse
+	if providerWrapper != nil {
+		schema = providerWrapper.GetSchema()
+	}
+	tfStateFile, err := terraformutils.PrintModulesTfStateV4(resourcesByModule, schema, terraformutils.ProviderSources(provider))
+	if err != nil {
+		return err
+	}
+	return writeTfState(ctx, provider, "", options, tfStateFile, rootPath, backend)
+}
@@ -0,0 +1,52 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package importpipeline
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestModulesRootPathRegions(t *testing.T) {
+	roots := map[string]bool{}
+	for _, region := range []string{"europe-west1", "us-east1"} {
+		options := ImportOptions{
+			PathPattern: strings.ReplaceAll(DefaultPathPattern, "{service}", "project/{service}/"+region),
+			PathOutput:  DefaultPathOutput,
+		}
+		root := modulesRootPath(options, "google")
+		if expected := "generated/google/project/" + region; root != expected {
+			t.Errorf("expected root module %s, got %s", expected, root)
+		}
+		roots[root] = true
+		source, err := moduleSource(root, Path(options.PathPattern, "google", "networks", options.PathOutput))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := "../networks/" + region; source != expected {
+			t.Errorf("expected module source %s, got %s", expected, source)
+		}
+	}
+	if len(roots) != 2 {
+		t.Errorf("expected a root module per region, got %v", roots)
+	}
+
+	source, err := moduleSource("generated/google", "generated/google/networks/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if source != "./networks" {
+		t.Errorf("expected module source ./networks, got %s", source)
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package importpipeline
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/terraformoutput"
+)
+
+// ImportPlan is a plan read from or written to a plan file, see terraformutils.PlanFile
+type ImportPlan struct {
+	// Version is the version of terraformer which wrote the plan
+	Version          string
+	Provider         string
+	Options          ImportOptions
+	Args             []string
+	ImportedResource map[string][]terraformutils.Resource
+}
+
+// LoadPlanfile reads a plan file, plan files of previous format versions are migrated
+func LoadPlanfile(path string) (*ImportPlan, error) {
+	planFile, err := ReadPlanFile(path)
+	if err != nil {
+		return nil, err
+	}
+	plan := &ImportPlan{
+		Version:          planFile.TerraformerVersion,
+		Provider:         planFile.Provider,
+		Args:             planFile.Args,
+		ImportedResource: planFile.Resources(),
+	}
+	if len(planFile.Options) > 0 {
+		if err := json.Unmarshal(planFile.Options, &plan.Options); err != nil {
+			return nil, fmt.Errorf("%s: invalid options: %w", path, err)
+		}
+	}
+	return plan, nil
+}
+
+// ReadPlanFile reads a plan file of any format version
+func ReadPlanFile(path string) (*terraformutils.PlanFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	plan, err := terraformutils.ReadPlanFile(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return plan, nil
+}
+
+func ExportPlanFile(plan *ImportPlan, path, filename string) error {
+	return exportPlanFile(terraformoutput.OS, plan, path, filename)
+}
+
+func exportPlanFile(fsys terraformoutput.FileSystem, plan *ImportPlan, path, filename string) error {
+	plan.Version = Version
+	options, err := json.Marshal(plan.Options)
+	if err != nil {
+		return err
+	}
+
+	planfilePath := filepath.Join(path, filename)
+	plan.Options.logln("Saving planfile to", planfilePath)
+
+	if err := fsys.MkdirAll(path, os.ModePerm); err != nil {
+		return err
+	}
+
+	data := bytes.Buffer{}
+	err = terraformutils.WritePlanFile(&data, &terraformutils.PlanFile{
+		TerraformerVersion: plan.Version,
+		Provider:           plan.Provider,
+		Args:               plan.Args,
+		Options:            options,
+		Services:           terraformutils.NewPlanResources(plan.ImportedResource),
+	})
+	if err != nil {
+		return err
+	}
+	return fsys.WriteFile(planfilePath, data.Bytes(), os.ModePerm)
+}
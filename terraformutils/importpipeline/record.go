@@ -0,0 +1,120 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package importpipeline
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/httpfixture"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/providerwrapper"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/providerwrapper/providertest"
+)
+
+const (
+	// recordedAPIFile holds the API calls of the provider in the directory of --record and --replay
+	recordedAPIFile = "api.json"
+	// recordedPluginFile holds the schema and the resource states of the provider plugin
+	recordedPluginFile = "provider.json"
+)
+
+// recording is the recording of --record to a directory, shared by the imports of the scopes of a command and
+// rewritten after every scope
+type recording struct {
+	api    *httpfixture.Recorder
+	plugin *providertest.Recorder
+	// mu serializes the writes of the scopes imported concurrently
+	mu sync.Mutex
+}
+
+// withRecording returns options with a new recording of --record, unless they have one
+func withRecording(options ImportOptions) ImportOptions {
+	if options.Record != "" && options.recording == nil {
+		options.recording = &recording{api: httpfixture.NewRecorder(), plugin: providertest.NewRecorder()}
+	}
+	return options
+}
+
+// writeRecording writes the recording of --record
+func writeRecording(options ImportOptions) {
+	dir := options.Record
+	if dir == "" || options.recording == nil {
+		return
+	}
+	options.recording.mu.Lock()
+	defer options.recording.mu.Unlock()
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		options.logf("failed to write recording %s: %v", dir, err)
+		return
+	}
+	if err := options.recording.api.Write(filepath.Join(dir, recordedAPIFile)); err != nil {
+		options.logf("failed to write recording %s: %v", dir, err)
+		return
+	}
+	if err := options.recording.plugin.Write(filepath.Join(dir, recordedPluginFile)); err != nil {
+		options.logf("failed to write recording %s: %v", dir, err)
+		return
+	}
+	options.logln("Saving recording to", dir)
+}
+
+// SetAPIMiddleware sends the API calls of a provider through the recorder of --record or the replayer of
+// --replay, see terraformutils.ProviderWithAPIMiddleware. It is called before the provider is initialized. The
+// calls are recorded to the recording of options, see CommandOptions.
+func SetAPIMiddleware(provider terraformutils.ProviderGenerator, options ImportOptions) error {
+	if options.Record != "" && options.Replay != "" {
+		return fmt.Errorf("--record and --replay are mutually exclusive")
+	}
+	providerWithAPIMiddleware, ok := provider.(terraformutils.ProviderWithAPIMiddleware)
+	switch {
+	case options.Record != "" && !ok:
+		options.logf("%s does not support recording its API calls, only the provider plugin is recorded to %s", provider.GetName(), options.Record)
+	case options.Record != "" && options.recording == nil:
+		return fmt.Errorf("no recording for --record=%s, see CommandOptions", options.Record)
+	case options.Record != "":
+		providerWithAPIMiddleware.SetAPIMiddleware(options.recording.api)
+	case options.Replay != "" && !ok:
+		return fmt.Errorf("%s does not support replaying its API calls", provider.GetName())
+	case options.Replay != "":
+		fixture, err := httpfixture.Load(filepath.Join(options.Replay, recordedAPIFile))
+		if err != nil {
+			return err
+		}
+		replayer, err := httpfixture.NewReplayer(fixture)
+		if err != nil {
+			return err
+		}
+		providerWithAPIMiddleware.SetAPIMiddleware(replayer)
+	}
+	return nil
+}
+
+// replayedPlugin returns the provider plugin replayed by --replay, nil without it
+func replayedPlugin(options ImportOptions) (*providertest.Provider, error) {
+	if options.Replay == "" {
+		return nil, nil
+	}
+	return providertest.Load(filepath.Join(options.Replay, recordedPluginFile))
+}
+
+// recordPlugin records the schema and the reads of the provider plugin of a wrapper with --record
+func recordPlugin(providerWrapper *providerwrapper.ProviderWrapper, options ImportOptions) {
+	if options.recording == nil {
+		return
+	}
+	providerWrapper.Provider = options.recording.plugin.Wrap(providerWrapper.Provider)
+}
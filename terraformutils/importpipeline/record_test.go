@@ -0,0 +1,34 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package importpipeline
+
+import "testing"
+
+func TestCommandOptionsRecording(t *testing.T) {
+	options := ImportOptions{Record: "testdata/recording"}
+	first := CommandOptions(options)
+	second := CommandOptions(options)
+	if first.recording == nil || second.recording == nil {
+		t.Fatal("CommandOptions() without a recording of --record")
+	}
+	if first.recording == second.recording {
+		t.Error("two commands share a recording of --record")
+	}
+	if CommandOptions(first).recording != first.recording {
+		t.Error("CommandOptions() replaced the recording of the command")
+	}
+	if CommandOptions(ImportOptions{}).recording != nil {
+		t.Error("CommandOptions() added a recording without --record")
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package importpipeline
+
+import (
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/terraformoutput"
+)
+
+// writeReport writes the report of --report, the report is rewritten after every scope of a command
+func writeReport(options ImportOptions, report *terraformutils.ImportReport) {
+	path := options.Report
+	if path == "" {
+		return
+	}
+	if err := report.Write(path); err != nil {
+		options.logf("failed to write report %s: %v", path, err)
+		return
+	}
+	options.logln("Saving report to", path)
+}
+
+// reportWritten records the resources of a service written to path
+func reportWritten(options ImportOptions, serviceName, path string, resources []terraformutils.Resource, summary *terraformoutput.UpdateSummary) {
+	options.report.Written(serviceName, path, resources, func(r terraformutils.Resource) (string, string) {
+		address := r.InstanceInfo.Type + "." + r.ResourceName
+		if options.Layout == LayoutModules {
+			address = "module." + serviceName + "." + address
+		}
+		if summary != nil {
+			return address, summary.Files[address]
+		}
+		return address, terraformoutput.ResourceFilePath(path, r.InstanceInfo.Type, options.Compact, options.Output)
+	})
+}
@@ -0,0 +1,28 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package importpipeline
+
+import "testing"
+
+func TestCommandOptionsReport(t *testing.T) {
+	options := ImportOptions{Report: "report.json"}
+	first := CommandOptions(options)
+	second := CommandOptions(options)
+	if first.ImportReport == nil || first.ImportReport == second.ImportReport {
+		t.Error("two commands share the report of --report")
+	}
+	if CommandOptions(first).ImportReport != first.ImportReport {
+		t.Error("CommandOptions() replaced the report of the command")
+	}
+}
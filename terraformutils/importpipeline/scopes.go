@@ -0,0 +1,142 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package importpipeline
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/providerwrapper"
+
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// DefaultScopeParallelism is the default number of scopes imported at once, see ImportScopes
+const DefaultScopeParallelism = 4
+
+// Scope is a scope of a provider command, e.g. a project and region
+type Scope struct {
+	// Name is the scope in the log, e.g. "project my-project region europe-west1"
+	Name     string
+	Provider terraformutils.ProviderGenerator
+	Args     []string
+	// PathPattern is the --path-pattern of the scope
+	PathPattern string
+	// Ignore returns true for errors which don't fail the command, e.g. a region which doesn't exist
+	Ignore func(err error) bool
+}
+
+// ImportScopes imports the scopes of a provider command, --scope-parallelism at once, into one report and
+// recording, see CommandOptions. Scopes with the same plugin config share one provider plugin, see pluginPool,
+// and all scopes share the refreshes of --parallelism. Scopes writing to the same directory are rejected. Once a
+// scope failed no more scopes are started, the first error is returned after the running scopes finished.
+func ImportScopes(ctx context.Context, options ImportOptions, scopes []Scope) error {
+	if err := checkScopePaths(options, scopes); err != nil {
+		return err
+	}
+	refreshOptions, err := newRefreshOptions(options)
+	if err != nil {
+		return err
+	}
+	options.refreshSlots = terraformutils.NewRefreshSlots(refreshOptions)
+	plugins := &pluginPool{plugins: map[string]*pooledPlugin{}}
+	defer plugins.kill()
+	options.plugins = plugins
+	options = CommandOptions(options)
+	tasks := []func(ctx context.Context) error{}
+	for _, scope := range scopes {
+		scope := scope
+		tasks = append(tasks, func(ctx context.Context) error {
+			scopeOptions := options
+			scopeOptions.PathPattern = scope.PathPattern
+			options.logln(scope.Provider.GetName() + " importing " + scope.Name)
+			err := ImportContext(ctx, scope.Provider, scopeOptions, scope.Args)
+			if err != nil && scope.Ignore != nil && scope.Ignore(err) {
+				options.logln(err)
+				return nil
+			}
+			if err != nil {
+				// the other scopes running concurrently may fail too, only the first error is returned
+				options.logf("%s import of %s failed: %v", scope.Provider.GetName(), scope.Name, err)
+			}
+			return err
+		})
+	}
+	return terraformutils.FanOut(ctx, options.ScopeParallelism, tasks)
+}
+
+// checkScopePaths returns an error when two scopes write to the same directory, e.g. the regions of a project
+// with a --path-pattern without {service}. Their files and states would overwrite each other.
+func checkScopePaths(options ImportOptions, scopes []Scope) error {
+	names := map[string]string{}
+	for _, scope := range scopes {
+		path := filepath.Clean(Path(scope.PathPattern, scope.Provider.GetName(), "", options.PathOutput))
+		if name, ok := names[path]; ok {
+			return fmt.Errorf("%s and %s are both written to %s, add {service} to --path-pattern", name, scope.Name, path)
+		}
+		names[path] = scope.Name
+	}
+	return nil
+}
+
+// pluginPool starts one provider plugin per provider and plugin config, the plugin config of a provider is its
+// shared config if it has one, see terraformutils.ProviderWithSharedConfig
+type pluginPool struct {
+	mu      sync.Mutex
+	plugins map[string]*pooledPlugin
+}
+
+type pooledPlugin struct {
+	once            sync.Once
+	providerWrapper *providerwrapper.ProviderWrapper
+	err             error
+}
+
+// get returns the plugin of an initialized provider, started by start with the plugin config on first use
+func (p *pluginPool) get(provider terraformutils.ProviderGenerator, start func(config cty.Value) (*providerwrapper.ProviderWrapper, error)) (*providerwrapper.ProviderWrapper, error) {
+	config := provider.GetConfig()
+	if providerWithSharedConfig, ok := provider.(terraformutils.ProviderWithSharedConfig); ok {
+		config = providerWithSharedConfig.GetSharedConfig()
+	}
+	key, err := ctyjson.Marshal(config, config.Type())
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	plugin, ok := p.plugins[provider.GetName()+" "+string(key)]
+	if !ok {
+		plugin = &pooledPlugin{}
+		p.plugins[provider.GetName()+" "+string(key)] = plugin
+	}
+	p.mu.Unlock()
+	plugin.once.Do(func() {
+		plugin.providerWrapper, plugin.err = start(config)
+	})
+	return plugin.providerWrapper, plugin.err
+}
+
+// kill kills the started plugins
+func (p *pluginPool) kill() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, plugin := range p.plugins {
+		if plugin.providerWrapper != nil {
+			plugin.providerWrapper.Kill()
+		}
+	}
+}
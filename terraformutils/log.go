@@ -0,0 +1,31 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+	"strings"
+)
+
+// Logf logs a line to logger, or to the standard logger when it is nil
+func Logf(logger *slog.Logger, format string, v ...interface{}) {
+	if logger == nil {
+		log.Printf(format, v...)
+		return
+	}
+	logger.Info(strings.TrimSuffix(fmt.Sprintf(format, v...), "\n"))
+}
@@ -0,0 +1,38 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"bytes"
+	"log"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLogf(t *testing.T) {
+	var standard, logged bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(&standard)
+
+	Logf(slog.New(slog.NewTextHandler(&logged, nil)), "refreshing %s\n", "networks")
+	if !strings.Contains(logged.String(), `msg="refreshing networks"`) || standard.Len() != 0 {
+		t.Errorf("expected the line in the logger only, got %q and %q", logged.String(), standard.String())
+	}
+	Logf(nil, "refreshing %s", "gcs")
+	if !strings.Contains(standard.String(), "refreshing gcs") {
+		t.Errorf("expected the line in the standard logger, got %q", standard.String())
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"bytes"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/providers"
+)
+
+var remoteStateOutputRe = regexp.MustCompile(`\$\{data\.terraform_remote_state\.([^.}]+)\.outputs\.([^}]+)\}`)
+
+// ModuleInputs rewrites the terraform_remote_state references between services, written by ConnectServices
+// and ResolveReferences, to input variables of the service modules. For every service it returns the
+// variables it declares, mapped to the service whose module output of the same name provides them.
+func ModuleInputs(importResources map[string][]Resource) (map[string][]Resource, map[string]map[string]string) {
+	inputs := map[string]map[string]string{}
+	for service := range importResources {
+		for i := range importResources[service] {
+			r := &importResources[service][i]
+			replace := func(key, value string) (string, bool) {
+				if !remoteStateOutputRe.MatchString(value) {
+					return "", false
+				}
+				return remoteStateOutputRe.ReplaceAllStringFunc(value, func(match string) string {
+					m := remoteStateOutputRe.FindStringSubmatch(match)
+					if inputs[service] == nil {
+						inputs[service] = map[string]string{}
+					}
+					inputs[service][m[2]] = m[1]
+					return "${var." + m[2] + "}"
+				}), true
+			}
+			for key, value := range r.Item {
+				r.Item[key] = replaceReferences(key, value, replace)
+			}
+		}
+	}
+	return importResources, inputs
+}
+
+// ModuleOrder sorts services so that every service comes after the services it reads inputs from.
+// Services in a dependency cycle are appended in alphabetical order.
+func ModuleOrder(services []string, inputs map[string]map[string]string) []string {
+	dependencies := map[string]map[string]bool{}
+	for _, service := range services {
+		dependencies[service] = map[string]bool{}
+		for _, source := range inputs[service] {
+			if source != service {
+				dependencies[service][source] = true
+			}
+		}
+	}
+	remaining := append([]string{}, services...)
+	sort.Strings(remaining)
+	ordered := []string{}
+	done := map[string]bool{}
+	for len(remaining) > 0 {
+		next := []string{}
+		for _, service := range remaining {
+			ready := true
+			for source := range dependencies[service] {
+				if _, known := dependencies[source]; known && !done[source] {
+					ready = false
+				}
+			}
+			if ready {
+				ordered = append(ordered, service)
+				done[service] = true
+			} else {
+				next = append(next, service)
+			}
+		}
+		if len(next) == len(remaining) {
+			log.Printf("services %s depend on each other", strings.Join(next, ", "))
+			ordered = append(ordered, next...)
+			break
+		}
+		remaining = next
+	}
+	return ordered
+}
+
+// PrintModulesTfStateV4 prints a single state for resources grouped by the name of the module they are written to
+func PrintModulesTfStateV4(resourcesByModule map[string][]Resource, schema *providers.GetSchemaResponse, providerSources map[string]string) ([]byte, error) {
+	modules := make([]string, 0, len(resourcesByModule))
+	for module := range resourcesByModule {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+	var state *StateV4
+	for _, module := range modules {
+		moduleState, err := NewTfStateV4(resourcesByModule[module], schema, providerSources)
+		if err != nil {
+			return nil, err
+		}
+		for i := range moduleState.Resources {
+			moduleState.Resources[i].Module = "module." + module
+		}
+		if state == nil {
+			state = moduleState
+		} else {
+			state.Resources = append(state.Resources, moduleState.Resources...)
+		}
+	}
+	if state == nil {
+		var err error
+		if state, err = NewTfStateV4(nil, schema, providerSources); err != nil {
+			return nil, err
+		}
+	}
+	// only outputs of the root module are stored in the state
+	state.Outputs = map[string]OutputStateV4{}
+	var buf bytes.Buffer
+	err := WriteStateV4(state, &buf)
+	return buf.Bytes(), err
+}
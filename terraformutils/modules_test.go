@@ -0,0 +1,117 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestModuleInputs(t *testing.T) {
+	importResources := map[string][]Resource{
+		"firewall": {prepare("ID1", "type1", map[string]string{
+			"network": "ID2",
+		}, map[string]interface{}{
+			"network": "ID2",
+			"rules":   []interface{}{map[string]interface{}{"source": "${data.terraform_remote_state.subnetworks.outputs.type3_tfer--name-type3_id}"}},
+		})},
+		"networks":    {prepareNoAttrs("ID2", "type2")},
+		"subnetworks": {prepareNoAttrs("ID3", "type3")},
+	}
+	resourceConnections := map[string]map[string][]string{
+		"firewall": {"networks": {"network", "id"}},
+	}
+	importResources = ConnectServices(importResources, true, resourceConnections)
+	resources, inputs := ModuleInputs(importResources)
+
+	expected := map[string]interface{}{
+		"network": "${var.type2_tfer--name-type2_id}",
+		"rules":   []interface{}{map[string]interface{}{"source": "${var.type3_tfer--name-type3_id}"}},
+	}
+	if !reflect.DeepEqual(resources["firewall"][0].Item, expected) {
+		t.Errorf("unexpected item %v", resources["firewall"][0].Item)
+	}
+	expectedInputs := map[string]map[string]string{
+		"firewall": {
+			"type2_tfer--name-type2_id": "networks",
+			"type3_tfer--name-type3_id": "subnetworks",
+		},
+	}
+	if !reflect.DeepEqual(inputs, expectedInputs) {
+		t.Errorf("unexpected inputs %v", inputs)
+	}
+	order := ModuleOrder([]string{"firewall", "networks", "subnetworks"}, inputs)
+	if !reflect.DeepEqual(order, []string{"networks", "subnetworks", "firewall"}) {
+		t.Errorf("unexpected order %v", order)
+	}
+}
+
+func TestModuleOrderCycle(t *testing.T) {
+	inputs := map[string]map[string]string{
+		"a": {"x": "b"},
+		"b": {"y": "a"},
+		"c": {"z": "a"},
+	}
+	order := ModuleOrder([]string{"c", "b", "a", "d"}, inputs)
+	if !reflect.DeepEqual(order, []string{"d", "a", "b", "c"}) {
+		t.Errorf("unexpected order %v", order)
+	}
+}
+
+func TestModulesStateAndImportBlocks(t *testing.T) {
+	resourcesByModule := map[string][]Resource{
+		"networks": {prepare("net", "google_compute_network", map[string]string{"id": "net"}, map[string]interface{}{})},
+		"firewall": {prepare("fw", "google_compute_firewall", map[string]string{"id": "fw"}, map[string]interface{}{})},
+	}
+	resourcesByModule["networks"][0].Outputs = nil
+
+	data, err := PrintModulesTfStateV4(resourcesByModule, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, err := ReadStateResources(data, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addresses := []string{}
+	for _, r := range state {
+		addresses = append(addresses, r.Address())
+	}
+	expected := []string{
+		"module.firewall.google_compute_firewall.tfer--name-google_compute_firewall",
+		"module.networks.google_compute_network.tfer--name-google_compute_network",
+	}
+	if !reflect.DeepEqual(addresses, expected) {
+		t.Errorf("unexpected state addresses %v", addresses)
+	}
+
+	imports, err := HclPrintModuleImportBlocks(resourcesByModule, "hcl", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedImports := `import {
+  to = module.firewall.google_compute_firewall.tfer--name-google_compute_firewall
+  id = "fw"
+}
+
+import {
+  to = module.networks.google_compute_network.tfer--name-google_compute_network
+  id = "net"
+}
+`
+	if string(imports) != expectedImports {
+		t.Errorf("unexpected import blocks:\n%s", string(imports))
+	}
+}
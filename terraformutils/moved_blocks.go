@@ -0,0 +1,65 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+// Move is a resource address change, written as a Terraform 1.1+ moved block
+type Move struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// HclPrintMovedBlocks prints one moved block per move, in the given order
+func HclPrintMovedBlocks(moves []Move, output string) ([]byte, error) {
+	switch output {
+	case "hcl":
+		return hclPrintMovedBlocks(moves)
+	case "json":
+		return jsonPrint(map[string]interface{}{
+			"moved": moves,
+		})
+	}
+	return []byte{}, errors.New("error: unknown output format")
+}
+
+func hclPrintMovedBlocks(moves []Move) ([]byte, error) {
+	f := hclwrite.NewEmptyFile()
+	body := f.Body()
+	for i, m := range moves {
+		from, diags := hclsyntax.ParseTraversalAbs([]byte(m.From), "", hcl.Pos{Line: 1, Column: 1})
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("invalid address %s: %s", m.From, diags.Error())
+		}
+		to, diags := hclsyntax.ParseTraversalAbs([]byte(m.To), "", hcl.Pos{Line: 1, Column: 1})
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("invalid address %s: %s", m.To, diags.Error())
+		}
+		if i > 0 {
+			body.AppendNewline()
+		}
+		block := body.AppendNewBlock("moved", nil).Body()
+		block.SetAttributeTraversal("from", from)
+		block.SetAttributeTraversal("to", to)
+	}
+	return hclwrite.Format(f.Bytes()), nil
+}
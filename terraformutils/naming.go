@@ -0,0 +1,224 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+const (
+	// NamingLegacy keeps the tfer-- labels of TfSanitize
+	NamingLegacy = "legacy"
+	// NamingSnake writes lowercase snake_case labels
+	NamingSnake = "snake"
+	// NamingTemplate renders labels with a Go template
+	NamingTemplate = "template"
+)
+
+// NamingStrategy returns the label of a resource, collisions are resolved by RenameResources
+type NamingStrategy interface {
+	Name(r Resource) (string, error)
+}
+
+// NewNamingStrategy returns the strategy called naming, text is the template of NamingTemplate
+func NewNamingStrategy(naming, text string) (NamingStrategy, error) {
+	switch naming {
+	case NamingLegacy, "":
+		return legacyNaming{}, nil
+	case NamingSnake:
+		return snakeNaming{}, nil
+	case NamingTemplate:
+		if text == "" {
+			return nil, fmt.Errorf("--naming=%s requires --naming-template", NamingTemplate)
+		}
+		t, err := template.New("naming").Option("missingkey=zero").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid naming template: %v", err)
+		}
+		return templateNaming{template: t}, nil
+	}
+	return nil, fmt.Errorf("unsupported naming strategy: %s", naming)
+}
+
+type legacyNaming struct{}
+
+func (legacyNaming) Name(r Resource) (string, error) {
+	return r.ResourceName, nil
+}
+
+type snakeNaming struct{}
+
+func (snakeNaming) Name(r Resource) (string, error) {
+	return snakeCase(r, generatorName(r.ResourceName)), nil
+}
+
+type templateNaming struct {
+	template *template.Template
+}
+
+// NamingData is the data of a naming template
+type NamingData struct {
+	// Type is the resource type, e.g. google_compute_network
+	Type string
+	// ID is the import ID
+	ID string
+	// Name is the name given by the generator
+	Name     string
+	resource Resource
+}
+
+// Attr returns a refreshed attribute in flatmap notation, e.g. labels.env, or an empty string
+func (d NamingData) Attr(key string) string {
+	if d.resource.InstanceState != nil {
+		if value, ok := d.resource.InstanceState.Attributes[key]; ok {
+			return value
+		}
+	}
+	if value, ok := d.resource.Item[key].(string); ok {
+		return value
+	}
+	return ""
+}
+
+func (n templateNaming) Name(r Resource) (string, error) {
+	data := NamingData{Type: r.InstanceInfo.Type, Name: generatorName(r.ResourceName), resource: r}
+	if r.InstanceState != nil {
+		data.ID = r.InstanceState.ID
+	}
+	var b strings.Builder
+	if err := n.template.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("failed to name %s: %v", r.ImportAddress(), err)
+	}
+	return identifier(r, b.String()), nil
+}
+
+var escapedASCII = regexp.MustCompile(`-00([0-7][0-9A-F])-`)
+
+// generatorName reverts TfSanitize for the ASCII characters it escaped
+func generatorName(label string) string {
+	return escapedASCII.ReplaceAllStringFunc(strings.TrimPrefix(label, "tfer--"), func(match string) string {
+		b, err := strconv.ParseUint(match[3:5], 16, 8)
+		if err != nil {
+			return match
+		}
+		return string(rune(b))
+	})
+}
+
+var nonSnake = regexp.MustCompile(`[^a-z0-9]+`)
+var nonIdentifier = regexp.MustCompile(`[^A-Za-z0-9_\-]+`)
+
+func snakeCase(r Resource, name string) string {
+	return identifier(r, strings.Trim(nonSnake.ReplaceAllString(strings.ToLower(name), "_"), "_"))
+}
+
+// identifier replaces characters invalid in labels and prefixes labels which are empty or start with a digit
+// with the last word of the resource type
+func identifier(r Resource, name string) string {
+	name = strings.Trim(nonIdentifier.ReplaceAllString(name, "_"), "_")
+	if name == "" {
+		return typeNoun(r)
+	}
+	if name[0] >= '0' && name[0] <= '9' || name[0] == '-' {
+		return typeNoun(r) + "_" + name
+	}
+	return name
+}
+
+// RenameResources labels resources with the naming strategy. Resources of a type with the same label get the
+// suffixes _2, _3, ... in the order of their import IDs, the same resource listed twice is kept once.
+func RenameResources(importResources map[string][]Resource, strategy NamingStrategy) (map[string][]Resource, error) {
+	services := make([]string, 0, len(importResources))
+	for service := range importResources {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+
+	type candidate struct {
+		service string
+		index   int
+		name    string
+		id      string
+	}
+	candidatesByType := map[string][]candidate{}
+	for _, service := range services {
+		for i, r := range importResources[service] {
+			name, err := strategy.Name(r)
+			if err != nil {
+				return nil, err
+			}
+			id := ""
+			if r.InstanceState != nil {
+				id = r.InstanceState.ID
+			}
+			candidatesByType[r.InstanceInfo.Type] = append(candidatesByType[r.InstanceInfo.Type], candidate{service: service, index: i, name: name, id: id})
+		}
+	}
+
+	duplicates := map[string]map[int]bool{}
+	for resourceType, candidates := range candidatesByType {
+		sort.SliceStable(candidates, func(i, j int) bool {
+			if candidates[i].name != candidates[j].name {
+				return candidates[i].name < candidates[j].name
+			}
+			return candidates[i].id < candidates[j].id
+		})
+		used := map[string]bool{}
+		for _, c := range candidates {
+			used[c.name] = true
+		}
+		for i, c := range candidates {
+			name := c.name
+			if i > 0 && candidates[i-1].name == c.name {
+				if c.id != "" && c.id == candidates[i-1].id {
+					log.Printf("skipping %s.%s, resource %s is listed twice", resourceType, c.name, c.id)
+					if duplicates[c.service] == nil {
+						duplicates[c.service] = map[int]bool{}
+					}
+					duplicates[c.service][c.index] = true
+					continue
+				}
+				for n := 2; used[name]; n++ {
+					name = c.name + "_" + strconv.Itoa(n)
+				}
+				used[name] = true
+				log.Printf("renaming %s.%s with ID %s to %s, the name is already used", resourceType, c.name, c.id, name)
+			}
+			r := &importResources[c.service][c.index]
+			// generators may share the instance info between resources
+			info := *r.InstanceInfo
+			info.Id = fmt.Sprintf("%s.%s", resourceType, name)
+			r.InstanceInfo = &info
+			r.ResourceName = name
+		}
+	}
+
+	for service, indexes := range duplicates {
+		resources := []Resource{}
+		for i, r := range importResources[service] {
+			if !indexes[i] {
+				resources = append(resources, r)
+			}
+		}
+		importResources[service] = resources
+	}
+	return importResources, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func namingTestResources() map[string][]Resource {
+	bucket := func(id, name, location string) Resource {
+		return NewResource(id, name, "google_storage_bucket", "google", map[string]string{
+			"name":     name,
+			"location": location,
+		}, []string{}, map[string]interface{}{})
+	}
+	return map[string][]Resource{
+		"gcs": {
+			bucket("my.Bucket", "my.Bucket", "EU"),
+			bucket("my-bucket", "my-bucket", "US"),
+			bucket("9lives", "9lives", "EU"),
+			bucket("9lives", "9lives", "EU"),
+		},
+	}
+}
+
+func resourceNames(resources []Resource) []string {
+	names := []string{}
+	for _, r := range resources {
+		names = append(names, r.ResourceName)
+	}
+	return names
+}
+
+func TestRenameResources(t *testing.T) {
+	for _, test := range []struct {
+		naming   string
+		template string
+		expected []string
+	}{
+		{NamingLegacy, "", []string{"tfer--my-002E-Bucket", "tfer--my-bucket", "tfer--9lives"}},
+		{NamingSnake, "", []string{"my_bucket_2", "my_bucket", "bucket_9lives"}},
+		{NamingTemplate, `{{.Attr "location"}}-{{.Name}}`, []string{"EU-my_Bucket", "US-my-bucket", "EU-9lives"}},
+		{NamingTemplate, `{{.Attr "location"}}`, []string{"EU_2", "US", "EU"}},
+	} {
+		strategy, err := NewNamingStrategy(test.naming, test.template)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resources, err := RenameResources(namingTestResources(), strategy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if names := resourceNames(resources["gcs"]); !reflect.DeepEqual(names, test.expected) {
+			t.Errorf("%s %s: expected %v, got %v", test.naming, test.template, test.expected, names)
+		}
+		for _, r := range resources["gcs"] {
+			if r.InstanceInfo.Id != r.ImportAddress() {
+				t.Errorf("unexpected instance info %s for %s", r.InstanceInfo.Id, r.ImportAddress())
+			}
+		}
+		if _, err := HclPrintResource(resources["gcs"], map[string]interface{}{}, "hcl", true); err != nil {
+			t.Errorf("%s: %v", test.naming, err)
+		}
+	}
+}
+
+func TestNewNamingStrategyErrors(t *testing.T) {
+	for _, args := range [][]string{{"camel", ""}, {NamingTemplate, ""}, {NamingTemplate, "{{.Attr"}} {
+		if _, err := NewNamingStrategy(args[0], args[1]); err == nil {
+			t.Errorf("expected an error for %v", args)
+		}
+	}
+}
+
+func TestHclPrintResourceDuplicates(t *testing.T) {
+	resources := []Resource{
+		prepare("ID1", "type1", map[string]string{}, map[string]interface{}{}),
+		prepare("ID2", "type1", map[string]string{}, map[string]interface{}{}),
+	}
+	if _, err := HclPrintResource(resources, map[string]interface{}{}, "hcl", true); err == nil {
+		t.Errorf("expected an error for a duplicate address")
+	}
+}
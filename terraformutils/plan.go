@@ -0,0 +1,449 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// PlanFormatVersion is the version of the plan file format written by terraformer plan
+const PlanFormatVersion = 2
+
+// PlanFile is the format of plan.json. Options are the import options of the command which wrote the plan.
+//
+// Version 1 plan files, written before the format was versioned, encoded Resource as is under ImportedResource
+// and carried the terraformer version in Version. They are migrated when read.
+type PlanFile struct {
+	FormatVersion      int
+	TerraformerVersion string
+	Provider           string
+	Args               []string
+	Options            json.RawMessage
+	Services           map[string][]PlanResource
+}
+
+// PlanResource is a resource of a plan file
+type PlanResource struct {
+	// Address is the resource address, e.g. google_storage_bucket.tfer--logs
+	Address    string
+	Type       string
+	Name       string
+	ID         string
+	Provider   string
+	Attributes map[string]string
+	// Meta is the meta data of the refreshed state, e.g. its schema_version
+	Meta                 map[string]interface{}            `json:",omitempty"`
+	Outputs              map[string]*terraform.OutputState `json:",omitempty"`
+	Item                 map[string]interface{}            `json:",omitempty"`
+	PreserveOrder        []string                          `json:",omitempty"`
+	IgnoreKeys           []string                          `json:",omitempty"`
+	AllowEmptyValues     []string                          `json:",omitempty"`
+	AdditionalFields     map[string]interface{}            `json:",omitempty"`
+	SlowQueryRequired    bool                              `json:",omitempty"`
+	DataFiles            map[string][]byte                 `json:",omitempty"`
+	ReferencedAttributes []string                          `json:",omitempty"`
+}
+
+// planMigrations migrate a plan file of a version to the next version
+var planMigrations = map[int]func(map[string]json.RawMessage) (map[string]json.RawMessage, error){
+	1: migratePlanV1,
+}
+
+// NewPlanResources returns the plan resources of resources by service
+func NewPlanResources(resourcesByService map[string][]Resource) map[string][]PlanResource {
+	services := map[string][]PlanResource{}
+	for service, resources := range resourcesByService {
+		services[service] = []PlanResource{}
+		for _, r := range resources {
+			services[service] = append(services[service], NewPlanResource(r))
+		}
+	}
+	return services
+}
+
+func NewPlanResource(r Resource) PlanResource {
+	p := PlanResource{
+		Name:                 r.ResourceName,
+		Provider:             r.Provider,
+		Outputs:              r.Outputs,
+		Item:                 r.Item,
+		PreserveOrder:        r.PreserveOrder,
+		IgnoreKeys:           r.IgnoreKeys,
+		AllowEmptyValues:     r.AllowEmptyValues,
+		AdditionalFields:     r.AdditionalFields,
+		SlowQueryRequired:    r.SlowQueryRequired,
+		DataFiles:            r.DataFiles,
+		ReferencedAttributes: r.ReferencedAttributes,
+	}
+	if r.InstanceInfo != nil {
+		p.Address = r.InstanceInfo.Id
+		p.Type = r.InstanceInfo.Type
+	}
+	if r.InstanceState != nil {
+		p.ID = r.InstanceState.ID
+		p.Attributes = r.InstanceState.Attributes
+		p.Meta = r.InstanceState.Meta
+	}
+	return p
+}
+
+// Resource returns the resource of a plan resource
+func (p PlanResource) Resource() Resource {
+	attributes := p.Attributes
+	if attributes == nil {
+		attributes = map[string]string{}
+	}
+	return Resource{
+		InstanceInfo:         &terraform.InstanceInfo{Id: p.Address, Type: p.Type},
+		InstanceState:        &terraform.InstanceState{ID: p.ID, Attributes: attributes, Meta: p.Meta},
+		Outputs:              p.Outputs,
+		ResourceName:         p.Name,
+		Provider:             p.Provider,
+		Item:                 p.Item,
+		PreserveOrder:        p.PreserveOrder,
+		IgnoreKeys:           p.IgnoreKeys,
+		AllowEmptyValues:     p.AllowEmptyValues,
+		AdditionalFields:     p.AdditionalFields,
+		SlowQueryRequired:    p.SlowQueryRequired,
+		DataFiles:            p.DataFiles,
+		ReferencedAttributes: p.ReferencedAttributes,
+	}
+}
+
+// Resources returns the resources of the plan by service
+func (p *PlanFile) Resources() map[string][]Resource {
+	resources := map[string][]Resource{}
+	for service, planResources := range p.Services {
+		resources[service] = []Resource{}
+		for _, r := range planResources {
+			resources[service] = append(resources[service], r.Resource())
+		}
+	}
+	return resources
+}
+
+// ReadPlanFile reads a plan file, migrating files of previous format versions
+func ReadPlanFile(r io.Reader) (*PlanFile, error) {
+	raw := map[string]json.RawMessage{}
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, err
+	}
+	formatVersion := 1
+	if data, ok := raw["FormatVersion"]; ok {
+		if err := json.Unmarshal(data, &formatVersion); err != nil {
+			return nil, fmt.Errorf("invalid plan format version: %w", err)
+		}
+	}
+	if formatVersion > PlanFormatVersion {
+		return nil, fmt.Errorf("plan format version %d is newer than the supported version %d, upgrade terraformer", formatVersion, PlanFormatVersion)
+	}
+	for ; formatVersion < PlanFormatVersion; formatVersion++ {
+		migrate, ok := planMigrations[formatVersion]
+		if !ok {
+			return nil, fmt.Errorf("plan format version %d is not supported", formatVersion)
+		}
+		var err error
+		if raw, err = migrate(raw); err != nil {
+			return nil, fmt.Errorf("failed to migrate plan from format version %d: %w", formatVersion, err)
+		}
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	plan := &PlanFile{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(plan); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// migratePlanV1 converts the encoded resources of a version 1 plan
+func migratePlanV1(raw map[string]json.RawMessage) (map[string]json.RawMessage, error) {
+	importedResource := map[string][]Resource{}
+	if data, ok := raw["ImportedResource"]; ok {
+		if err := json.Unmarshal(data, &importedResource); err != nil {
+			return nil, err
+		}
+	}
+	services, err := json.Marshal(NewPlanResources(importedResource))
+	if err != nil {
+		return nil, err
+	}
+	migrated := map[string]json.RawMessage{
+		"FormatVersion": json.RawMessage("2"),
+		"Services":      services,
+	}
+	for _, key := range []string{"Provider", "Args", "Options"} {
+		if data, ok := raw[key]; ok {
+			migrated[key] = data
+		}
+	}
+	if data, ok := raw["Version"]; ok {
+		migrated["TerraformerVersion"] = data
+	}
+	return migrated, nil
+}
+
+// WritePlanFile writes a plan file with the current format version
+func WritePlanFile(w io.Writer, plan *PlanFile) error {
+	plan.FormatVersion = PlanFormatVersion
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "\t")
+	return encoder.Encode(plan)
+}
+
+// PlanSelector selects resources of a plan by service and type, e.g. type=google_storage_bucket,service=gcs.
+// Values of a key are alternatives, keys must all match. Values may contain * and ? wildcards.
+type PlanSelector map[string][]string
+
+// ParsePlanSelector parses key=value selectors of the keys service, type and id
+func ParsePlanSelector(values []string) (PlanSelector, error) {
+	selector := PlanSelector{}
+	for _, value := range values {
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return nil, fmt.Errorf("invalid selector %q, expected service=, type= or id=", value)
+		}
+		switch parts[0] {
+		case "service", "type", "id":
+		default:
+			return nil, fmt.Errorf("invalid selector %q, expected service=, type= or id=", value)
+		}
+		if _, err := path.Match(parts[1], ""); err != nil {
+			return nil, fmt.Errorf("invalid selector %q: %w", value, err)
+		}
+		selector[parts[0]] = append(selector[parts[0]], parts[1])
+	}
+	return selector, nil
+}
+
+// Select returns the resources of the plan matched by the selector, services without resources are dropped
+func (s PlanSelector) Select(resourcesByService map[string][]Resource) map[string][]Resource {
+	if len(s) == 0 {
+		return resourcesByService
+	}
+	selected := map[string][]Resource{}
+	for service, resources := range resourcesByService {
+		for _, r := range resources {
+			if s.match("service", service) && s.match("type", r.InstanceInfo.Type) && s.match("id", r.InstanceState.ID) {
+				selected[service] = append(selected[service], r)
+			}
+		}
+	}
+	return selected
+}
+
+func (s PlanSelector) match(key, value string) bool {
+	patterns, ok := s[key]
+	if !ok {
+		return true
+	}
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, value); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// PrintPlanSummary prints the number of resources of a plan by service and type, and every resource when verbose
+func PrintPlanSummary(w io.Writer, plan *PlanFile, verbose bool) {
+	fmt.Fprintf(w, "Plan of %s", strings.TrimSpace(plan.Provider+" "+strings.Join(plan.Args, " ")))
+	if plan.TerraformerVersion != "" {
+		fmt.Fprintf(w, ", written by terraformer %s", plan.TerraformerVersion)
+	}
+	fmt.Fprintln(w)
+	total, services := 0, planKeys(plan.Services)
+	for _, service := range services {
+		resources := plan.Services[service]
+		total += len(resources)
+		fmt.Fprintf(w, "\n%s: %d resources\n", service, len(resources))
+		byType := map[string][]PlanResource{}
+		for _, r := range resources {
+			byType[r.Type] = append(byType[r.Type], r)
+		}
+		for _, resourceType := range planKeys(byType) {
+			fmt.Fprintf(w, "  %-50s %d\n", resourceType, len(byType[resourceType]))
+			if verbose {
+				for _, r := range sortPlanResources(byType[resourceType]) {
+					fmt.Fprintf(w, "    %s (%s)\n", r.Address, r.ID)
+				}
+			}
+		}
+	}
+	fmt.Fprintf(w, "\n%d resources in %d services\n", total, len(services))
+}
+
+// PlanDiff is the difference between two plans
+type PlanDiff struct {
+	Added   []PlanResourceDiff
+	Removed []PlanResourceDiff
+	Changed []PlanResourceDiff
+}
+
+// PlanResourceDiff is a resource added, removed or changed between two plans
+type PlanResourceDiff struct {
+	Service string
+	Type    string
+	ID      string
+	Address string
+	// Attributes are the changed attributes of a changed resource, with their old and new values
+	Attributes map[string][2]*string `json:",omitempty"`
+}
+
+// DiffPlans compares two plans, resources are matched by service, type and ID
+func DiffPlans(a, b *PlanFile) PlanDiff {
+	diff := PlanDiff{}
+	before := planResourcesByKey(a)
+	after := planResourcesByKey(b)
+	for _, key := range planKeys(before) {
+		old := before[key]
+		r, ok := after[key]
+		if !ok {
+			diff.Removed = append(diff.Removed, PlanResourceDiff{Service: old.service, Type: old.Type, ID: old.ID, Address: old.Address})
+			continue
+		}
+		changes := map[string][2]*string{}
+		for _, k := range planKeys(mergeKeys(old.Attributes, r.Attributes)) {
+			oldValue, oldOK := old.Attributes[k]
+			newValue, newOK := r.Attributes[k]
+			if oldOK != newOK || oldValue != newValue {
+				change := [2]*string{}
+				if oldOK {
+					change[0] = &oldValue
+				}
+				if newOK {
+					change[1] = &newValue
+				}
+				changes[k] = change
+			}
+		}
+		if len(changes) > 0 || old.Address != r.Address {
+			diff.Changed = append(diff.Changed, PlanResourceDiff{Service: r.service, Type: r.Type, ID: r.ID, Address: r.Address, Attributes: changes})
+		}
+	}
+	for _, key := range planKeys(after) {
+		if _, ok := before[key]; !ok {
+			r := after[key]
+			diff.Added = append(diff.Added, PlanResourceDiff{Service: r.service, Type: r.Type, ID: r.ID, Address: r.Address})
+		}
+	}
+	return diff
+}
+
+// Empty reports whether the plans are the same
+func (d PlanDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Print prints the diff like terraform plan, + added, - removed and ~ changed resources
+func (d PlanDiff) Print(w io.Writer) {
+	lines := []planDiffLine{}
+	for _, r := range d.Added {
+		lines = append(lines, planDiffLine{"+", r})
+	}
+	for _, r := range d.Removed {
+		lines = append(lines, planDiffLine{"-", r})
+	}
+	for _, r := range d.Changed {
+		lines = append(lines, planDiffLine{"~", r})
+	}
+	sort.Slice(lines, func(i, j int) bool {
+		if lines[i].diff.Service != lines[j].diff.Service {
+			return lines[i].diff.Service < lines[j].diff.Service
+		}
+		if lines[i].diff.Address != lines[j].diff.Address {
+			return lines[i].diff.Address < lines[j].diff.Address
+		}
+		return lines[i].diff.ID < lines[j].diff.ID
+	})
+	service := ""
+	for i, line := range lines {
+		if i == 0 || line.diff.Service != service {
+			service = line.diff.Service
+			fmt.Fprintf(w, "%s:\n", service)
+		}
+		fmt.Fprintf(w, "  %s %s (%s)\n", line.sign, line.diff.Address, line.diff.ID)
+		for _, k := range planKeys(line.diff.Attributes) {
+			change := line.diff.Attributes[k]
+			fmt.Fprintf(w, "      %s: %s => %s\n", k, quotedOrNull(change[0]), quotedOrNull(change[1]))
+		}
+	}
+	fmt.Fprintf(w, "Plan diff: %d to add, %d to remove, %d to change\n", len(d.Added), len(d.Removed), len(d.Changed))
+}
+
+type planDiffLine struct {
+	sign string
+	diff PlanResourceDiff
+}
+
+type keyedPlanResource struct {
+	PlanResource
+	service string
+}
+
+func planResourcesByKey(plan *PlanFile) map[string]keyedPlanResource {
+	resources := map[string]keyedPlanResource{}
+	for service, planResources := range plan.Services {
+		for _, r := range planResources {
+			resources[service+"\x00"+r.Type+"\x00"+r.ID] = keyedPlanResource{PlanResource: r, service: service}
+		}
+	}
+	return resources
+}
+
+func sortPlanResources(resources []PlanResource) []PlanResource {
+	sorted := append([]PlanResource{}, resources...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Address < sorted[j].Address })
+	return sorted
+}
+
+func mergeKeys(a, b map[string]string) map[string]bool {
+	keys := map[string]bool{}
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+	return keys
+}
+
+func planKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func quotedOrNull(value *string) string {
+	if value == nil {
+		return "null"
+	}
+	return fmt.Sprintf("%q", *value)
+}
@@ -0,0 +1,185 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func planTestResources() map[string][]Resource {
+	bucket := prepare("logs", "google_storage_bucket", map[string]string{"name": "logs", "location": "EU"}, nil)
+	bucket.InstanceState.Meta = map[string]interface{}{"schema_version": float64(1)}
+	bucket.DataFiles = map[string][]byte{"policy.json": []byte("{}")}
+	bucket.IgnoreKeys = []string{"^self_link$"}
+	return map[string][]Resource{
+		"gcs":      {bucket},
+		"networks": {prepare("default", "google_compute_network", map[string]string{"name": "default"}, nil)},
+	}
+}
+
+func TestPlanFileRoundTrip(t *testing.T) {
+	resources := planTestResources()
+	var b bytes.Buffer
+	err := WritePlanFile(&b, &PlanFile{
+		TerraformerVersion: "v0.8.30",
+		Provider:           "google",
+		Args:               []string{"global", "my-project", ""},
+		Options:            json.RawMessage(`{"Resources":["gcs","networks"]}`),
+		Services:           NewPlanResources(resources),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := ReadPlanFile(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.FormatVersion != PlanFormatVersion || plan.TerraformerVersion != "v0.8.30" || plan.Provider != "google" {
+		t.Errorf("unexpected plan %v", plan)
+	}
+	assertPlanResources(t, plan, resources)
+}
+
+// assertPlanResources compares resources as written to a plan, nil and empty lists and maps are the same
+func assertPlanResources(t *testing.T, plan *PlanFile, resources map[string][]Resource) {
+	t.Helper()
+	expected, _ := json.Marshal(NewPlanResources(resources))
+	actual, _ := json.Marshal(NewPlanResources(plan.Resources()))
+	if string(actual) != string(expected) {
+		t.Errorf("expected the resources of the plan\n%s\ngot\n%s", expected, actual)
+	}
+	bucket := plan.Resources()["gcs"][0]
+	if bucket.InstanceState.Meta["schema_version"] != float64(1) || string(bucket.DataFiles["policy.json"]) != "{}" ||
+		bucket.InstanceInfo.Id != "google_storage_bucket.tfer--name-google_storage_bucket" {
+		t.Errorf("unexpected bucket %v", bucket)
+	}
+}
+
+func TestReadPlanFileMigratesVersion1(t *testing.T) {
+	resources := planTestResources()
+	data, err := json.Marshal(map[string]interface{}{
+		"Version":          "v0.8.24",
+		"Provider":         "google",
+		"Options":          map[string]interface{}{"Resources": []string{"gcs", "networks"}, "Compact": true},
+		"Args":             []string{"global", "my-project", ""},
+		"ImportedResource": resources,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := ReadPlanFile(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.FormatVersion != PlanFormatVersion || plan.TerraformerVersion != "v0.8.24" {
+		t.Errorf("unexpected versions %d %s", plan.FormatVersion, plan.TerraformerVersion)
+	}
+	if !strings.Contains(string(plan.Options), `"Compact":true`) {
+		t.Errorf("expected the options of the plan, got %s", plan.Options)
+	}
+	assertPlanResources(t, plan, resources)
+}
+
+func TestReadPlanFileNewerVersion(t *testing.T) {
+	_, err := ReadPlanFile(strings.NewReader(`{"FormatVersion": 3, "Services": {}}`))
+	if err == nil || !strings.Contains(err.Error(), "upgrade terraformer") {
+		t.Errorf("expected an error, got %v", err)
+	}
+}
+
+func TestPlanSelector(t *testing.T) {
+	resources := planTestResources()
+	resources["gcs"] = append(resources["gcs"], prepare("prod-data", "google_storage_bucket", map[string]string{}, nil))
+	for _, test := range []struct {
+		only     []string
+		expected map[string]int
+	}{
+		{[]string{}, map[string]int{"gcs": 2, "networks": 1}},
+		{[]string{"service=gcs"}, map[string]int{"gcs": 2}},
+		{[]string{"type=google_compute_network", "type=google_storage_bucket"}, map[string]int{"gcs": 2, "networks": 1}},
+		{[]string{"type=google_storage_*", "id=prod-*"}, map[string]int{"gcs": 1}},
+		{[]string{"service=networks", "type=google_storage_bucket"}, map[string]int{}},
+	} {
+		selector, err := ParsePlanSelector(test.only)
+		if err != nil {
+			t.Fatal(err)
+		}
+		counts := map[string]int{}
+		for service, selected := range selector.Select(resources) {
+			counts[service] = len(selected)
+		}
+		if !reflect.DeepEqual(counts, test.expected) {
+			t.Errorf("%v: expected %v, got %v", test.only, test.expected, counts)
+		}
+	}
+	for _, invalid := range []string{"name=logs", "type", "type=", "id=[a"} {
+		if _, err := ParsePlanSelector([]string{invalid}); err == nil {
+			t.Errorf("expected an error for %s", invalid)
+		}
+	}
+}
+
+func TestDiffPlans(t *testing.T) {
+	a := &PlanFile{Services: NewPlanResources(planTestResources())}
+	resources := planTestResources()
+	resources["gcs"][0].InstanceState.Attributes["location"] = "US"
+	resources["gcs"][0].InstanceState.Attributes["versioning.#"] = "1"
+	resources["networks"] = []Resource{prepare("vpc", "google_compute_network", map[string]string{"name": "vpc"}, nil)}
+	b := &PlanFile{Services: NewPlanResources(resources)}
+
+	diff := DiffPlans(a, b)
+	var out bytes.Buffer
+	diff.Print(&out)
+	expected := `gcs:
+  ~ google_storage_bucket.tfer--name-google_storage_bucket (logs)
+      location: "EU" => "US"
+      versioning.#: null => "1"
+networks:
+  - google_compute_network.tfer--name-google_compute_network (default)
+  + google_compute_network.tfer--name-google_compute_network (vpc)
+Plan diff: 1 to add, 1 to remove, 1 to change
+`
+	if out.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, out.String())
+	}
+	if !DiffPlans(a, a).Empty() {
+		t.Errorf("expected no diff between the same plans")
+	}
+}
+
+func TestPrintPlanSummary(t *testing.T) {
+	plan := &PlanFile{
+		TerraformerVersion: "v0.8.30",
+		Provider:           "google",
+		Args:               []string{"global", "my-project"},
+		Services:           NewPlanResources(planTestResources()),
+	}
+	var out bytes.Buffer
+	PrintPlanSummary(&out, plan, true)
+	for _, expected := range []string{
+		"Plan of google global my-project, written by terraformer v0.8.30",
+		"gcs: 1 resources",
+		"google_storage_bucket.tfer--name-google_storage_bucket (logs)",
+		"2 resources in 2 services",
+	} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("expected %q in\n%s", expected, out.String())
+		}
+	}
+}
@@ -34,7 +34,11 @@ func NewProvidersMapping(baseProvider ProviderGenerator) *ProvidersMapping {
 }
 
 func deepCopyProvider(provider ProviderGenerator) ProviderGenerator {
-	return reflect.New(reflect.ValueOf(provider).Elem().Type()).Interface().(ProviderGenerator)
+	newProvider := reflect.New(reflect.ValueOf(provider).Elem().Type()).Interface().(ProviderGenerator)
+	if providerWithAPIMiddleware, ok := provider.(ProviderWithAPIMiddleware); ok {
+		newProvider.(ProviderWithAPIMiddleware).SetAPIMiddleware(providerWithAPIMiddleware.APIMiddleware())
+	}
+	return newProvider
 }
 
 func (p *ProvidersMapping) GetBaseProvider() ProviderGenerator {
@@ -146,9 +150,11 @@ func (p *ProvidersMapping) GetResourcesByService() map[string][]Resource {
 	return mapping
 }
 
-func (p *ProvidersMapping) ConvertTFStates(providerWrapper *providerwrapper.ProviderWrapper) {
+// ConvertTFStates converts the refreshed states to attributes, report records the outcomes and may be nil
+func (p *ProvidersMapping) ConvertTFStates(providerWrapper *providerwrapper.ProviderWrapper, report *ScopeReport) {
 	for resource := range p.Resources {
 		err := resource.ConvertTFstate(providerWrapper)
+		report.Converted(resource, err)
 		if err != nil {
 			log.Printf("failed to convert resources %s because of error %s", resource.InstanceInfo.Id, err)
 		}
@@ -169,10 +175,23 @@ func (p *ProvidersMapping) ConvertTFStates(providerWrapper *providerwrapper.Prov
 
 }
 
-func (p *ProvidersMapping) CleanupProviders() {
+// FilterResources drops the refreshed resources of every service not selected by the expressions
+func (p *ProvidersMapping) FilterResources(expressions []*FilterExpression) {
+	if len(expressions) == 0 {
+		return
+	}
+	for provider := range p.Providers {
+		service := provider.GetService()
+		service.SetResources(FilterResources(expressions, p.providerToService[provider], service.GetResources(), true))
+	}
+}
+
+// CleanupProviders runs the cleanup and PostConvertHook of every service, report records the outcomes and may be nil
+func (p *ProvidersMapping) CleanupProviders(report *ScopeReport) {
 	for provider := range p.Providers {
 		provider.GetService().PostRefreshCleanup()
 		err := provider.GetService().PostConvertHook()
+		report.PostConverted(p.providerToService[provider], err)
 		if err != nil {
 			log.Printf("failed run PostConvertHook because of error %s", err)
 		}
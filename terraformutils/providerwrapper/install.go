@@ -0,0 +1,566 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package providerwrapper //nolint
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-version"
+)
+
+// DefaultRegistryHost is the hostname of provider sources without one, e.g. hashicorp/google
+const DefaultRegistryHost = "registry.terraform.io"
+
+// ProviderSource is the address of a provider, e.g. registry.terraform.io/hashicorp/google
+type ProviderSource struct {
+	Hostname  string
+	Namespace string
+	Type      string
+}
+
+// ParseProviderSource parses a required_providers source, the hostname defaults to registry.terraform.io and
+// the namespace to hashicorp
+func ParseProviderSource(source string) (ProviderSource, error) {
+	parts := strings.Split(strings.ToLower(source), "/")
+	for _, part := range parts {
+		if part == "" {
+			return ProviderSource{}, fmt.Errorf("invalid provider source %q", source)
+		}
+	}
+	switch len(parts) {
+	case 1:
+		return ProviderSource{Hostname: DefaultRegistryHost, Namespace: "hashicorp", Type: parts[0]}, nil
+	case 2:
+		return ProviderSource{Hostname: DefaultRegistryHost, Namespace: parts[0], Type: parts[1]}, nil
+	case 3:
+		return ProviderSource{Hostname: parts[0], Namespace: parts[1], Type: parts[2]}, nil
+	}
+	return ProviderSource{}, fmt.Errorf("invalid provider source %q", source)
+}
+
+func (s ProviderSource) String() string {
+	return s.Hostname + "/" + s.Namespace + "/" + s.Type
+}
+
+// InstallOptions selects the provider plugin of InstallPlugin
+type InstallOptions struct {
+	// Source is the required_providers source of the provider, hashicorp/<provider name> when empty
+	Source string
+	// Constraint is a version constraint, e.g. ~> 5.0
+	Constraint string
+	// Lock pins the version and the checksums of the provider, if set
+	Lock *LockFile
+	// Mirrors are filesystem mirror directories and network mirror URLs, see Terraform's provider installation
+	Mirrors []string
+}
+
+// Plugin is an installed provider plugin
+type Plugin struct {
+	Source  ProviderSource
+	Version *version.Version
+	// Constraint is the version of the provider in required_providers
+	Constraint string
+	// Path is the plugin executable
+	Path string
+	// Hashes are the checksums of the lock file, the locked ones and the ones of the installed package
+	Hashes []string
+}
+
+// LockFile returns the lock file of the plugin
+func (p *Plugin) LockFile() *LockFile {
+	return &LockFile{Providers: []*LockedProvider{{
+		Address:     p.Source.String(),
+		Version:     p.Version.String(),
+		Constraints: p.Constraint,
+		Hashes:      p.Hashes,
+	}}}
+}
+
+type installedPlugin struct {
+	key    string
+	plugin *Plugin
+}
+
+var (
+	installMu sync.Mutex
+	// installed are the plugins of InstallPlugin by provider name
+	installed = map[string]installedPlugin{}
+)
+
+// InstalledPlugin returns the plugin InstallPlugin installed for a provider name, nil if none was
+func InstalledPlugin(providerName string) *Plugin {
+	installMu.Lock()
+	defer installMu.Unlock()
+	return installed[providerName].plugin
+}
+
+// InstallPlugin installs the newest version of a provider plugin allowed by the constraint and the lock file. The
+// packages are looked up in the plugin directory of terraform init, see DefaultDataDir, in the mirrors and in
+// ~/.terraform.d/plugins, all in the layout of Terraform's provider installation. Packed packages are unpacked to
+// the plugin directory of terraform init. A package which doesn't match the checksums of the lock file is an
+// error. NewProviderWrapper and GetProviderVersion use the plugin of the provider name from then on.
+func InstallPlugin(ctx context.Context, providerName string, options InstallOptions) (*Plugin, error) {
+	source, err := ParseProviderSource(options.Source)
+	if options.Source == "" {
+		source, err = ParseProviderSource(providerName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var constraints version.Constraints
+	if options.Constraint != "" {
+		if constraints, err = version.NewConstraint(options.Constraint); err != nil {
+			return nil, fmt.Errorf("invalid provider version %q: %w", options.Constraint, err)
+		}
+	}
+	locked := options.Lock.Provider(source)
+	key := fmt.Sprintf("%s %s %v %q", source, options.Constraint, locked, options.Mirrors)
+
+	installMu.Lock()
+	defer installMu.Unlock()
+	if previous, ok := installed[providerName]; ok && previous.key == key {
+		return previous.plugin, nil
+	}
+
+	mirrors := []string{filepath.Join(dataDir(), "providers"), filepath.Join(dataDir(), "plugins")}
+	mirrors = append(mirrors, options.Mirrors...)
+	if home, err := os.UserHomeDir(); err == nil {
+		mirrors = append(mirrors, filepath.Join(home, ".terraform.d", "plugins"))
+	}
+	var selected *pluginPackage
+	for _, mirror := range mirrors {
+		packages, err := mirrorPackages(ctx, mirror, source)
+		if err != nil {
+			return nil, err
+		}
+		for _, pkg := range packages {
+			if constraints != nil && !constraints.Check(pkg.version) {
+				continue
+			}
+			if locked != nil && pkg.version.String() != locked.Version {
+				continue
+			}
+			// the first mirror wins between equal versions, installed packages are not unpacked again
+			if selected == nil || pkg.version.GreaterThan(selected.version) {
+				selected = pkg
+			}
+		}
+	}
+	if selected == nil {
+		wanted := options.Constraint
+		if locked != nil {
+			wanted = locked.Version + " locked by the lock file"
+		}
+		return nil, fmt.Errorf("no version %s of %s for %s found in %s", wanted, source, pluginMachineName, strings.Join(mirrors, ", "))
+	}
+
+	plugin, err := installPackage(ctx, source, selected, locked)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case options.Constraint != "":
+		plugin.Constraint = options.Constraint
+	case locked != nil && locked.Constraints != "":
+		plugin.Constraint = locked.Constraints
+	default:
+		plugin.Constraint = "~> " + plugin.Version.String()
+	}
+	installed[providerName] = installedPlugin{key: key, plugin: plugin}
+	return plugin, nil
+}
+
+// pluginPackage is a version of a provider in a mirror
+type pluginPackage struct {
+	version *version.Version
+	// dir is the directory of an unpacked package
+	dir string
+	// archive is the path or URL of a packed package
+	archive string
+	// versionURL is the URL of the version of a network mirror listing the archives and their checksums
+	versionURL string
+	hashes     []string
+}
+
+func dataDir() string {
+	if dir := os.Getenv("TF_DATA_DIR"); dir != "" {
+		return dir
+	}
+	return DefaultDataDir
+}
+
+// mirrorPackages returns the packages of a provider for this platform in a filesystem mirror directory or a
+// network mirror URL
+func mirrorPackages(ctx context.Context, mirror string, source ProviderSource) ([]*pluginPackage, error) {
+	if strings.HasPrefix(mirror, "https://") || strings.HasPrefix(mirror, "http://") {
+		return networkMirrorPackages(ctx, mirror, source)
+	}
+	dir := filepath.Join(mirror, source.Hostname, source.Namespace, source.Type)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	packages := []*pluginPackage{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			// unpacked layout, HOSTNAME/NAMESPACE/TYPE/VERSION/TARGET/
+			v, err := version.NewVersion(entry.Name())
+			if err != nil {
+				continue
+			}
+			packageDir := filepath.Join(dir, entry.Name(), pluginMachineName)
+			if info, err := os.Stat(packageDir); err == nil && info.IsDir() {
+				packages = append(packages, &pluginPackage{version: v, dir: packageDir})
+			}
+			continue
+		}
+		// packed layout, HOSTNAME/NAMESPACE/TYPE/terraform-provider-TYPE_VERSION_TARGET.zip
+		name := strings.TrimPrefix(entry.Name(), "terraform-provider-"+source.Type+"_")
+		if name == entry.Name() || !strings.HasSuffix(name, "_"+pluginMachineName+".zip") {
+			continue
+		}
+		v, err := version.NewVersion(strings.TrimSuffix(name, "_"+pluginMachineName+".zip"))
+		if err != nil {
+			continue
+		}
+		packages = append(packages, &pluginPackage{version: v, archive: filepath.Join(dir, entry.Name())})
+	}
+	return packages, nil
+}
+
+// networkMirrorPackages lists the versions of the index.json of a provider in a network mirror, their archives
+// are looked up in VERSION.json when the package is installed
+func networkMirrorPackages(ctx context.Context, mirror string, source ProviderSource) ([]*pluginPackage, error) {
+	base, err := url.Parse(strings.TrimSuffix(mirror, "/") + "/" + source.String() + "/")
+	if err != nil {
+		return nil, err
+	}
+	var index struct {
+		Versions map[string]struct{} `json:"versions"`
+	}
+	found, err := getJSON(ctx, base.ResolveReference(&url.URL{Path: "index.json"}).String(), &index)
+	if err != nil || !found {
+		return nil, err
+	}
+	packages := []*pluginPackage{}
+	for name := range index.Versions {
+		v, err := version.NewVersion(name)
+		if err != nil {
+			continue
+		}
+		versionURL := base.ResolveReference(&url.URL{Path: name + ".json"}).String()
+		packages = append(packages, &pluginPackage{version: v, versionURL: versionURL})
+	}
+	return packages, nil
+}
+
+// resolveArchive sets the archive of a network mirror package and the checksums the mirror lists for it
+func (p *pluginPackage) resolveArchive(ctx context.Context) error {
+	var archives struct {
+		Archives map[string]struct {
+			URL    string   `json:"url"`
+			Hashes []string `json:"hashes"`
+		} `json:"archives"`
+	}
+	found, err := getJSON(ctx, p.versionURL, &archives)
+	if err != nil {
+		return err
+	}
+	archive, ok := archives.Archives[pluginMachineName]
+	if !found || !ok {
+		return fmt.Errorf("%s has no package for %s", p.versionURL, pluginMachineName)
+	}
+	base, err := url.Parse(p.versionURL)
+	if err != nil {
+		return err
+	}
+	archiveURL, err := base.Parse(archive.URL)
+	if err != nil {
+		return err
+	}
+	p.archive = archiveURL.String()
+	p.hashes = archive.Hashes
+	return nil
+}
+
+// getJSON decodes the JSON document of a URL, found is false when it doesn't exist
+func getJSON(ctx context.Context, documentURL string, v interface{}) (found bool, err error) {
+	body, err := get(ctx, documentURL)
+	if err != nil || body == nil {
+		return false, err
+	}
+	defer body.Close()
+	if err := json.NewDecoder(body).Decode(v); err != nil {
+		return false, fmt.Errorf("%s: %w", documentURL, err)
+	}
+	return true, nil
+}
+
+// get returns the body of a URL, nil when it doesn't exist
+func get(ctx context.Context, documentURL string) (io.ReadCloser, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, documentURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	switch response.StatusCode {
+	case http.StatusOK:
+		return response.Body, nil
+	case http.StatusNotFound:
+		response.Body.Close()
+		return nil, nil
+	}
+	response.Body.Close()
+	return nil, fmt.Errorf("%s: %s", documentURL, response.Status)
+}
+
+// installPackage unpacks a packed package and verifies the checksums of the package against the lock file
+func installPackage(ctx context.Context, source ProviderSource, pkg *pluginPackage, locked *LockedProvider) (*Plugin, error) {
+	dir, archiveHash, unpacked := pkg.dir, "", ""
+	if dir == "" {
+		if pkg.versionURL != "" {
+			if err := pkg.resolveArchive(ctx); err != nil {
+				return nil, err
+			}
+		}
+		archive, err := fetchArchive(ctx, pkg.archive)
+		if err != nil {
+			return nil, err
+		}
+		if strings.HasPrefix(pkg.archive, "http") {
+			defer os.Remove(archive)
+		}
+		if archiveHash, err = fileHash(archive); err != nil {
+			return nil, err
+		}
+		archiveHash = "zh:" + archiveHash
+		dir = filepath.Join(dataDir(), "providers", source.Hostname, source.Namespace, source.Type, pkg.version.String(), pluginMachineName)
+		if unpacked, err = unpack(archive, dir); err != nil {
+			return nil, fmt.Errorf("failed to unpack %s: %w", pkg.archive, err)
+		}
+		// the unverified package is removed unless it was moved to dir
+		defer os.RemoveAll(unpacked)
+	}
+	packageDir := dir
+	if unpacked != "" {
+		packageDir = unpacked
+	}
+	dirHash, err := packageHash(packageDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkg.hashes) > 0 && !hasHash(pkg.hashes, dirHash, archiveHash) {
+		return nil, fmt.Errorf("%s %s from %s doesn't match the checksums listed by the mirror", source, pkg.version, pkg.archive)
+	}
+	hashes := []string{dirHash}
+	if archiveHash != "" {
+		hashes = append(hashes, archiveHash)
+	}
+	if locked != nil && len(locked.Hashes) > 0 {
+		if !hasHash(locked.Hashes, hashes...) {
+			location := pkg.dir
+			if location == "" {
+				location = pkg.archive
+			}
+			return nil, fmt.Errorf("%s %s at %s doesn't match the checksums of the lock file", source, pkg.version, location)
+		}
+		for _, hash := range locked.Hashes {
+			if !hasHash(hashes, hash) {
+				hashes = append(hashes, hash)
+			}
+		}
+	}
+	sort.Strings(hashes)
+	if unpacked != "" {
+		if err := replaceDir(unpacked, dir); err != nil {
+			return nil, err
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasPrefix(entry.Name(), "terraform-provider-"+source.Type) {
+			return &Plugin{
+				Source:  source,
+				Version: pkg.version,
+				Path:    filepath.Join(dir, entry.Name()),
+				Hashes:  hashes,
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("no terraform-provider-%s executable in %s", source.Type, dir)
+}
+
+// fetchArchive returns the local path of an archive, downloaded to a temporary file if it is a URL
+func fetchArchive(ctx context.Context, archive string) (string, error) {
+	if !strings.HasPrefix(archive, "https://") && !strings.HasPrefix(archive, "http://") {
+		return archive, nil
+	}
+	body, err := get(ctx, archive)
+	if err != nil {
+		return "", err
+	}
+	if body == nil {
+		return "", fmt.Errorf("%s not found", archive)
+	}
+	defer body.Close()
+	file, err := os.CreateTemp("", "terraform-provider-*.zip")
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	if _, err := io.Copy(file, body); err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("failed to download %s: %w", archive, err)
+	}
+	return file.Name(), nil
+}
+
+// unpack extracts a zip archive to a temporary directory next to dir, which replaceDir moves to dir once the
+// package is verified
+func unpack(archive, dir string) (string, error) {
+	reader, err := zip.OpenReader(archive)
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+	if err := os.MkdirAll(filepath.Dir(dir), os.ModePerm); err != nil {
+		return "", err
+	}
+	tmp, err := os.MkdirTemp(filepath.Dir(dir), ".unpack-")
+	if err != nil {
+		return "", err
+	}
+	for _, file := range reader.File {
+		if err := extract(file, tmp); err != nil {
+			os.RemoveAll(tmp)
+			return "", err
+		}
+	}
+	return tmp, nil
+}
+
+// extract extracts a file of a zip archive to dir
+func extract(file *zip.File, dir string) error {
+	name := filepath.FromSlash(file.Name)
+	if !filepath.IsLocal(name) {
+		return fmt.Errorf("invalid file name %q", file.Name)
+	}
+	target := filepath.Join(dir, name)
+	if file.FileInfo().IsDir() {
+		return os.MkdirAll(target, os.ModePerm)
+	}
+	if err := os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+		return err
+	}
+	return unpackFile(file, target)
+}
+
+// replaceDir replaces dir, e.g. a previous copy of a package, with a package unpacked and verified
+func replaceDir(unpacked, dir string) error {
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	return os.Rename(unpacked, dir)
+}
+
+func unpackFile(file *zip.File, target string) error {
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	// plugins are executables, the mode of the archive may be lost
+	dst, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o755)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+// fileHash returns the hex encoded SHA-256 of a file
+func fileHash(name string) (string, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// packageHash returns the h1: checksum of an unpacked package, the dirhash of Go modules as used by Terraform
+func packageHash(dir string) (string, error) {
+	files := []string{}
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(files)
+	summary := sha256.New()
+	for _, file := range files {
+		if strings.Contains(file, "\n") {
+			return "", fmt.Errorf("invalid file name %q", file)
+		}
+		hash, err := fileHash(filepath.Join(dir, filepath.FromSlash(file)))
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(summary, "%s  %s\n", hash, file)
+	}
+	return "h1:" + base64.StdEncoding.EncodeToString(summary.Sum(nil)), nil
+}
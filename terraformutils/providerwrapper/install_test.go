@@ -0,0 +1,236 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package providerwrapper
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testMirror returns a filesystem mirror directory with the packages of hashicorp/random
+func testMirror(t *testing.T) string {
+	t.Helper()
+	mirror := t.TempDir()
+	providerDir := filepath.Join(mirror, DefaultRegistryHost, "hashicorp", "random")
+	// unpacked 3.1.0 and packed 3.2.0 for this platform, 3.3.0 for another one only
+	unpacked := filepath.Join(providerDir, "3.1.0", pluginMachineName)
+	if err := os.MkdirAll(unpacked, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(unpacked, "terraform-provider-random_v3.1.0_x5"), []byte("3.1.0"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeZip(t, filepath.Join(providerDir, "terraform-provider-random_3.2.0_"+pluginMachineName+".zip"), "terraform-provider-random_v3.2.0_x5", "3.2.0")
+	writeZip(t, filepath.Join(providerDir, "terraform-provider-random_3.3.0_plan9_arm.zip"), "terraform-provider-random_v3.3.0_x5", "3.3.0")
+	return mirror
+}
+
+func writeZip(t *testing.T, name, file, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(name), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	w := zip.NewWriter(f)
+	fw, err := w.Create(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fw.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// isolate keeps the plugin directories of terraform init and the installed plugins of a test to itself
+func isolate(t *testing.T) string {
+	t.Helper()
+	dataDir := t.TempDir()
+	t.Setenv("TF_DATA_DIR", dataDir)
+	t.Setenv("HOME", t.TempDir())
+	installMu.Lock()
+	installed = map[string]installedPlugin{}
+	installMu.Unlock()
+	return dataDir
+}
+
+func TestInstallPluginFilesystemMirror(t *testing.T) {
+	dataDir := isolate(t)
+	mirror := testMirror(t)
+
+	plugin, err := InstallPlugin(context.Background(), "random", InstallOptions{Mirrors: []string{mirror}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plugin.Version.String() != "3.2.0" || plugin.Constraint != "~> 3.2.0" {
+		t.Errorf("got version %s constraint %s, want the newest package for this platform", plugin.Version, plugin.Constraint)
+	}
+	wantPath := filepath.Join(dataDir, "providers", DefaultRegistryHost, "hashicorp", "random", "3.2.0", pluginMachineName, "terraform-provider-random_v3.2.0_x5")
+	if plugin.Path != wantPath {
+		t.Errorf("got path %s, want the archive unpacked to %s", plugin.Path, wantPath)
+	}
+	if content, err := os.ReadFile(wantPath); err != nil || string(content) != "3.2.0" {
+		t.Errorf("unpacked plugin: %q %v", content, err)
+	}
+	if len(plugin.Hashes) != 2 || !strings.HasPrefix(plugin.Hashes[0], "h1:") || !strings.HasPrefix(plugin.Hashes[1], "zh:") {
+		t.Errorf("got hashes %v, want the h1: and zh: checksums", plugin.Hashes)
+	}
+	if path, _ := getProviderFileName("random"); path != wantPath {
+		t.Errorf("getProviderFileName returned %s", path)
+	}
+	if v := GetProviderVersion("random"); v != "~> 3.2.0" {
+		t.Errorf("GetProviderVersion returned %s", v)
+	}
+
+	plugin, err = InstallPlugin(context.Background(), "random", InstallOptions{Constraint: "< 3.2", Mirrors: []string{mirror}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plugin.Version.String() != "3.1.0" || plugin.Path != filepath.Join(mirror, DefaultRegistryHost, "hashicorp", "random", "3.1.0", pluginMachineName, "terraform-provider-random_v3.1.0_x5") {
+		t.Errorf("got %s at %s, want the unpacked 3.1.0 of the mirror", plugin.Version, plugin.Path)
+	}
+
+	if _, err := InstallPlugin(context.Background(), "random", InstallOptions{Constraint: ">= 3.3", Mirrors: []string{mirror}}); err == nil {
+		t.Error("installed a version without a package for this platform")
+	}
+}
+
+func TestInstallPluginLockFile(t *testing.T) {
+	isolate(t)
+	mirror := testMirror(t)
+	plugin, err := InstallPlugin(context.Background(), "random", InstallOptions{Mirrors: []string{mirror}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lock, err := ParseLockFile(plugin.LockFile().Bytes(), LockFileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the lock file pins 3.2.0, the newest version allowed by the constraint is 3.1.0
+	for _, constraint := range []string{"", ">= 3.2"} {
+		isolate(t)
+		lock.Providers[0].Constraints = constraint
+		locked, err := InstallPlugin(context.Background(), "random", InstallOptions{Lock: lock, Mirrors: []string{mirror}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if locked.Version.String() != "3.2.0" {
+			t.Errorf("got version %s, want the locked 3.2.0", locked.Version)
+		}
+		if constraint != "" && locked.Constraint != constraint {
+			t.Errorf("got constraint %s, want the one of the lock file", locked.Constraint)
+		}
+	}
+	if _, err := InstallPlugin(context.Background(), "random", InstallOptions{Constraint: "< 3.2", Lock: lock, Mirrors: []string{mirror}}); err == nil {
+		t.Error("installed a version the lock file doesn't allow")
+	}
+
+	// a package which doesn't match is not unpacked over the previous copy
+	dataDir := isolate(t)
+	installDir := filepath.Join(dataDir, "providers", DefaultRegistryHost, "hashicorp", "random", "3.2.0", pluginMachineName)
+	previous := filepath.Join(installDir, "terraform-provider-random_v3.2.0_x5")
+	if err := os.MkdirAll(installDir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(previous, []byte("previous"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	lock.Providers[0].Hashes = []string{"h1:Z2FyYmFnZQ=="}
+	if _, err := InstallPlugin(context.Background(), "random", InstallOptions{Lock: lock, Mirrors: []string{mirror}}); err == nil || !strings.Contains(err.Error(), "checksums of the lock file") {
+		t.Errorf("got %v, want a checksum mismatch", err)
+	}
+	if content, err := os.ReadFile(previous); err != nil || string(content) != "previous" {
+		t.Errorf("previous copy: %q %v, want it kept", content, err)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(installDir)); len(entries) != 1 {
+		t.Errorf("got %d entries in %s, want the unverified package removed", len(entries), filepath.Dir(installDir))
+	}
+}
+
+func TestInstallPluginNetworkMirror(t *testing.T) {
+	dataDir := isolate(t)
+	mirror := testMirror(t)
+	providerPath := "/" + DefaultRegistryHost + "/hashicorp/random/"
+	archive := "terraform-provider-random_3.2.0_" + pluginMachineName + ".zip"
+	hash, err := fileHash(filepath.Join(mirror, filepath.FromSlash(providerPath), archive))
+	if err != nil {
+		t.Fatal(err)
+	}
+	documents := map[string]interface{}{
+		providerPath + "index.json": map[string]interface{}{"versions": map[string]interface{}{"3.2.0": map[string]interface{}{}}},
+		providerPath + "3.2.0.json": map[string]interface{}{"archives": map[string]interface{}{
+			pluginMachineName: map[string]interface{}{"url": archive, "hashes": []string{"zh:" + hash}},
+		}},
+	}
+	files := http.FileServer(http.Dir(mirror))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if document, ok := documents[r.URL.Path]; ok {
+			_ = json.NewEncoder(w).Encode(document)
+			return
+		}
+		files.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	plugin, err := InstallPlugin(context.Background(), "random", InstallOptions{Mirrors: []string{server.URL + "/"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plugin.Version.String() != "3.2.0" || !strings.HasPrefix(plugin.Path, dataDir) || !hasHash(plugin.Hashes, "zh:"+hash) {
+		t.Errorf("got %s at %s with %v, want the archive of the mirror", plugin.Version, plugin.Path, plugin.Hashes)
+	}
+
+	isolate(t)
+	documents[providerPath+"3.2.0.json"].(map[string]interface{})["archives"].(map[string]interface{})[pluginMachineName].(map[string]interface{})["hashes"] = []string{"zh:0000"}
+	if _, err := InstallPlugin(context.Background(), "random", InstallOptions{Mirrors: []string{server.URL}}); err == nil {
+		t.Error("installed an archive which doesn't match the checksums of the mirror")
+	}
+}
+
+func TestParseProviderSource(t *testing.T) {
+	for source, want := range map[string]string{
+		"google":                         "registry.terraform.io/hashicorp/google",
+		"DataDog/datadog":                "registry.terraform.io/datadog/datadog",
+		"example.com/acme/widgets":       "example.com/acme/widgets",
+		"registry.terraform.io/a/b":      "registry.terraform.io/a/b",
+		"registry.terraform.io//invalid": "",
+		"a/b/c/d":                        "",
+	} {
+		got, err := ParseProviderSource(source)
+		if want == "" {
+			if err == nil {
+				t.Errorf("%s: got %s, want an error", source, got)
+			}
+			continue
+		}
+		if err != nil || got.String() != want {
+			t.Errorf("%s: got %s %v, want %s", source, got, err, want)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package providerwrapper //nolint
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// LockFileName is the name of the dependency lock file of Terraform
+const LockFileName = ".terraform.lock.hcl"
+
+// LockFile is a dependency lock file of Terraform, the providers are sorted by address
+type LockFile struct {
+	Providers []*LockedProvider
+}
+
+// LockedProvider is the provider block of a lock file
+type LockedProvider struct {
+	// Address is the provider source with its hostname, e.g. registry.terraform.io/hashicorp/google
+	Address     string
+	Version     string
+	Constraints string
+	// Hashes are the checksums of the provider packages, h1: of the unpacked package or zh: of the archive
+	Hashes []string
+}
+
+// ReadLockFile reads a .terraform.lock.hcl file
+func ReadLockFile(name string) (*LockFile, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLockFile(data, name)
+}
+
+// ParseLockFile parses the provider blocks of a lock file
+func ParseLockFile(data []byte, filename string) (*LockFile, error) {
+	file, diags := hclsyntax.ParseConfig(data, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	content, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: "provider", LabelNames: []string{"source"}}},
+	})
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	lock := &LockFile{}
+	for _, block := range content.Blocks {
+		source, err := ParseProviderSource(block.Labels[0])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", block.DefRange, err)
+		}
+		provider := &LockedProvider{Address: source.String()}
+		attributes, diags := block.Body.JustAttributes()
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		for name, attribute := range attributes {
+			value, diags := attribute.Expr.Value(nil)
+			if diags.HasErrors() {
+				return nil, diags
+			}
+			switch name {
+			case "version":
+				provider.Version, err = lockString(value)
+			case "constraints":
+				provider.Constraints, err = lockString(value)
+			case "hashes":
+				if !value.Type().IsTupleType() && !value.Type().IsListType() {
+					err = fmt.Errorf("hashes must be a list of strings")
+					break
+				}
+				for it := value.ElementIterator(); it.Next() && err == nil; {
+					var hash string
+					_, element := it.Element()
+					hash, err = lockString(element)
+					provider.Hashes = append(provider.Hashes, hash)
+				}
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", attribute.NameRange, name, err)
+			}
+		}
+		if provider.Version == "" {
+			return nil, fmt.Errorf("%s: provider %s has no version", block.DefRange, provider.Address)
+		}
+		lock.Providers = append(lock.Providers, provider)
+	}
+	sort.Slice(lock.Providers, func(i, j int) bool {
+		return lock.Providers[i].Address < lock.Providers[j].Address
+	})
+	return lock, nil
+}
+
+func lockString(value cty.Value) (string, error) {
+	if value.IsNull() || !value.IsKnown() || value.Type() != cty.String {
+		return "", fmt.Errorf("must be a string")
+	}
+	return value.AsString(), nil
+}
+
+// Provider returns the provider block of source, nil if the provider is not locked
+func (l *LockFile) Provider(source ProviderSource) *LockedProvider {
+	if l == nil {
+		return nil
+	}
+	for _, provider := range l.Providers {
+		if provider.Address == source.String() {
+			return provider
+		}
+	}
+	return nil
+}
+
+// Bytes returns the lock file as written by terraform init
+func (l *LockFile) Bytes() []byte {
+	var buf bytes.Buffer
+	buf.WriteString("# This file is maintained automatically by \"terraform init\".\n")
+	buf.WriteString("# Manual edits may be lost in future updates.\n")
+	for _, provider := range l.Providers {
+		fmt.Fprintf(&buf, "\nprovider %s {\n", strconv.Quote(provider.Address))
+		if provider.Constraints != "" {
+			fmt.Fprintf(&buf, "  version     = %s\n", strconv.Quote(provider.Version))
+			fmt.Fprintf(&buf, "  constraints = %s\n", strconv.Quote(provider.Constraints))
+		} else {
+			fmt.Fprintf(&buf, "  version = %s\n", strconv.Quote(provider.Version))
+		}
+		if len(provider.Hashes) > 0 {
+			buf.WriteString("  hashes = [\n")
+			for _, hash := range provider.Hashes {
+				fmt.Fprintf(&buf, "    %s,\n", strconv.Quote(hash))
+			}
+			buf.WriteString("  ]\n")
+		}
+		buf.WriteString("}\n")
+	}
+	return buf.Bytes()
+}
+
+// hasHash returns whether hashes holds one of the checksums of a package
+func hasHash(hashes []string, packageHashes ...string) bool {
+	for _, hash := range hashes {
+		for _, packageHash := range packageHashes {
+			if packageHash != "" && hash == packageHash {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package providerwrapper
+
+import (
+	"reflect"
+	"testing"
+)
+
+const testLockFile = `# This file is maintained automatically by "terraform init".
+# Manual edits may be lost in future updates.
+
+provider "registry.terraform.io/hashicorp/google" {
+  version     = "5.10.0"
+  constraints = "~> 5.0"
+  hashes = [
+    "h1:abc=",
+    "zh:0123",
+  ]
+}
+
+provider "registry.terraform.io/hashicorp/random" {
+  version = "3.2.0"
+}
+`
+
+func TestLockFile(t *testing.T) {
+	lock, err := ParseLockFile([]byte(testLockFile), LockFileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	google := lock.Provider(ProviderSource{Hostname: DefaultRegistryHost, Namespace: "hashicorp", Type: "google"})
+	want := &LockedProvider{
+		Address:     "registry.terraform.io/hashicorp/google",
+		Version:     "5.10.0",
+		Constraints: "~> 5.0",
+		Hashes:      []string{"h1:abc=", "zh:0123"},
+	}
+	if !reflect.DeepEqual(google, want) {
+		t.Errorf("got %+v, want %+v", google, want)
+	}
+	if lock.Provider(ProviderSource{Hostname: DefaultRegistryHost, Namespace: "hashicorp", Type: "aws"}) != nil {
+		t.Error("found a provider which isn't locked")
+	}
+	if got := string(lock.Bytes()); got != testLockFile {
+		t.Errorf("got\n%s\nwant\n%s", got, testLockFile)
+	}
+
+	for name, data := range map[string]string{
+		"no version":    `provider "hashicorp/google" {}`,
+		"hashes":        "provider \"hashicorp/google\" {\n  version = \"1.0.0\"\n  hashes = \"h1:abc=\"\n}",
+		"invalid block": "provider \"\" {\n  version = \"1.0.0\"\n}",
+	} {
+		if _, err := ParseLockFile([]byte(data), LockFileName); err == nil {
+			t.Errorf("%s: parsed an invalid lock file", name)
+		}
+	}
+}
@@ -15,6 +15,7 @@
 package providerwrapper //nolint
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
@@ -49,17 +50,36 @@ const DefaultPluginVendorDirV12 = "terraform.d/plugins/" + pluginMachineName
 const pluginMachineName = runtime.GOOS + "_" + runtime.GOARCH
 
 type ProviderWrapper struct {
-	Provider     *tfplugin.GRPCProvider
+	Provider     providers.Interface
 	client       *plugin.Client
 	rpcClient    plugin.ClientProtocol
 	providerName string
 	config       cty.Value
 	schema       *providers.GetSchemaResponse
+	// schemaHash is the hash of the plugin binary caching its schema, see SchemaCacheDir
+	schemaHash   string
 	retryCount   int
 	retrySleepMs int
 }
 
 func NewProviderWrapper(providerName string, providerConfig cty.Value, verbose bool, options ...map[string]int) (*ProviderWrapper, error) {
+	p := newProviderWrapper(providerName, providerConfig, options...)
+	err := p.initProvider(verbose)
+
+	return p, err
+}
+
+// NewProviderWrapperWithProvider returns a ProviderWrapper of a provider running in the process, e.g. a
+// providertest.Provider, in place of the plugin of providerName
+func NewProviderWrapperWithProvider(providerName string, provider providers.Interface, providerConfig cty.Value, options ...map[string]int) (*ProviderWrapper, error) {
+	p := newProviderWrapper(providerName, providerConfig, options...)
+	p.Provider = provider
+	err := p.configure()
+
+	return p, err
+}
+
+func newProviderWrapper(providerName string, providerConfig cty.Value, options ...map[string]int) *ProviderWrapper {
 	p := &ProviderWrapper{retryCount: 5, retrySleepMs: 300}
 	p.providerName = providerName
 	p.config = providerConfig
@@ -74,20 +94,30 @@ func NewProviderWrapper(providerName string, providerConfig cty.Value, verbose b
 			p.retrySleepMs = retrySleepMs
 		}
 	}
-
-	err := p.initProvider(verbose)
-
-	return p, err
+	return p
 }
 
 func (p *ProviderWrapper) Kill() {
+	if p.client == nil {
+		if p.Provider != nil {
+			_ = p.Provider.Close()
+		}
+		return
+	}
 	p.client.Kill()
 }
 
+// GetSchema returns the schema of the provider, from the schema cache when the plugin binary was read before
 func (p *ProviderWrapper) GetSchema() *providers.GetSchemaResponse {
+	if p.schema == nil {
+		p.schema = readCachedSchema(p.schemaHash)
+	}
 	if p.schema == nil {
 		r := p.Provider.GetSchema()
 		p.schema = &r
+		if err := writeCachedSchema(p.schemaHash, p.schema); err != nil {
+			log.Printf("failed to cache the schema of %s: %v", p.providerName, err)
+		}
 	}
 	return p.schema
 }
@@ -157,61 +187,97 @@ func (p *ProviderWrapper) readObjBlocks(block map[string]*configschema.NestedBlo
 	return readOnlyAttributes
 }
 
-func (p *ProviderWrapper) Refresh(info *terraform.InstanceInfo, state *terraform.InstanceState) (*terraform.InstanceState, error) {
+func (p *ProviderWrapper) Refresh(ctx context.Context, info *terraform.InstanceInfo, state *terraform.InstanceState) (*terraform.InstanceState, error) {
+	var err error
+	for i := 0; i < p.retryCount; i++ {
+		var newState *terraform.InstanceState
+		newState, err = p.ReadResource(ctx, info, state)
+		if err == nil {
+			return newState, nil
+		}
+		if errors.Is(err, ErrNullState) || ctx.Err() != nil {
+			return nil, err
+		}
+		log.Println(err)
+		delay := Backoff(time.Duration(p.retrySleepMs)*time.Millisecond, i)
+		log.Printf("WARN: Fail read resource from provider, wait %s before retry\n", delay)
+		if err := Sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+	log.Println("Fail read resource from provider, trying import command")
+	if state, importErr := p.ImportResource(ctx, info, state); importErr == nil {
+		return state, nil
+	}
+	return nil, err
+}
+
+// ErrNullState is returned by ReadResource for resources which no longer exist
+var ErrNullState = errors.New("read resource response is null")
+
+// ReadResource reads the current state of a resource with a single ReadResource call. The call can't be
+// canceled, a canceled ctx only prevents it, the plugin is killed to stop calls in flight.
+func (p *ProviderWrapper) ReadResource(ctx context.Context, info *terraform.InstanceInfo, state *terraform.InstanceState) (*terraform.InstanceState, error) {
+	if ctx.Err() != nil {
+		return nil, context.Cause(ctx)
+	}
 	schema := p.GetSchema()
 	impliedType := schema.ResourceTypes[info.Type].Block.ImpliedType()
 	priorState, err := state.AttrsAsObjectValue(impliedType)
 	if err != nil {
 		return nil, err
 	}
-	successReadResource := false
-	resp := providers.ReadResourceResponse{}
-	for i := 0; i < p.retryCount; i++ {
-		resp = p.Provider.ReadResource(providers.ReadResourceRequest{
-			TypeName:   info.Type,
-			PriorState: priorState,
-			Private:    []byte{},
-		})
-		if resp.Diagnostics.HasErrors() {
-			log.Println(resp.Diagnostics.Err())
-			log.Printf("WARN: Fail read resource from provider, wait %dms before retry\n", p.retrySleepMs)
-			time.Sleep(time.Duration(p.retrySleepMs) * time.Millisecond)
-			continue
-		} else {
-			successReadResource = true
-			break
-		}
-	}
-
-	if !successReadResource {
-		log.Println("Fail read resource from provider, trying import command")
-		// retry with regular import command - without resource attributes
-		importResponse := p.Provider.ImportResourceState(providers.ImportResourceStateRequest{
-			TypeName: info.Type,
-			ID:       state.ID,
-		})
-		if importResponse.Diagnostics.HasErrors() {
-			return nil, resp.Diagnostics.Err()
-		}
-		if len(importResponse.ImportedResources) == 0 {
-			return nil, errors.New("not able to import resource for a given ID")
-		}
-		return terraform.NewInstanceStateShimmedFromValue(importResponse.ImportedResources[0].State, int(schema.ResourceTypes[info.Type].Version)), nil
+	resp := p.Provider.ReadResource(providers.ReadResourceRequest{
+		TypeName:   info.Type,
+		PriorState: priorState,
+		Private:    []byte{},
+	})
+	if resp.Diagnostics.HasErrors() {
+		return nil, resp.Diagnostics.Err()
 	}
-
 	if resp.NewState.IsNull() {
-		msg := fmt.Sprintf("ERROR: Read resource response is null for resource %s", info.Id)
-		return nil, errors.New(msg)
+		return nil, fmt.Errorf("ERROR: %w for resource %s", ErrNullState, info.Id)
 	}
-
 	return terraform.NewInstanceStateShimmedFromValue(resp.NewState, int(schema.ResourceTypes[info.Type].Version)), nil
 }
 
+// ImportResource imports a resource by ID, without the attributes known beforehand
+func (p *ProviderWrapper) ImportResource(ctx context.Context, info *terraform.InstanceInfo, state *terraform.InstanceState) (*terraform.InstanceState, error) {
+	if ctx.Err() != nil {
+		return nil, context.Cause(ctx)
+	}
+	schema := p.GetSchema()
+	importResponse := p.Provider.ImportResourceState(providers.ImportResourceStateRequest{
+		TypeName: info.Type,
+		ID:       state.ID,
+	})
+	if importResponse.Diagnostics.HasErrors() {
+		return nil, importResponse.Diagnostics.Err()
+	}
+	if len(importResponse.ImportedResources) == 0 {
+		return nil, errors.New("not able to import resource for a given ID")
+	}
+	return terraform.NewInstanceStateShimmedFromValue(importResponse.ImportedResources[0].State, int(schema.ResourceTypes[info.Type].Version)), nil
+}
+
 func (p *ProviderWrapper) initProvider(verbose bool) error {
 	providerFilePath, err := getProviderFileName(p.providerName)
 	if err != nil {
 		return err
 	}
+	if err := p.startPlugin(providerFilePath, verbose); err != nil {
+		return err
+	}
+	if p.schemaHash, err = pluginHash(providerFilePath); err != nil {
+		log.Printf("not caching the schema of %s: %v", p.providerName, err)
+	}
+
+	return p.configure()
+}
+
+// startPlugin launches the plugin binary of the provider
+func (p *ProviderWrapper) startPlugin(providerFilePath string, verbose bool) error {
+	var err error
 	options := hclog.LoggerOptions{
 		Name:   "plugin",
 		Level:  hclog.Error,
@@ -241,7 +307,10 @@ func (p *ProviderWrapper) initProvider(verbose bool) error {
 	}
 
 	p.Provider = raw.(*tfplugin.GRPCProvider)
+	return nil
+}
 
+func (p *ProviderWrapper) configure() error {
 	config, err := p.GetSchema().Provider.Block.CoerceValue(p.config)
 	if err != nil {
 		return err
@@ -255,6 +324,9 @@ func (p *ProviderWrapper) initProvider(verbose bool) error {
 }
 
 func getProviderFileName(providerName string) (string, error) {
+	if plugin := InstalledPlugin(providerName); plugin != nil {
+		return plugin.Path, nil
+	}
 	defaultDataDir := os.Getenv("TF_DATA_DIR")
 	if defaultDataDir == "" {
 		defaultDataDir = DefaultDataDir
@@ -339,7 +411,12 @@ func getProviderFileNameV12(providerName string) (string, error) {
 	return providerFilePath, nil
 }
 
+// GetProviderVersion returns the version constraint of the plugin of a provider, the constraint of InstallPlugin
+// or the version of the plugin found in the plugin directories
 func GetProviderVersion(providerName string) string {
+	if plugin := InstalledPlugin(providerName); plugin != nil {
+		return plugin.Constraint
+	}
 	providerFilePath, err := getProviderFileName(providerName)
 	if err != nil {
 		log.Println("Can't find provider file path. Ensure that you are following https://www.terraform.io/docs/configuration/providers.html#third-party-plugins.")
@@ -0,0 +1,236 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package providertest serves the schema and resource states of a fixture in the process, in place of a provider
+// plugin, e.g.
+//
+//	provider, err := providertest.Load("testdata/google.json")
+//	options.Plugin = provider
+package providertest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/providerwrapper"
+
+	"github.com/hashicorp/terraform/providers"
+	"github.com/hashicorp/terraform/tfdiags"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// Fixture is a provider schema and the resource states of a provider, in the format of the fixture files of Load
+type Fixture struct {
+	// Schema is the schema of the provider, see providerwrapper.UnmarshalSchemaJSON
+	Schema json.RawMessage `json:"schema"`
+	// Resources are the attributes of the resources by type and ID, in the format of the attributes of a
+	// terraform.tfstate. Attributes left out are null.
+	Resources map[string]map[string]json.RawMessage `json:"resources"`
+}
+
+// Provider is a providers.Interface serving a Fixture. ReadResource and ImportResourceState return the state
+// of the resource with the ID of the request, resources missing from the fixture no longer exist. Like the google
+// plugin, resources with a region attribute are found in their region only, the region of the prior state or
+// else of the provider config.
+type Provider struct {
+	schema    *providers.GetSchemaResponse
+	resources map[string]map[string]json.RawMessage
+
+	mu     sync.Mutex
+	config cty.Value
+	reads  []string
+}
+
+var _ providers.Interface = (*Provider)(nil)
+
+// New returns a Provider serving a fixture
+func New(fixture Fixture) (*Provider, error) {
+	schema, err := providerwrapper.UnmarshalSchemaJSON(fixture.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("providertest: schema: %w", err)
+	}
+	for resourceType, resources := range fixture.Resources {
+		resourceSchema, exists := schema.ResourceTypes[resourceType]
+		if !exists {
+			return nil, fmt.Errorf("providertest: %s is not in the schema", resourceType)
+		}
+		for id, attributes := range resources {
+			if _, err := ctyjson.Unmarshal(attributes, resourceSchema.Block.ImpliedType()); err != nil {
+				return nil, fmt.Errorf("providertest: %s.%s: %w", resourceType, id, err)
+			}
+		}
+	}
+	return &Provider{schema: schema, resources: fixture.Resources, config: cty.NullVal(cty.DynamicPseudoType)}, nil
+}
+
+// Load returns a Provider serving the Fixture of a JSON file
+func Load(path string) (*Provider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var fixture Fixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("providertest: %s: %w", path, err)
+	}
+	return New(fixture)
+}
+
+// Config returns the configuration of the last Configure call
+func (p *Provider) Config() cty.Value {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.config
+}
+
+// Reads returns the resources read by ReadResource, as type.ID in the order of the calls
+func (p *Provider) Reads() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string{}, p.reads...)
+}
+
+// state returns the state of a resource, a null value when it is not in the fixture or in another region than
+// the region of prior or the provider config
+func (p *Provider) state(resourceType, id string, prior cty.Value) (cty.Value, error) {
+	resourceSchema, exists := p.schema.ResourceTypes[resourceType]
+	if !exists {
+		return cty.NilVal, fmt.Errorf("unknown resource type %s", resourceType)
+	}
+	impliedType := resourceSchema.Block.ImpliedType()
+	attributes, exists := p.resources[resourceType][id]
+	if !exists {
+		return cty.NullVal(impliedType), nil
+	}
+	state, err := ctyjson.Unmarshal(attributes, impliedType)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	if region := stringAttribute(state, "region"); region != "" && region != p.region(prior) {
+		return cty.NullVal(impliedType), nil
+	}
+	return state, nil
+}
+
+// region returns the region of a request, the region of the prior state or else of the provider config
+func (p *Provider) region(prior cty.Value) string {
+	if region := stringAttribute(prior, "region"); region != "" {
+		return region
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return stringAttribute(p.config, "region")
+}
+
+// stateID returns the id attribute of a state, the key of the resources of a Fixture
+func stateID(state cty.Value) string {
+	return stringAttribute(state, "id")
+}
+
+// stringAttribute returns a string attribute of an object, "" when it is null or missing
+func stringAttribute(value cty.Value, name string) string {
+	if value == cty.NilVal || !value.IsKnown() || value.IsNull() || !value.Type().IsObjectType() || !value.Type().HasAttribute(name) {
+		return ""
+	}
+	if attribute := value.GetAttr(name); attribute.IsKnown() && !attribute.IsNull() && attribute.Type() == cty.String {
+		return attribute.AsString()
+	}
+	return ""
+}
+
+func (p *Provider) GetSchema() providers.GetSchemaResponse {
+	return *p.schema
+}
+
+func (p *Provider) PrepareProviderConfig(req providers.PrepareProviderConfigRequest) providers.PrepareProviderConfigResponse {
+	return providers.PrepareProviderConfigResponse{PreparedConfig: req.Config}
+}
+
+func (p *Provider) ValidateResourceTypeConfig(req providers.ValidateResourceTypeConfigRequest) providers.ValidateResourceTypeConfigResponse {
+	return providers.ValidateResourceTypeConfigResponse{}
+}
+
+func (p *Provider) ValidateDataSourceConfig(req providers.ValidateDataSourceConfigRequest) providers.ValidateDataSourceConfigResponse {
+	return providers.ValidateDataSourceConfigResponse{}
+}
+
+func (p *Provider) UpgradeResourceState(req providers.UpgradeResourceStateRequest) providers.UpgradeResourceStateResponse {
+	var diags tfdiags.Diagnostics
+	resourceSchema, exists := p.schema.ResourceTypes[req.TypeName]
+	if !exists {
+		return providers.UpgradeResourceStateResponse{Diagnostics: diags.Append(fmt.Errorf("unknown resource type %s", req.TypeName))}
+	}
+	state, err := ctyjson.Unmarshal(req.RawStateJSON, resourceSchema.Block.ImpliedType())
+	if err != nil {
+		diags = diags.Append(err)
+	}
+	return providers.UpgradeResourceStateResponse{UpgradedState: state, Diagnostics: diags}
+}
+
+func (p *Provider) Configure(req providers.ConfigureRequest) providers.ConfigureResponse {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.config = req.Config
+	return providers.ConfigureResponse{}
+}
+
+func (p *Provider) Stop() error {
+	return nil
+}
+
+func (p *Provider) ReadResource(req providers.ReadResourceRequest) providers.ReadResourceResponse {
+	var diags tfdiags.Diagnostics
+	id := stateID(req.PriorState)
+	p.mu.Lock()
+	p.reads = append(p.reads, req.TypeName+"."+id)
+	p.mu.Unlock()
+	state, err := p.state(req.TypeName, id, req.PriorState)
+	if err != nil {
+		return providers.ReadResourceResponse{Diagnostics: diags.Append(err)}
+	}
+	return providers.ReadResourceResponse{NewState: state, Private: req.Private}
+}
+
+func (p *Provider) PlanResourceChange(req providers.PlanResourceChangeRequest) providers.PlanResourceChangeResponse {
+	return providers.PlanResourceChangeResponse{PlannedState: req.ProposedNewState, PlannedPrivate: req.PriorPrivate}
+}
+
+func (p *Provider) ApplyResourceChange(req providers.ApplyResourceChangeRequest) providers.ApplyResourceChangeResponse {
+	return providers.ApplyResourceChangeResponse{NewState: req.PlannedState, Private: req.PlannedPrivate}
+}
+
+func (p *Provider) ImportResourceState(req providers.ImportResourceStateRequest) providers.ImportResourceStateResponse {
+	var diags tfdiags.Diagnostics
+	state, err := p.state(req.TypeName, req.ID, cty.NilVal)
+	if err != nil {
+		return providers.ImportResourceStateResponse{Diagnostics: diags.Append(err)}
+	}
+	if state.IsNull() {
+		return providers.ImportResourceStateResponse{Diagnostics: diags.Append(fmt.Errorf("cannot import non-existent remote object %s %s", req.TypeName, req.ID))}
+	}
+	return providers.ImportResourceStateResponse{
+		ImportedResources: []providers.ImportedResource{{TypeName: req.TypeName, State: state}},
+	}
+}
+
+func (p *Provider) ReadDataSource(req providers.ReadDataSourceRequest) providers.ReadDataSourceResponse {
+	return providers.ReadDataSourceResponse{State: req.Config}
+}
+
+func (p *Provider) Close() error {
+	return nil
+}
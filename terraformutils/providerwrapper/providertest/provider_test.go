@@ -0,0 +1,121 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package providertest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/providerwrapper"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var fixture = Fixture{
+	Schema: json.RawMessage(`{
+  "provider": {"block": {"attributes": {"project": {"type": "string", "optional": true}}}},
+  "resource_schemas": {
+    "google_storage_bucket": {
+      "block": {
+        "attributes": {
+          "id": {"type": "string", "computed": true},
+          "name": {"type": "string", "required": true},
+          "location": {"type": "string", "optional": true},
+          "labels": {"type": ["map", "string"], "optional": true}
+        }
+      }
+    },
+    "google_sql_user": {
+      "block": {
+        "attributes": {
+          "id": {"type": "string", "computed": true},
+          "name": {"type": "string", "required": true},
+          "password": {"type": "string", "optional": true, "sensitive": true}
+        },
+        "block_types": {
+          "sql_server_user_details": {
+            "nesting_mode": "list",
+            "block": {"attributes": {"token": {"type": "string", "computed": true, "sensitive": true}, "disabled": {"type": "bool", "computed": true}}}
+          }
+        }
+      }
+    }
+  }
+}`),
+	Resources: map[string]map[string]json.RawMessage{
+		"google_storage_bucket": {
+			"logs": json.RawMessage(`{"id": "logs", "name": "logs", "location": "EU", "labels": {"team": "ops"}}`),
+		},
+		"google_sql_user": {
+			"admin": json.RawMessage(`{"id": "admin", "name": "admin", "password": "hunter2", "sql_server_user_details": [{"token": "t0k3n", "disabled": false}]}`),
+		},
+	},
+}
+
+func TestProviderWrapper(t *testing.T) {
+	provider, err := New(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := cty.ObjectVal(map[string]cty.Value{"project": cty.StringVal("my-project")})
+	wrapper, err := providerwrapper.NewProviderWrapperWithProvider("google", provider, config, map[string]int{"retryCount": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer wrapper.Kill()
+	if !provider.Config().RawEquals(config) {
+		t.Errorf("unexpected config %#v", provider.Config())
+	}
+
+	info := &terraform.InstanceInfo{Type: "google_storage_bucket", Id: "logs"}
+	state, err := wrapper.Refresh(context.Background(), info, &terraform.InstanceState{ID: "logs", Attributes: map[string]string{"id": "logs", "name": "logs"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{"id": "logs", "name": "logs", "location": "EU", "labels.%": "1", "labels.team": "ops"}
+	if !reflect.DeepEqual(state.Attributes, expected) {
+		t.Errorf("expected %v, got %v", expected, state.Attributes)
+	}
+
+	deleted := &terraform.InstanceInfo{Type: "google_storage_bucket", Id: "deleted"}
+	if _, err := wrapper.ReadResource(context.Background(), deleted, &terraform.InstanceState{ID: "deleted", Attributes: map[string]string{"id": "deleted"}}); !errors.Is(err, providerwrapper.ErrNullState) {
+		t.Errorf("expected ErrNullState, got %v", err)
+	}
+	if _, err := wrapper.ImportResource(context.Background(), deleted, &terraform.InstanceState{ID: "deleted"}); err == nil {
+		t.Error("expected an error importing a missing resource")
+	}
+	if imported, err := wrapper.ImportResource(context.Background(), info, &terraform.InstanceState{ID: "logs"}); err != nil || imported.Attributes["location"] != "EU" {
+		t.Errorf("unexpected import %v, %v", imported, err)
+	}
+
+	if reads := provider.Reads(); !reflect.DeepEqual(reads, []string{"google_storage_bucket.logs", "google_storage_bucket.deleted"}) {
+		t.Errorf("unexpected reads %v", reads)
+	}
+}
+
+func TestNewInvalidFixture(t *testing.T) {
+	for name, resources := range map[string]map[string]map[string]json.RawMessage{
+		"unknown type":      {"google_compute_network": {"default": json.RawMessage(`{}`)}},
+		"unknown attribute": {"google_storage_bucket": {"logs": json.RawMessage(`{"size": 1}`)}},
+	} {
+		if _, err := New(Fixture{Schema: fixture.Schema, Resources: resources}); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
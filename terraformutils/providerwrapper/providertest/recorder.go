@@ -0,0 +1,182 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package providertest
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/providerwrapper"
+
+	"github.com/hashicorp/terraform/configs/configschema"
+	"github.com/hashicorp/terraform/providers"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// Recorder records the schema and the resource states read from providers into a Fixture, which Load serves
+// in place of the provider plugins. The attributes the schema marks as sensitive are recorded as null.
+type Recorder struct {
+	mu        sync.Mutex
+	schema    json.RawMessage
+	blocks    map[string]*configschema.Block
+	resources map[string]map[string]json.RawMessage
+}
+
+// NewRecorder returns an empty Recorder
+func NewRecorder() *Recorder {
+	return &Recorder{
+		blocks:    map[string]*configschema.Block{},
+		resources: map[string]map[string]json.RawMessage{},
+	}
+}
+
+// Wrap returns a providers.Interface recording the schema and the states returned by ReadResource and
+// ImportResourceState of provider. Resources read as deleted are left out, as the Provider serves them.
+func (r *Recorder) Wrap(provider providers.Interface) providers.Interface {
+	recording := &recordingProvider{Interface: provider, recorder: r}
+	if schema := provider.GetSchema(); !schema.Diagnostics.HasErrors() {
+		r.recordSchema(&schema)
+	}
+	return recording
+}
+
+// Fixture returns the fixture recorded so far
+func (r *Recorder) Fixture() Fixture {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	resources := map[string]map[string]json.RawMessage{}
+	for resourceType, states := range r.resources {
+		resources[resourceType] = map[string]json.RawMessage{}
+		for id, state := range states {
+			resources[resourceType][id] = state
+		}
+	}
+	return Fixture{Schema: r.schema, Resources: resources}
+}
+
+// Write writes the recorded fixture to a JSON file, see Load
+func (r *Recorder) Write(path string) error {
+	data, err := json.MarshalIndent(r.Fixture(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0600)
+}
+
+func (r *Recorder) recordSchema(schema *providers.GetSchemaResponse) {
+	data, err := providerwrapper.MarshalSchemaJSON(schema)
+	if err != nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.schema = data
+	for resourceType, resourceSchema := range schema.ResourceTypes {
+		r.blocks[resourceType] = resourceSchema.Block
+	}
+}
+
+func (r *Recorder) recordState(resourceType, id string, state cty.Value) {
+	if id == "" || state.IsNull() || !state.IsWhollyKnown() {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	data, err := ctyjson.Marshal(nullSensitive(r.blocks[resourceType], state), state.Type())
+	if err != nil {
+		return
+	}
+	if r.resources[resourceType] == nil {
+		r.resources[resourceType] = map[string]json.RawMessage{}
+	}
+	r.resources[resourceType][id] = data
+}
+
+type recordingProvider struct {
+	providers.Interface
+	recorder *Recorder
+}
+
+func (p *recordingProvider) ReadResource(req providers.ReadResourceRequest) providers.ReadResourceResponse {
+	resp := p.Interface.ReadResource(req)
+	if !resp.Diagnostics.HasErrors() {
+		p.recorder.recordState(req.TypeName, stateID(req.PriorState), resp.NewState)
+	}
+	return resp
+}
+
+func (p *recordingProvider) ImportResourceState(req providers.ImportResourceStateRequest) providers.ImportResourceStateResponse {
+	resp := p.Interface.ImportResourceState(req)
+	if !resp.Diagnostics.HasErrors() {
+		for _, imported := range resp.ImportedResources {
+			if imported.TypeName == req.TypeName {
+				p.recorder.recordState(req.TypeName, req.ID, imported.State)
+			}
+		}
+	}
+	return resp
+}
+
+// nullSensitive returns value with the attributes block marks as sensitive set to null, also in nested blocks
+func nullSensitive(block *configschema.Block, value cty.Value) cty.Value {
+	if block == nil || value.IsNull() || !value.IsKnown() || !value.Type().IsObjectType() {
+		return value
+	}
+	attributes := value.AsValueMap()
+	for name, attribute := range block.Attributes {
+		if current, ok := attributes[name]; ok && attribute.Sensitive && !current.IsNull() {
+			attributes[name] = cty.NullVal(current.Type())
+		}
+	}
+	for name, blockType := range block.BlockTypes {
+		current, ok := attributes[name]
+		if !ok || current.IsNull() || !current.IsKnown() {
+			continue
+		}
+		nested := &blockType.Block
+		switch {
+		case current.Type().IsObjectType():
+			attributes[name] = nullSensitive(nested, current)
+		case current.LengthInt() == 0:
+		case current.Type().IsListType():
+			attributes[name] = cty.ListVal(nullSensitiveElements(nested, current))
+		case current.Type().IsSetType():
+			attributes[name] = cty.SetVal(nullSensitiveElements(nested, current))
+		case current.Type().IsTupleType():
+			attributes[name] = cty.TupleVal(nullSensitiveElements(nested, current))
+		case current.Type().IsMapType():
+			elements := map[string]cty.Value{}
+			for key, element := range current.AsValueMap() {
+				elements[key] = nullSensitive(nested, element)
+			}
+			attributes[name] = cty.MapVal(elements)
+		}
+	}
+	if len(attributes) == 0 {
+		return value
+	}
+	return cty.ObjectVal(attributes)
+}
+
+func nullSensitiveElements(block *configschema.Block, value cty.Value) []cty.Value {
+	elements := []cty.Value{}
+	for it := value.ElementIterator(); it.Next(); {
+		_, element := it.Element()
+		elements = append(elements, nullSensitive(block, element))
+	}
+	return elements
+}
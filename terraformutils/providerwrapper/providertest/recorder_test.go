@@ -0,0 +1,91 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package providertest
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/providerwrapper"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestRecorder(t *testing.T) {
+	provider, err := New(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder := NewRecorder()
+	wrapper, err := providerwrapper.NewProviderWrapperWithProvider("google", recorder.Wrap(provider), cty.EmptyObjectVal, map[string]int{"retryCount": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer wrapper.Kill()
+	info := &terraform.InstanceInfo{Type: "google_storage_bucket", Id: "logs"}
+	if _, err := wrapper.Refresh(context.Background(), info, &terraform.InstanceState{ID: "logs", Attributes: map[string]string{"id": "logs", "name": "logs"}}); err != nil {
+		t.Fatal(err)
+	}
+	deleted := &terraform.InstanceInfo{Type: "google_storage_bucket", Id: "deleted"}
+	_, _ = wrapper.ReadResource(context.Background(), deleted, &terraform.InstanceState{ID: "deleted", Attributes: map[string]string{"id": "deleted"}})
+
+	path := filepath.Join(t.TempDir(), "provider.json")
+	if err := recorder.Write(path); err != nil {
+		t.Fatal(err)
+	}
+	replayed, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(replayed.GetSchema().ResourceTypes, provider.GetSchema().ResourceTypes) {
+		t.Errorf("the schema was not recorded")
+	}
+	for _, id := range []string{"logs", "deleted"} {
+		expected, _ := provider.state("google_storage_bucket", id, cty.NilVal)
+		state, err := replayed.state("google_storage_bucket", id, cty.NilVal)
+		if err != nil || !state.RawEquals(expected) {
+			t.Errorf("%s: expected %#v, got %#v, %v", id, expected, state, err)
+		}
+	}
+}
+
+func TestRecorderSensitive(t *testing.T) {
+	provider, err := New(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder := NewRecorder()
+	wrapper, err := providerwrapper.NewProviderWrapperWithProvider("google", recorder.Wrap(provider), cty.EmptyObjectVal, map[string]int{"retryCount": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer wrapper.Kill()
+	info := &terraform.InstanceInfo{Type: "google_sql_user", Id: "admin"}
+	if _, err := wrapper.Refresh(context.Background(), info, &terraform.InstanceState{ID: "admin", Attributes: map[string]string{"id": "admin", "name": "admin"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	recorded := string(recorder.Fixture().Resources["google_sql_user"]["admin"])
+	if strings.Contains(recorded, "hunter2") || strings.Contains(recorded, "t0k3n") {
+		t.Errorf("expected the sensitive attributes to be left out, got %s", recorded)
+	}
+	if !strings.Contains(recorded, `"name":"admin"`) || !strings.Contains(recorded, `"disabled":false`) {
+		t.Errorf("expected the other attributes to be recorded, got %s", recorded)
+	}
+}
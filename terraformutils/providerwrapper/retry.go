@@ -0,0 +1,63 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package providerwrapper
+
+import (
+	"context"
+	"math/rand"
+	"regexp"
+	"time"
+)
+
+// MaxBackoff caps the delay between retries
+const MaxBackoff = 30 * time.Second
+
+// Backoff returns the delay before retry attempt, counted from 0: base doubled per attempt, capped at
+// MaxBackoff, with a random jitter of up to half of the delay so that concurrent retries spread out
+func Backoff(base time.Duration, attempt int) time.Duration {
+	if base <= 0 {
+		return 0
+	}
+	delay := base
+	for i := 0; i < attempt && delay < MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > MaxBackoff {
+		delay = MaxBackoff
+	}
+	half := int64(delay / 2)
+	return time.Duration(half + rand.Int63n(half+1)) //nolint:gosec
+}
+
+// Sleep waits for d, it returns the cause of ctx when ctx is canceled first
+func Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
+
+// throttlingRe matches the diagnostics of providers when an API rejects a request for exceeding a rate limit
+// or quota, e.g. googleapi: Error 429: Quota exceeded, or ThrottlingException: Rate exceeded
+var throttlingRe = regexp.MustCompile(`(?i)(\b429\b|too many requests|rate ?limit|rate exceeded|quota exceeded|resource_exhausted|throttl|requestlimitexceeded|slow ?down)`)
+
+// IsThrottled reports whether err was returned because a rate limit or quota was exceeded
+func IsThrottled(err error) bool {
+	return err != nil && throttlingRe.MatchString(err.Error())
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package providerwrapper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	for attempt, max := range []time.Duration{100, 200, 400, 800} {
+		max *= time.Millisecond
+		for i := 0; i < 100; i++ {
+			if delay := Backoff(100*time.Millisecond, attempt); delay < max/2 || delay > max {
+				t.Fatalf("attempt %d: delay %s not in [%s, %s]", attempt, delay, max/2, max)
+			}
+		}
+	}
+	if delay := Backoff(time.Second, 20); delay > MaxBackoff || delay < MaxBackoff/2 {
+		t.Errorf("expected the delay to be capped at %s, got %s", MaxBackoff, delay)
+	}
+	if delay := Backoff(0, 3); delay != 0 {
+		t.Errorf("expected no delay, got %s", delay)
+	}
+}
+
+func TestSleep(t *testing.T) {
+	if err := Sleep(context.Background(), time.Millisecond); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	if err := Sleep(ctx, time.Minute); !errors.Is(err, context.Canceled) || time.Since(start) > time.Second {
+		t.Errorf("expected the sleep to be canceled, got %v", err)
+	}
+}
+
+func TestIsThrottled(t *testing.T) {
+	for message, expected := range map[string]bool{
+		"googleapi: Error 429: Quota exceeded for quota metric":         true,
+		"ThrottlingException: Rate exceeded":                            true,
+		"rpc error: code = ResourceExhausted desc = RESOURCE_EXHAUSTED": true,
+		"googleapi: Error 404: The resource was not found":              false,
+		"connection reset by peer":                                      false,
+	} {
+		if IsThrottled(errors.New(message)) != expected {
+			t.Errorf("IsThrottled(%q) != %t", message, expected)
+		}
+	}
+	if IsThrottled(nil) {
+		t.Errorf("nil is not throttled")
+	}
+}
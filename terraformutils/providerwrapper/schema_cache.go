@@ -0,0 +1,156 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package providerwrapper //nolint
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform/providers"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// SchemaCacheEnv is the environment variable of the directory of the schema cache, off disables the cache
+const SchemaCacheEnv = "TERRAFORMER_SCHEMA_CACHE"
+
+// SchemaCacheDir returns the directory caching the schemas of provider plugins by the SHA-256 of their binary,
+// $TERRAFORMER_SCHEMA_CACHE or terraformer/schemas in the user cache directory. It is empty when the cache is
+// disabled.
+func SchemaCacheDir() string {
+	if dir := os.Getenv(SchemaCacheEnv); dir != "" {
+		if dir == "off" {
+			return ""
+		}
+		return dir
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "terraformer", "schemas")
+}
+
+// pluginFile identifies a version of a plugin binary without reading it
+type pluginFile struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+var (
+	pluginHashesMu sync.Mutex
+	// pluginHashes are the hashes of the plugin binaries hashed by the process
+	pluginHashes = map[pluginFile]string{}
+)
+
+// pluginHash returns the hex encoded SHA-256 of a plugin binary, each version of a binary is read once
+func pluginHash(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	key := pluginFile{path: path, size: info.Size(), modTime: info.ModTime()}
+	pluginHashesMu.Lock()
+	defer pluginHashesMu.Unlock()
+	if hash, ok := pluginHashes[key]; ok {
+		return hash, nil
+	}
+	hash, err := fileHash(path)
+	if err != nil {
+		return "", err
+	}
+	pluginHashes[key] = hash
+	return hash, nil
+}
+
+// readCachedSchema returns the cached schema of the plugin binary with a hash, nil when it isn't cached
+func readCachedSchema(hash string) *providers.GetSchemaResponse {
+	dir := SchemaCacheDir()
+	if dir == "" || hash == "" {
+		return nil
+	}
+	data, err := os.ReadFile(filepath.Join(dir, hash+".json"))
+	if err != nil {
+		return nil
+	}
+	schema, err := UnmarshalSchemaJSON(data)
+	if err != nil {
+		log.Printf("ignoring invalid cached schema %s: %v", filepath.Join(dir, hash+".json"), err)
+		return nil
+	}
+	return schema
+}
+
+// writeCachedSchema caches the schema of the plugin binary with a hash, schemas with errors are not cached
+func writeCachedSchema(hash string, schema *providers.GetSchemaResponse) error {
+	dir := SchemaCacheDir()
+	if dir == "" || hash == "" || schema.Diagnostics.HasErrors() {
+		return nil
+	}
+	data, err := MarshalSchemaJSON(schema)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(dir, hash+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), filepath.Join(dir, hash+".json"))
+}
+
+// ProviderSchema returns the schema of the plugin of a provider. A cached schema is returned without launching
+// the plugin, otherwise the plugin is launched, killed and its schema cached.
+func ProviderSchema(providerName string, verbose bool) (*providers.GetSchemaResponse, error) {
+	providerFilePath, err := getProviderFileName(providerName)
+	if err != nil {
+		return nil, err
+	}
+	if providerFilePath == "" {
+		return nil, fmt.Errorf("no plugin found for provider %s", providerName)
+	}
+	hash, err := pluginHash(providerFilePath)
+	if err != nil {
+		return nil, err
+	}
+	if schema := readCachedSchema(hash); schema != nil {
+		return schema, nil
+	}
+	p := newProviderWrapper(providerName, cty.NilVal)
+	defer p.Kill()
+	if err := p.startPlugin(providerFilePath, verbose); err != nil {
+		return nil, err
+	}
+	p.schemaHash = hash
+	schema := p.GetSchema()
+	if schema.Diagnostics.HasErrors() {
+		return nil, schema.Diagnostics.Err()
+	}
+	return schema, nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package providerwrapper //nolint
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/configs/configschema"
+	"github.com/hashicorp/terraform/providers"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// schemaProvider is a plugin serving a schema, counting the GetSchema calls
+type schemaProvider struct {
+	providers.Interface
+	schema providers.GetSchemaResponse
+	calls  int
+}
+
+func (p *schemaProvider) GetSchema() providers.GetSchemaResponse {
+	p.calls++
+	return p.schema
+}
+
+func TestSchemaCache(t *testing.T) {
+	t.Setenv(SchemaCacheEnv, filepath.Join(t.TempDir(), "schemas"))
+	binary := filepath.Join(t.TempDir(), "terraform-provider-google_v5.0.0")
+	if err := os.WriteFile(binary, []byte("v5.0.0"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	hash, err := pluginHash(binary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plugin := &schemaProvider{schema: providers.GetSchemaResponse{
+		Provider: providers.Schema{Block: &configschema.Block{
+			Attributes: map[string]*configschema.Attribute{"project": {Type: cty.String, Optional: true}},
+			BlockTypes: map[string]*configschema.NestedBlock{},
+		}},
+		ResourceTypes: map[string]providers.Schema{
+			"google_storage_bucket": {Block: &configschema.Block{
+				Attributes: map[string]*configschema.Attribute{"name": {Type: cty.String, Required: true}},
+				BlockTypes: map[string]*configschema.NestedBlock{},
+			}},
+		},
+		DataSources: map[string]providers.Schema{},
+	}}
+
+	first := &ProviderWrapper{Provider: plugin, schemaHash: hash}
+	first.GetSchema()
+	second := &ProviderWrapper{Provider: plugin, schemaHash: hash}
+	if schema := second.GetSchema(); !reflect.DeepEqual(schema, &plugin.schema) {
+		t.Errorf("unexpected cached schema %v", schema)
+	}
+	if plugin.calls != 1 {
+		t.Errorf("expected the plugin to be asked once, got %d calls", plugin.calls)
+	}
+
+	// another binary is another cache entry
+	if err := os.WriteFile(binary, []byte("v5.10.0"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if updated, err := pluginHash(binary); err != nil || updated == hash {
+		t.Fatalf("expected another hash, got %s, %v", updated, err)
+	}
+	if readCachedSchema("unknown") != nil {
+		t.Error("expected no schema for an unknown hash")
+	}
+
+	t.Setenv(SchemaCacheEnv, "off")
+	third := &ProviderWrapper{Provider: plugin, schemaHash: hash}
+	third.GetSchema()
+	if plugin.calls != 2 || SchemaCacheDir() != "" {
+		t.Errorf("expected the cache to be disabled, got %d calls", plugin.calls)
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package providerwrapper //nolint
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform/configs/configschema"
+	"github.com/hashicorp/terraform/providers"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ProviderSchemaJSON is the schema of a provider in the format of a provider of terraform providers schema -json
+type ProviderSchemaJSON struct {
+	Provider          *SchemaJSON            `json:"provider,omitempty"`
+	ResourceSchemas   map[string]*SchemaJSON `json:"resource_schemas,omitempty"`
+	DataSourceSchemas map[string]*SchemaJSON `json:"data_source_schemas,omitempty"`
+}
+
+type SchemaJSON struct {
+	Version int64      `json:"version"`
+	Block   *BlockJSON `json:"block"`
+}
+
+type BlockJSON struct {
+	Attributes map[string]*AttributeJSON   `json:"attributes,omitempty"`
+	BlockTypes map[string]*NestedBlockJSON `json:"block_types,omitempty"`
+}
+
+type AttributeJSON struct {
+	Type        cty.Type `json:"type"`
+	Description string   `json:"description,omitempty"`
+	Required    bool     `json:"required,omitempty"`
+	Optional    bool     `json:"optional,omitempty"`
+	Computed    bool     `json:"computed,omitempty"`
+	Sensitive   bool     `json:"sensitive,omitempty"`
+}
+
+type NestedBlockJSON struct {
+	NestingMode string     `json:"nesting_mode"`
+	Block       *BlockJSON `json:"block"`
+	MinItems    int        `json:"min_items,omitempty"`
+	MaxItems    int        `json:"max_items,omitempty"`
+}
+
+var nestingModes = map[configschema.NestingMode]string{
+	configschema.NestingSingle: "single",
+	configschema.NestingGroup:  "group",
+	configschema.NestingList:   "list",
+	configschema.NestingSet:    "set",
+	configschema.NestingMap:    "map",
+}
+
+// MarshalSchemaJSON returns the schema of a provider in the format of ProviderSchemaJSON
+func MarshalSchemaJSON(schema *providers.GetSchemaResponse) ([]byte, error) {
+	if schema.Diagnostics.HasErrors() {
+		return nil, schema.Diagnostics.Err()
+	}
+	schemaJSON := ProviderSchemaJSON{
+		Provider:          marshalSchema(schema.Provider),
+		ResourceSchemas:   map[string]*SchemaJSON{},
+		DataSourceSchemas: map[string]*SchemaJSON{},
+	}
+	for name, resourceSchema := range schema.ResourceTypes {
+		schemaJSON.ResourceSchemas[name] = marshalSchema(resourceSchema)
+	}
+	for name, dataSourceSchema := range schema.DataSources {
+		schemaJSON.DataSourceSchemas[name] = marshalSchema(dataSourceSchema)
+	}
+	return json.MarshalIndent(schemaJSON, "", "  ")
+}
+
+func marshalSchema(schema providers.Schema) *SchemaJSON {
+	return &SchemaJSON{Version: schema.Version, Block: marshalBlock(schema.Block)}
+}
+
+func marshalBlock(block *configschema.Block) *BlockJSON {
+	blockJSON := &BlockJSON{}
+	if block == nil {
+		return blockJSON
+	}
+	if len(block.Attributes) > 0 {
+		blockJSON.Attributes = map[string]*AttributeJSON{}
+	}
+	for name, attribute := range block.Attributes {
+		blockJSON.Attributes[name] = &AttributeJSON{
+			Type:        attribute.Type,
+			Description: attribute.Description,
+			Required:    attribute.Required,
+			Optional:    attribute.Optional,
+			Computed:    attribute.Computed,
+			Sensitive:   attribute.Sensitive,
+		}
+	}
+	if len(block.BlockTypes) > 0 {
+		blockJSON.BlockTypes = map[string]*NestedBlockJSON{}
+	}
+	for name, nested := range block.BlockTypes {
+		blockJSON.BlockTypes[name] = &NestedBlockJSON{
+			NestingMode: nestingModes[nested.Nesting],
+			Block:       marshalBlock(&nested.Block),
+			MinItems:    nested.MinItems,
+			MaxItems:    nested.MaxItems,
+		}
+	}
+	return blockJSON
+}
+
+// UnmarshalSchemaJSON returns the schema of a provider from ProviderSchemaJSON, or from the output of terraform
+// providers schema -json listing a single provider
+func UnmarshalSchemaJSON(data []byte) (*providers.GetSchemaResponse, error) {
+	var document struct {
+		ProviderSchemas map[string]*ProviderSchemaJSON `json:"provider_schemas"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	schemaJSON := &ProviderSchemaJSON{}
+	switch len(document.ProviderSchemas) {
+	case 0:
+		if err := json.Unmarshal(data, schemaJSON); err != nil {
+			return nil, err
+		}
+	case 1:
+		for _, providerSchema := range document.ProviderSchemas {
+			schemaJSON = providerSchema
+		}
+	default:
+		return nil, fmt.Errorf("schema lists %d providers, expected one", len(document.ProviderSchemas))
+	}
+
+	schema := &providers.GetSchemaResponse{
+		ResourceTypes: map[string]providers.Schema{},
+		DataSources:   map[string]providers.Schema{},
+	}
+	var err error
+	if schema.Provider, err = unmarshalSchema(schemaJSON.Provider); err != nil {
+		return nil, fmt.Errorf("provider: %w", err)
+	}
+	for name, resourceSchema := range schemaJSON.ResourceSchemas {
+		if schema.ResourceTypes[name], err = unmarshalSchema(resourceSchema); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	for name, dataSourceSchema := range schemaJSON.DataSourceSchemas {
+		if schema.DataSources[name], err = unmarshalSchema(dataSourceSchema); err != nil {
+			return nil, fmt.Errorf("data source %s: %w", name, err)
+		}
+	}
+	return schema, nil
+}
+
+func unmarshalSchema(schemaJSON *SchemaJSON) (providers.Schema, error) {
+	if schemaJSON == nil {
+		return providers.Schema{Block: &configschema.Block{}}, nil
+	}
+	block, err := unmarshalBlock(schemaJSON.Block)
+	if err != nil {
+		return providers.Schema{}, err
+	}
+	return providers.Schema{Version: schemaJSON.Version, Block: block}, nil
+}
+
+func unmarshalBlock(blockJSON *BlockJSON) (*configschema.Block, error) {
+	block := &configschema.Block{
+		Attributes: map[string]*configschema.Attribute{},
+		BlockTypes: map[string]*configschema.NestedBlock{},
+	}
+	if blockJSON == nil {
+		return block, nil
+	}
+	for name, attributeJSON := range blockJSON.Attributes {
+		if attributeJSON.Type == cty.NilType {
+			return nil, fmt.Errorf("attribute %s has no type", name)
+		}
+		block.Attributes[name] = &configschema.Attribute{
+			Type:        attributeJSON.Type,
+			Description: attributeJSON.Description,
+			Required:    attributeJSON.Required,
+			Optional:    attributeJSON.Optional,
+			Computed:    attributeJSON.Computed,
+			Sensitive:   attributeJSON.Sensitive,
+		}
+	}
+	for name, nestedJSON := range blockJSON.BlockTypes {
+		nesting := configschema.NestingMode(-1)
+		for mode, modeName := range nestingModes {
+			if modeName == nestedJSON.NestingMode {
+				nesting = mode
+			}
+		}
+		if nesting == -1 {
+			return nil, fmt.Errorf("block %s has unknown nesting_mode %q", name, nestedJSON.NestingMode)
+		}
+		nestedBlock, err := unmarshalBlock(nestedJSON.Block)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		block.BlockTypes[name] = &configschema.NestedBlock{
+			Block:    *nestedBlock,
+			Nesting:  nesting,
+			MinItems: nestedJSON.MinItems,
+			MaxItems: nestedJSON.MaxItems,
+		}
+	}
+	return block, nil
+}
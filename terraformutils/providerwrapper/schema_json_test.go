@@ -0,0 +1,111 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package providerwrapper //nolint
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/configs/configschema"
+	"github.com/hashicorp/terraform/providers"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestSchemaJSONRoundTrip(t *testing.T) {
+	schema := &providers.GetSchemaResponse{
+		Provider: providers.Schema{Block: &configschema.Block{
+			Attributes: map[string]*configschema.Attribute{"project": {Type: cty.String, Optional: true}},
+			BlockTypes: map[string]*configschema.NestedBlock{},
+		}},
+		ResourceTypes: map[string]providers.Schema{
+			"google_sql_user": {Version: 1, Block: &configschema.Block{
+				Attributes: map[string]*configschema.Attribute{
+					"name":     {Type: cty.String, Required: true},
+					"password": {Type: cty.String, Optional: true, Sensitive: true},
+					"labels":   {Type: cty.Map(cty.String), Optional: true, Computed: true},
+				},
+				BlockTypes: map[string]*configschema.NestedBlock{
+					"password_policy": {
+						Nesting:  configschema.NestingList,
+						MaxItems: 1,
+						Block: configschema.Block{
+							Attributes: map[string]*configschema.Attribute{
+								"allowed_failed_attempts": {Type: cty.Number, Optional: true},
+							},
+							BlockTypes: map[string]*configschema.NestedBlock{},
+						},
+					},
+				},
+			}},
+		},
+		DataSources: map[string]providers.Schema{},
+	}
+	data, err := MarshalSchemaJSON(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unmarshaled, err := UnmarshalSchemaJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(unmarshaled, schema) {
+		t.Errorf("schema changed by a round trip through\n%s", data)
+	}
+}
+
+func TestUnmarshalSchemaJSON(t *testing.T) {
+	data := []byte(`{
+  "format_version": "0.1",
+  "provider_schemas": {
+    "registry.terraform.io/hashicorp/google": {
+      "resource_schemas": {
+        "google_compute_network": {
+          "version": 0,
+          "block": {
+            "attributes": {
+              "name": {"type": "string", "required": true},
+              "tags": {"type": ["set", "string"], "optional": true}
+            },
+            "block_types": {
+              "timeouts": {"nesting_mode": "single", "block": {"attributes": {"create": {"type": "string", "optional": true}}}}
+            }
+          }
+        }
+      }
+    }
+  }
+}`)
+	schema, err := UnmarshalSchemaJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := schema.ResourceTypes["google_compute_network"].Block
+	if block == nil || !block.Attributes["tags"].Type.Equals(cty.Set(cty.String)) || !block.Attributes["name"].Required {
+		t.Fatalf("unexpected block %+v", block)
+	}
+	if block.BlockTypes["timeouts"].Nesting != configschema.NestingSingle {
+		t.Errorf("unexpected nesting %v", block.BlockTypes["timeouts"].Nesting)
+	}
+
+	for _, invalid := range []string{
+		`{"provider_schemas": {"a": {}, "b": {}}}`,
+		`{"resource_schemas": {"x": {"block": {"attributes": {"name": {"required": true}}}}}}`,
+		`{"resource_schemas": {"x": {"block": {"block_types": {"y": {"nesting_mode": "tuple"}}}}}}`,
+	} {
+		if _, err := UnmarshalSchemaJSON([]byte(invalid)); err == nil {
+			t.Errorf("expected an error for %s", invalid)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// defaultIdentityAttributes are indexed for every resource, in order of preference
+var defaultIdentityAttributes = []string{"self_link", "id", "name"}
+
+type referenceTarget struct {
+	service   string
+	resource  *Resource
+	attribute string
+	// declared is set for identity attributes declared by the provider, see matchingTargets
+	declared bool
+}
+
+// referenceGraph holds the resolved references between resources, by referencing resource
+type referenceGraph map[*Resource]map[*Resource]bool
+
+// reaches returns true when from references to, directly or through other resources
+func (g referenceGraph) reaches(from, to *Resource) bool {
+	visited := map[*Resource]bool{}
+	pending := []*Resource{from}
+	for len(pending) > 0 {
+		r := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if r == to {
+			return true
+		}
+		if visited[r] {
+			continue
+		}
+		visited[r] = true
+		for next := range g[r] {
+			pending = append(pending, next)
+		}
+	}
+	return false
+}
+
+func (g referenceGraph) add(from, to *Resource) {
+	if g[from] == nil {
+		g[from] = map[*Resource]bool{}
+	}
+	g[from][to] = true
+}
+
+// ResolveReferences replaces attribute values of imported resources which identify another imported resource
+// with a reference to it. Resources are indexed by self_link, id, name and identityAttributes of their type.
+// A value is only replaced when the attribute name mentions the resource type, e.g. network = "default"
+// references a network, or names an identity attribute of identityAttributes, e.g. email for a service account,
+// so free text like a description is never rewritten.
+// Values matching more than one resource are left untouched, as are references which would close a cycle, which
+// Terraform rejects. Resources and attributes are visited in order, so the same references are kept every run.
+//
+// References within a service, or any reference when isServicePath is false, are written as
+// type.name.attribute. Other references read the terraform_remote_state output of the other service, the
+// returned map lists the services every service reads from.
+func ResolveReferences(importResources map[string][]Resource, isServicePath bool, identityAttributes map[string][]string) (map[string][]Resource, map[string][]string) {
+	services := make([]string, 0, len(importResources))
+	for service := range importResources {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+
+	index := map[string][]referenceTarget{}
+	for _, service := range services {
+		for i := range importResources[service] {
+			r := &importResources[service][i]
+			if r.InstanceState == nil {
+				continue
+			}
+			indexed := map[string]bool{}
+			attributes := append(append([]string{}, defaultIdentityAttributes...), identityAttributes[r.InstanceInfo.Type]...)
+			for n, attribute := range attributes {
+				value := r.InstanceState.Attributes[attribute]
+				if !isReferenceable(value) || indexed[value] {
+					continue
+				}
+				indexed[value] = true
+				index[value] = append(index[value], referenceTarget{
+					service:   service,
+					resource:  r,
+					attribute: attribute,
+					declared:  n >= len(defaultIdentityAttributes),
+				})
+			}
+		}
+	}
+
+	remoteStates := map[string]map[string]bool{}
+	graph := referenceGraph{}
+	resolved, ambiguous, cycles := 0, 0, 0
+	for _, service := range services {
+		for i := range importResources[service] {
+			r := &importResources[service][i]
+			replace := func(key, value string) (string, bool) {
+				targets := matchingTargets(index[value], r, key, value)
+				if len(targets) > 1 {
+					ambiguous++
+				}
+				if len(targets) != 1 {
+					return "", false
+				}
+				target := targets[0]
+				if graph.reaches(target.resource, r) {
+					cycles++
+					return "", false
+				}
+				graph.add(r, target.resource)
+				resolved++
+				address := target.resource.InstanceInfo.Type + "." + target.resource.ResourceName
+				if !isServicePath || target.service == service {
+					return "${" + address + "." + target.attribute + "}", true
+				}
+				if remoteStates[service] == nil {
+					remoteStates[service] = map[string]bool{}
+				}
+				remoteStates[service][target.service] = true
+				if !containsString(target.resource.ReferencedAttributes, target.attribute) {
+					target.resource.ReferencedAttributes = append(target.resource.ReferencedAttributes, target.attribute)
+				}
+				output := target.resource.InstanceInfo.Type + "_" + target.resource.ResourceName + "_" + target.attribute
+				return "${data.terraform_remote_state." + target.service + ".outputs." + output + "}", true
+			}
+			for _, key := range sortedKeys(r.Item) {
+				r.Item[key] = replaceReferences(key, r.Item[key], replace)
+			}
+		}
+	}
+	if resolved > 0 || ambiguous > 0 || cycles > 0 {
+		log.Printf("resolved %d references, skipped %d ambiguous values and %d references closing a cycle", resolved, ambiguous, cycles)
+	}
+
+	readServices := map[string][]string{}
+	for service, targets := range remoteStates {
+		for target := range targets {
+			readServices[service] = append(readServices[service], target)
+		}
+		sort.Strings(readServices[service])
+	}
+	return importResources, readServices
+}
+
+// isReferenceable excludes values too common to identify a resource, like booleans and numbers
+func isReferenceable(value string) bool {
+	if len(value) < 3 || value == "true" || value == "false" || strings.Contains(value, "${") {
+		return false
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return false
+	}
+	return true
+}
+
+// matchingTargets returns the distinct resources value of key may reference from r: key mentions the type of the
+// resource, e.g. network, or a declared identity attribute, e.g. email
+func matchingTargets(candidates []referenceTarget, r *Resource, key, value string) []referenceTarget {
+	targets := []referenceTarget{}
+	for _, target := range candidates {
+		if target.resource == r {
+			continue
+		}
+		if !strings.Contains(key, typeNoun(*target.resource)) && !(target.declared && strings.Contains(key, target.attribute)) {
+			continue
+		}
+		targets = append(targets, target)
+	}
+	return targets
+}
+
+// typeNoun returns the last word of the resource type, e.g. network for google_compute_network
+func typeNoun(r Resource) string {
+	words := strings.Split(r.InstanceInfo.Type, "_")
+	return words[len(words)-1]
+}
+
+// replaceReferences walks an item value and replaces strings with references, key is the closest map key
+func replaceReferences(key string, value interface{}, replace func(key, value string) (string, bool)) interface{} {
+	switch v := value.(type) {
+	case string:
+		if reference, ok := replace(key, v); ok {
+			return reference
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = replaceReferences(key, v[i], replace)
+		}
+	case []string:
+		for i := range v {
+			if reference, ok := replace(key, v[i]); ok {
+				v[i] = reference
+			}
+		}
+	case map[string]interface{}:
+		for _, k := range sortedKeys(v) {
+			v[k] = replaceReferences(k, v[k], replace)
+		}
+	case []map[string]interface{}:
+		for i := range v {
+			for _, k := range sortedKeys(v[i]) {
+				v[i][k] = replaceReferences(k, v[i][k], replace)
+			}
+		}
+	}
+	return value
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"reflect"
+	"testing"
+)
+
+const testNetworkSelfLink = "https://www.googleapis.com/compute/v1/projects/p/global/networks/default"
+
+func TestResolveReferencesWithinService(t *testing.T) {
+	importResources := map[string][]Resource{
+		"compute": {
+			prepare("net-id", "google_compute_network", map[string]string{
+				"name":      "default",
+				"self_link": testNetworkSelfLink,
+			}, map[string]interface{}{"name": "default"}),
+			prepare("fw-id", "google_compute_firewall", map[string]string{
+				"network": testNetworkSelfLink,
+			}, map[string]interface{}{
+				"network":     testNetworkSelfLink,
+				"description": "default",
+				"allow":       []interface{}{map[string]interface{}{"protocol": "tcp"}},
+			}),
+			prepare("sub-id", "google_compute_subnetwork", map[string]string{}, map[string]interface{}{
+				"network": "default",
+			}),
+		},
+	}
+	resources, remoteStates := ResolveReferences(importResources, true, nil)
+
+	if len(remoteStates) != 0 {
+		t.Errorf("unexpected remote states %v", remoteStates)
+	}
+	firewall := resources["compute"][1].Item
+	if firewall["network"] != "${google_compute_network.tfer--name-google_compute_network.self_link}" {
+		t.Errorf("failed to resolve self link %v", firewall)
+	}
+	if firewall["description"] != "default" {
+		t.Errorf("unexpected reference from an unrelated attribute %v", firewall)
+	}
+	if resources["compute"][2].Item["network"] != "${google_compute_network.tfer--name-google_compute_network.name}" {
+		t.Errorf("failed to resolve name %v", resources["compute"][2].Item)
+	}
+	if resources["compute"][0].Item["name"] != "default" {
+		t.Errorf("unexpected self reference %v", resources["compute"][0].Item)
+	}
+}
+
+func TestResolveReferencesAcrossServices(t *testing.T) {
+	importResources := map[string][]Resource{
+		"iam": {prepare("sa-id", "google_service_account", map[string]string{
+			"email": "sa@p.iam.gserviceaccount.com",
+		}, map[string]interface{}{})},
+		"instances": {prepare("vm-id", "google_compute_instance", map[string]string{}, map[string]interface{}{
+			"service_account": []interface{}{map[string]interface{}{
+				"email": "sa@p.iam.gserviceaccount.com",
+			}},
+		})},
+	}
+	identities := map[string][]string{"google_service_account": {"email"}}
+	resources, remoteStates := ResolveReferences(importResources, true, identities)
+
+	expected := map[string]interface{}{
+		"service_account": []interface{}{map[string]interface{}{
+			"email": "${data.terraform_remote_state.iam.outputs.google_service_account_tfer--name-google_service_account_email}",
+		}},
+	}
+	if !reflect.DeepEqual(resources["instances"][0].Item, expected) {
+		t.Errorf("failed to resolve email %v", resources["instances"][0].Item)
+	}
+	if !reflect.DeepEqual(remoteStates, map[string][]string{"instances": {"iam"}}) {
+		t.Errorf("unexpected remote states %v", remoteStates)
+	}
+	if !reflect.DeepEqual(resources["iam"][0].ReferencedAttributes, []string{"email"}) {
+		t.Errorf("unexpected referenced attributes %v", resources["iam"][0].ReferencedAttributes)
+	}
+}
+
+func TestResolveReferencesSkipsAmbiguousValues(t *testing.T) {
+	importResources := map[string][]Resource{
+		"networks": {
+			prepare("net-1", "google_compute_network", map[string]string{"name": "shared"}, map[string]interface{}{}),
+		},
+		"otherNetworks": {
+			prepare("net-2", "google_compute_network", map[string]string{"name": "shared"}, map[string]interface{}{}),
+		},
+		"firewall": {
+			prepare("fw-id", "google_compute_firewall", map[string]string{}, map[string]interface{}{
+				"network":  "shared",
+				"priority": "1000",
+			}),
+		},
+	}
+	resources, _ := ResolveReferences(importResources, false, nil)
+
+	if !reflect.DeepEqual(resources["firewall"][0].Item, map[string]interface{}{"network": "shared", "priority": "1000"}) {
+		t.Errorf("unexpected reference %v", resources["firewall"][0].Item)
+	}
+}
+
+func TestResolveReferencesSkipsFreeText(t *testing.T) {
+	importResources := map[string][]Resource{
+		"compute": {
+			prepare("net-id", "google_compute_network", map[string]string{"self_link": testNetworkSelfLink}, map[string]interface{}{}),
+			prepare("route-id", "google_compute_route", map[string]string{}, map[string]interface{}{
+				"description": testNetworkSelfLink,
+			}),
+		},
+	}
+	resources, _ := ResolveReferences(importResources, false, nil)
+	if resources["compute"][1].Item["description"] != testNetworkSelfLink {
+		t.Errorf("unexpected reference from a description %v", resources["compute"][1].Item)
+	}
+}
+
+func TestResolveReferencesSkipsCycles(t *testing.T) {
+	importResources := map[string][]Resource{
+		"compute": {
+			prepare("net-id", "google_compute_network", map[string]string{"name": "net"}, map[string]interface{}{
+				"router": "router",
+			}),
+			prepare("router-id", "google_compute_router", map[string]string{"name": "router"}, map[string]interface{}{
+				"network": "net",
+			}),
+		},
+	}
+	resources, _ := ResolveReferences(importResources, false, nil)
+	network, router := resources["compute"][0].Item, resources["compute"][1].Item
+	if network["router"] != "${google_compute_router.tfer--name-google_compute_router.name}" {
+		t.Errorf("failed to resolve router %v", network)
+	}
+	if router["network"] != "net" {
+		t.Errorf("unexpected reference closing a cycle %v", router)
+	}
+}
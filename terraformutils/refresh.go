@@ -0,0 +1,379 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/providerwrapper"
+	"github.com/hashicorp/terraform/terraform"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultParallelism is the number of concurrent refreshes of all resource types
+	DefaultParallelism = 15
+	// slowQueryRate is the rate of resource types with SlowQueryRequired resources, unless set with --rate-limit
+	slowQueryRate = 5
+	// throttledRate is the first rate of a resource type without rate limit once its API throttles
+	throttledRate = 10
+	// minRate is the lowest rate adaptation throttles a resource type to
+	minRate = 0.1
+	// recoverAfter is the number of successful refreshes after which a throttled rate is raised again
+	recoverAfter = 10
+)
+
+// RefreshOptions configures the refresh scheduler
+type RefreshOptions struct {
+	// Parallelism caps the concurrent refreshes of all resource types
+	Parallelism int
+	// TypeParallelism caps the concurrent refreshes of a resource type
+	TypeParallelism map[string]int
+	// RateLimits are the refreshes per second of a resource type, the type "*" applies to all other types
+	RateLimits map[string]float64
+	// RetryCount is the number of reads of a resource before it is imported by ID instead
+	RetryCount int
+	// RetryDelay is the delay before the first retry, it doubles with every retry
+	RetryDelay time.Duration
+	// Checkpoint restores resources refreshed by a previous run and records refreshed resources, if set
+	Checkpoint *Checkpoint
+	// Report records the outcome of every refresh, if set
+	Report *ScopeReport
+	// Logger receives the log of the refresh, the standard logger when nil
+	Logger *slog.Logger
+	// Slots are the slots of Parallelism, shared by the schedulers of the scopes of a command so that Parallelism
+	// caps their refreshes together, see NewRefreshSlots. The scheduler has its own when nil.
+	Slots chan struct{}
+}
+
+// NewRefreshSlots returns the slots of the parallelism of RefreshOptions, to be shared by several schedulers
+func NewRefreshSlots(options RefreshOptions) chan struct{} {
+	if options.Parallelism < 1 {
+		options.Parallelism = DefaultParallelism
+	}
+	return make(chan struct{}, options.Parallelism)
+}
+
+func DefaultRefreshOptions() RefreshOptions {
+	return RefreshOptions{
+		Parallelism: DefaultParallelism,
+		RetryCount:  5,
+		RetryDelay:  300 * time.Millisecond,
+	}
+}
+
+// ParseParallelism parses --parallelism values, either the total number of concurrent refreshes or
+// type=N to limit the concurrent refreshes of a resource type
+func ParseParallelism(values []string, options *RefreshOptions) error {
+	for _, value := range values {
+		resourceType, limit := "", value
+		if i := strings.Index(value, "="); i != -1 {
+			resourceType, limit = value[:i], value[i+1:]
+		}
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid parallelism %q, expected N or type=N with N > 0", value)
+		}
+		if resourceType == "" {
+			options.Parallelism = n
+			continue
+		}
+		if options.TypeParallelism == nil {
+			options.TypeParallelism = map[string]int{}
+		}
+		options.TypeParallelism[resourceType] = n
+	}
+	return nil
+}
+
+// ParseRateLimits parses --rate-limit values like google_compute_instance=10/s or *=600/m
+func ParseRateLimits(values []string, options *RefreshOptions) error {
+	for _, value := range values {
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return fmt.Errorf("invalid rate limit %q, expected type=N/s", value)
+		}
+		limit, unit := parts[1], time.Second
+		if i := strings.Index(limit, "/"); i != -1 {
+			switch limit[i+1:] {
+			case "s":
+			case "m":
+				unit = time.Minute
+			default:
+				return fmt.Errorf("invalid rate limit %q, expected type=N/s or type=N/m", value)
+			}
+			limit = limit[:i]
+		}
+		n, err := strconv.ParseFloat(limit, 64)
+		if err != nil || n <= 0 {
+			return fmt.Errorf("invalid rate limit %q, expected a positive number", value)
+		}
+		if options.RateLimits == nil {
+			options.RateLimits = map[string]float64{}
+		}
+		options.RateLimits[parts[0]] = n / unit.Seconds()
+	}
+	return nil
+}
+
+// ResourceReader reads resources from a provider, implemented by providerwrapper.ProviderWrapper
+type ResourceReader interface {
+	ReadResource(ctx context.Context, info *terraform.InstanceInfo, state *terraform.InstanceState) (*terraform.InstanceState, error)
+	ImportResource(ctx context.Context, info *terraform.InstanceInfo, state *terraform.InstanceState) (*terraform.InstanceState, error)
+}
+
+// RefreshScheduler refreshes resources with a worker pool per resource type sharing a total parallelism.
+// Every type is paced by a token bucket, whose rate is halved when the API throttles and raised again
+// after a series of successful refreshes, up to the configured rate limit.
+type RefreshScheduler struct {
+	reader  ResourceReader
+	options RefreshOptions
+	slots   chan struct{}
+}
+
+func NewRefreshScheduler(reader ResourceReader, options RefreshOptions) *RefreshScheduler {
+	if options.Parallelism < 1 {
+		options.Parallelism = DefaultParallelism
+	}
+	if options.RetryCount < 1 {
+		options.RetryCount = 1
+	}
+	slots := options.Slots
+	if slots == nil {
+		slots = NewRefreshSlots(options)
+	}
+	return &RefreshScheduler{
+		reader:  reader,
+		options: options,
+		slots:   slots,
+	}
+}
+
+// typeScheduler paces the refreshes of a resource type
+type typeScheduler struct {
+	resourceType string
+	limiter      *rate.Limiter
+	// maxRate is the configured rate limit, rate.Inf without
+	maxRate   rate.Limit
+	logger    *slog.Logger
+	mu        sync.Mutex
+	successes int
+}
+
+func (s *RefreshScheduler) newTypeScheduler(resourceType string, slow bool) *typeScheduler {
+	maxRate := rate.Inf
+	if limit, ok := s.options.RateLimits["*"]; ok {
+		maxRate = rate.Limit(limit)
+	}
+	if slow {
+		maxRate = slowQueryRate
+	}
+	if limit, ok := s.options.RateLimits[resourceType]; ok {
+		maxRate = rate.Limit(limit)
+	}
+	return &typeScheduler{
+		resourceType: resourceType,
+		limiter:      rate.NewLimiter(maxRate, 1),
+		maxRate:      maxRate,
+		logger:       s.options.Logger,
+	}
+}
+
+// throttled halves the rate of the type
+func (t *typeScheduler) throttled() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.successes = 0
+	limit := t.limiter.Limit()
+	if limit == rate.Inf {
+		limit = throttledRate * 2
+	}
+	limit = rate.Limit(math.Max(float64(limit)/2, minRate))
+	if limit != t.limiter.Limit() {
+		Logf(t.logger, "%s is throttled, lowering the refresh rate to %.2f/s", t.resourceType, float64(limit))
+		t.limiter.SetLimit(limit)
+	}
+}
+
+// succeeded raises a throttled rate by a quarter after recoverAfter successful refreshes
+func (t *typeScheduler) succeeded() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	limit := t.limiter.Limit()
+	if limit >= t.maxRate {
+		return
+	}
+	t.successes++
+	if t.successes < recoverAfter {
+		return
+	}
+	t.successes = 0
+	limit *= 1.25
+	if limit >= t.maxRate || (t.maxRate == rate.Inf && limit > throttledRate*10) {
+		limit = t.maxRate
+	}
+	t.limiter.SetLimit(limit)
+}
+
+// Refresh refreshes resources and sets their state, the state of resources which could not be read is nil.
+// Once ctx is canceled no refresh is started, resources which weren't refreshed keep their state and are
+// missing from the report.
+func (s *RefreshScheduler) Refresh(ctx context.Context, resources []*Resource) {
+	byType := map[string][]*Resource{}
+	slowTypes := map[string]bool{}
+	restored := 0
+	for _, r := range resources {
+		if s.options.Checkpoint != nil && s.options.Checkpoint.Restore(r) {
+			s.options.Report.Refreshed(r, r.InstanceState.ID, RefreshReport{Status: StatusRestored})
+			restored++
+			continue
+		}
+		byType[r.InstanceInfo.Type] = append(byType[r.InstanceInfo.Type], r)
+		if r.SlowQueryRequired {
+			slowTypes[r.InstanceInfo.Type] = true
+		}
+	}
+	if restored > 0 {
+		Logf(s.options.Logger, "restored %d refreshed resources from the checkpoint", restored)
+	}
+	types := make([]string, 0, len(byType))
+	for resourceType := range byType {
+		types = append(types, resourceType)
+	}
+	sort.Strings(types)
+
+	var wg sync.WaitGroup
+	for _, resourceType := range types {
+		t := s.newTypeScheduler(resourceType, slowTypes[resourceType])
+		workers := s.options.Parallelism
+		if slowTypes[resourceType] {
+			workers = 1
+		}
+		if n, ok := s.options.TypeParallelism[resourceType]; ok {
+			workers = n
+		}
+		if workers > len(byType[resourceType]) {
+			workers = len(byType[resourceType])
+		}
+		input := make(chan *Resource, len(byType[resourceType]))
+		for _, r := range byType[resourceType] {
+			input <- r
+		}
+		close(input)
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for r := range input {
+					if ctx.Err() != nil {
+						return
+					}
+					Logf(s.options.Logger, "Refreshing state... %s", r.InstanceInfo.Id)
+					s.refresh(ctx, t, r)
+				}
+			}()
+		}
+	}
+	wg.Wait()
+}
+
+func (s *RefreshScheduler) refresh(ctx context.Context, t *typeScheduler, r *Resource) {
+	id := r.InstanceState.ID
+	start := time.Now()
+	report := s.refreshState(ctx, t, r)
+	report.DurationMs = time.Since(start).Milliseconds()
+	s.options.Report.Refreshed(r, id, report)
+	if s.options.Checkpoint != nil && r.InstanceState != nil && r.InstanceState.ID != "" {
+		if err := s.options.Checkpoint.SaveRefreshed(r, id); err != nil {
+			Logf(s.options.Logger, "failed to checkpoint %s: %v", r.InstanceInfo.Id, err)
+		}
+	}
+}
+
+// refreshState reads the state of a resource, retrying failed reads before importing it by its ID
+func (s *RefreshScheduler) refreshState(ctx context.Context, t *typeScheduler, r *Resource) RefreshReport {
+	var err error
+	report := RefreshReport{}
+	for attempt := 0; attempt < s.options.RetryCount; attempt++ {
+		var state *terraform.InstanceState
+		report.Attempts++
+		state, err = s.read(ctx, t, r, s.reader.ReadResource)
+		if err == nil {
+			t.succeeded()
+			r.InstanceState = state
+			report.Status = StatusOK
+			return report
+		}
+		if errors.Is(err, providerwrapper.ErrNullState) || ctx.Err() != nil {
+			break
+		}
+		if providerwrapper.IsThrottled(err) {
+			t.throttled()
+		}
+		if attempt+1 < s.options.RetryCount {
+			delay := providerwrapper.Backoff(s.options.RetryDelay, attempt)
+			Logf(s.options.Logger, "WARN: failed to read %s, retrying in %s: %v", r.InstanceInfo.Id, delay, err)
+			if sleepErr := providerwrapper.Sleep(ctx, delay); sleepErr != nil {
+				err = sleepErr
+				break
+			}
+		}
+	}
+	report.Status = StatusFailed
+	report.Error = err.Error()
+	if errors.Is(err, providerwrapper.ErrNullState) {
+		report.Status = StatusDeleted
+	} else if ctx.Err() != nil {
+		report.Error = context.Cause(ctx).Error()
+	} else {
+		Logf(s.options.Logger, "failed to read %s, importing it by ID", r.InstanceInfo.Id)
+		state, importErr := s.read(ctx, t, r, s.reader.ImportResource)
+		if importErr == nil {
+			r.InstanceState = state
+			report.Status = StatusImported
+			return report
+		}
+		report.Error = importErr.Error()
+	}
+	Logf(s.options.Logger, "%v", err)
+	r.InstanceState = nil
+	return report
+}
+
+// read waits for a token of the type and a free slot of the total parallelism before calling the provider
+func (s *RefreshScheduler) read(ctx context.Context, t *typeScheduler, r *Resource, read func(context.Context, *terraform.InstanceInfo, *terraform.InstanceState) (*terraform.InstanceState, error)) (*terraform.InstanceState, error) {
+	if err := t.limiter.Wait(ctx); err != nil {
+		if ctx.Err() != nil {
+			return nil, context.Cause(ctx)
+		}
+		return nil, err
+	}
+	select {
+	case s.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, context.Cause(ctx)
+	}
+	defer func() { <-s.slots }()
+	return read(ctx, r.InstanceInfo, r.InstanceState)
+}
@@ -0,0 +1,259 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/providerwrapper"
+	"github.com/hashicorp/terraform/terraform"
+	"golang.org/x/time/rate"
+)
+
+// fakeReader fails the first reads of every resource with failures and records the concurrency per type
+type fakeReader struct {
+	mu          sync.Mutex
+	failures    map[string][]error
+	reads       map[string]int
+	imports     map[string]int
+	running     map[string]int
+	maxRunning  map[string]int
+	readLatency time.Duration
+}
+
+func newFakeReader(failures map[string][]error) *fakeReader {
+	return &fakeReader{
+		failures:   failures,
+		reads:      map[string]int{},
+		imports:    map[string]int{},
+		running:    map[string]int{},
+		maxRunning: map[string]int{},
+	}
+}
+
+func (f *fakeReader) ReadResource(ctx context.Context, info *terraform.InstanceInfo, state *terraform.InstanceState) (*terraform.InstanceState, error) {
+	f.mu.Lock()
+	f.running[info.Type]++
+	if f.running[info.Type] > f.maxRunning[info.Type] {
+		f.maxRunning[info.Type] = f.running[info.Type]
+	}
+	attempt := f.reads[state.ID]
+	f.reads[state.ID]++
+	f.mu.Unlock()
+
+	time.Sleep(f.readLatency)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.running[info.Type]--
+	if attempt < len(f.failures[state.ID]) {
+		return nil, f.failures[state.ID][attempt]
+	}
+	return &terraform.InstanceState{ID: state.ID, Attributes: map[string]string{"id": state.ID}}, nil
+}
+
+func (f *fakeReader) ImportResource(ctx context.Context, info *terraform.InstanceInfo, state *terraform.InstanceState) (*terraform.InstanceState, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.imports[state.ID]++
+	return &terraform.InstanceState{ID: state.ID, Attributes: map[string]string{"imported": "true"}}, nil
+}
+
+func refreshTestResources(resourceType string, n int) []*Resource {
+	resources := []*Resource{}
+	for i := 0; i < n; i++ {
+		r := prepareNoAttrs(fmt.Sprintf("%s-%d", resourceType, i), resourceType)
+		resources = append(resources, &r)
+	}
+	return resources
+}
+
+func TestRefreshSchedulerRetries(t *testing.T) {
+	failure := errors.New("connection reset")
+	reader := newFakeReader(map[string][]error{
+		"a-0": {failure},
+		"a-1": {failure, failure, failure},
+		"a-2": {fmt.Errorf("gone: %w", providerwrapper.ErrNullState)},
+	})
+	options := DefaultRefreshOptions()
+	options.RetryCount = 3
+	options.RetryDelay = time.Millisecond
+	resources := refreshTestResources("a", 3)
+	NewRefreshScheduler(reader, options).Refresh(context.Background(), resources)
+
+	if resources[0].InstanceState == nil || resources[0].InstanceState.Attributes["id"] != "a-0" || reader.reads["a-0"] != 2 {
+		t.Errorf("expected a-0 to be read on the second attempt, got %v after %d reads", resources[0].InstanceState, reader.reads["a-0"])
+	}
+	if resources[1].InstanceState == nil || resources[1].InstanceState.Attributes["imported"] != "true" || reader.reads["a-1"] != 3 {
+		t.Errorf("expected a-1 to be imported after 3 reads, got %v after %d reads", resources[1].InstanceState, reader.reads["a-1"])
+	}
+	if resources[2].InstanceState != nil || reader.reads["a-2"] != 1 || reader.imports["a-2"] != 0 {
+		t.Errorf("expected a-2 to be dropped after 1 read, got %v after %d reads", resources[2].InstanceState, reader.reads["a-2"])
+	}
+}
+
+func TestRefreshSchedulerParallelism(t *testing.T) {
+	reader := newFakeReader(map[string][]error{})
+	reader.readLatency = 5 * time.Millisecond
+	options := DefaultRefreshOptions()
+	options.Parallelism = 4
+	options.TypeParallelism = map[string]int{"b": 2}
+	slow := refreshTestResources("slow", 4)
+	for _, r := range slow {
+		r.SlowQueryRequired = true
+	}
+	resources := append(append(refreshTestResources("a", 20), refreshTestResources("b", 20)...), slow...)
+	NewRefreshScheduler(reader, options).Refresh(context.Background(), resources)
+
+	for resourceType, limit := range map[string]int{"a": 4, "b": 2, "slow": 1} {
+		if reader.maxRunning[resourceType] > limit {
+			t.Errorf("expected at most %d concurrent refreshes of %s, got %d", limit, resourceType, reader.maxRunning[resourceType])
+		}
+	}
+	for _, r := range resources {
+		if r.InstanceState == nil {
+			t.Errorf("%s was not refreshed", r.InstanceInfo.Id)
+		}
+	}
+}
+
+func TestRefreshSchedulerSharedSlots(t *testing.T) {
+	reader := newFakeReader(map[string][]error{})
+	reader.readLatency = 5 * time.Millisecond
+	options := DefaultRefreshOptions()
+	options.Parallelism = 2
+	options.Slots = NewRefreshSlots(options)
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			NewRefreshScheduler(reader, options).Refresh(context.Background(), refreshTestResources("a", 6))
+		}()
+	}
+	wg.Wait()
+	if reader.maxRunning["a"] > 2 {
+		t.Errorf("expected at most 2 concurrent refreshes of all schedulers, got %d", reader.maxRunning["a"])
+	}
+}
+
+func TestRefreshSchedulerCanceled(t *testing.T) {
+	failure := errors.New("connection reset")
+	reader := newFakeReader(map[string][]error{"a-0": {failure, failure}})
+	reader.readLatency = 10 * time.Millisecond
+	options := DefaultRefreshOptions()
+	options.Parallelism = 2
+	options.RetryDelay = time.Minute
+	options.Report = NewImportReport().NewScope("google", nil)
+	resources := refreshTestResources("a", 50)
+	listed := []Resource{}
+	for _, r := range resources {
+		listed = append(listed, *r)
+	}
+	options.Report.Listed("a", listed, 0, false)
+	ctx, cancel := context.WithCancelCause(context.Background())
+	interrupted := errors.New("interrupted")
+	time.AfterFunc(50*time.Millisecond, func() { cancel(interrupted) })
+
+	start := time.Now()
+	refreshed, err := RefreshResources(ctx, resources, reader, options)
+	if !errors.Is(err, interrupted) || refreshed != nil {
+		t.Errorf("expected the cause of the cancellation, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the refresh to stop when canceled, took %s", elapsed)
+	}
+	if reader.reads["a-0"] != 1 || reader.imports["a-0"] != 0 {
+		t.Errorf("expected the retry of a-0 to be canceled, got %d reads", reader.reads["a-0"])
+	}
+	if n := len(reader.reads); n == len(resources) {
+		t.Errorf("expected refreshes not to start once canceled, %d were read", n)
+	}
+	report := options.Report.resources["a.a-0"]
+	if report.Refresh == nil || report.Refresh.Status != StatusFailed || report.Refresh.Error != "interrupted" {
+		t.Errorf("unexpected report of a-0 %+v", report)
+	}
+}
+
+func TestRefreshSchedulerThrottling(t *testing.T) {
+	throttled := errors.New("googleapi: Error 429: Quota exceeded for quota metric 'Read requests'")
+	if !providerwrapper.IsThrottled(throttled) {
+		t.Fatalf("expected %q to be throttled", throttled)
+	}
+	s := NewRefreshScheduler(newFakeReader(nil), RefreshOptions{RateLimits: map[string]float64{"a": 8}})
+	ts := s.newTypeScheduler("a", false)
+	ts.throttled()
+	if ts.limiter.Limit() != 4 {
+		t.Errorf("expected the rate to be halved to 4/s, got %v", ts.limiter.Limit())
+	}
+	for i := 0; i < recoverAfter; i++ {
+		ts.succeeded()
+	}
+	if ts.limiter.Limit() != 5 {
+		t.Errorf("expected the rate to be raised to 5/s, got %v", ts.limiter.Limit())
+	}
+	for i := 0; i < 10*recoverAfter; i++ {
+		ts.succeeded()
+	}
+	if ts.limiter.Limit() != 8 {
+		t.Errorf("expected the rate to recover to the limit 8/s, got %v", ts.limiter.Limit())
+	}
+
+	unlimited := s.newTypeScheduler("b", false)
+	unlimited.throttled()
+	if unlimited.limiter.Limit() != throttledRate {
+		t.Errorf("expected an unlimited type to be throttled to %d/s, got %v", throttledRate, unlimited.limiter.Limit())
+	}
+	if slow := s.newTypeScheduler("c", true); slow.limiter.Limit() != slowQueryRate {
+		t.Errorf("expected a slow type to start at %d/s, got %v", slowQueryRate, slow.limiter.Limit())
+	}
+	if other := s.newTypeScheduler("c", false); other.limiter.Limit() != rate.Inf {
+		t.Errorf("expected no rate limit, got %v", other.limiter.Limit())
+	}
+}
+
+func TestParseRefreshOptions(t *testing.T) {
+	options := DefaultRefreshOptions()
+	if err := ParseParallelism([]string{"20", "google_sql_database_instance=2"}, &options); err != nil {
+		t.Fatal(err)
+	}
+	if err := ParseRateLimits([]string{"google_compute_instance=10/s", "*=600/m", "google_dns_record_set=2"}, &options); err != nil {
+		t.Fatal(err)
+	}
+	if options.Parallelism != 20 || !reflect.DeepEqual(options.TypeParallelism, map[string]int{"google_sql_database_instance": 2}) {
+		t.Errorf("unexpected parallelism %d %v", options.Parallelism, options.TypeParallelism)
+	}
+	expected := map[string]float64{"google_compute_instance": 10, "*": 10, "google_dns_record_set": 2}
+	if !reflect.DeepEqual(options.RateLimits, expected) {
+		t.Errorf("unexpected rate limits %v", options.RateLimits)
+	}
+
+	for _, value := range []string{"0", "a=b", "-1"} {
+		if err := ParseParallelism([]string{value}, &options); err == nil {
+			t.Errorf("expected an error for parallelism %s", value)
+		}
+	}
+	for _, value := range []string{"10/s", "a=10/h", "a=0", "=1"} {
+		if err := ParseRateLimits([]string{value}, &options); err == nil {
+			t.Errorf("expected an error for rate limit %s", value)
+		}
+	}
+}
@@ -0,0 +1,390 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ReportVersion is the version of the report format
+const ReportVersion = 1
+
+// Statuses of an import, a scope, a service or a step of a resource
+const (
+	StatusComplete = "complete"
+	StatusPartial  = "partial"
+	StatusFailed   = "failed"
+	StatusSkipped  = "skipped"
+	StatusOK       = "ok"
+	// StatusRestored is a step done by a previous run, read from the checkpoint
+	StatusRestored = "restored"
+	// StatusImported is a refresh which fell back to importing the resource by its ID
+	StatusImported = "imported"
+	// StatusDeleted is a refresh of a resource which no longer exists
+	StatusDeleted = "deleted"
+	// StatusWritten is a resource written to a file
+	StatusWritten = "written"
+	// StatusFiltered is a resource dropped by filters after the refresh
+	StatusFiltered = "filtered"
+)
+
+// ImportReport is the machine-readable outcome of an import, written by --report
+type ImportReport struct {
+	Version int
+	// Status is StatusComplete when every service and resource was imported, StatusPartial when some failed
+	// and StatusFailed when an import was aborted
+	Status     string
+	Started    time.Time
+	Finished   time.Time
+	DurationMs int64
+	Resources  ReportCounts
+	Scopes     []*ScopeReport
+
+	mu sync.Mutex
+}
+
+// ReportCounts counts the resources of a report by their final status
+type ReportCounts struct {
+	Listed   int
+	Written  int
+	Failed   int
+	Deleted  int
+	Filtered int
+}
+
+// ScopeReport is the outcome of the import of a provider scope, e.g. a project and region
+type ScopeReport struct {
+	Provider   string
+	Args       []string `json:",omitempty"`
+	Status     string
+	Error      string `json:",omitempty"`
+	Started    time.Time
+	Finished   time.Time
+	DurationMs int64
+	Services   []*ServiceReport
+
+	mu        sync.Mutex
+	services  map[string]*ServiceReport
+	resources map[string]*ResourceReport
+}
+
+// ServiceReport is the outcome of a service
+type ServiceReport struct {
+	Name   string
+	Status string
+	Error  string `json:",omitempty"`
+	// List is StatusOK, StatusRestored or StatusFailed
+	List   string
+	ListMs int64
+	Path   string `json:",omitempty"`
+	// PostConvert is the outcome of the PostConvertHook of the service
+	PostConvert *StepReport `json:",omitempty"`
+	Resources   []*ResourceReport
+}
+
+// ResourceReport is the outcome of every step of a resource
+type ResourceReport struct {
+	Type string
+	// ID is the import ID the resource was listed with
+	ID string
+	// Status is StatusWritten, StatusFailed, StatusDeleted or StatusFiltered
+	Status  string
+	Address string `json:",omitempty"`
+	File    string `json:",omitempty"`
+	Refresh *RefreshReport
+	Convert *StepReport `json:",omitempty"`
+}
+
+// RefreshReport is the outcome of the refresh of a resource
+type RefreshReport struct {
+	// Status is StatusOK, StatusImported when the resource was imported by its ID after failed reads,
+	// StatusDeleted, StatusRestored or StatusFailed
+	Status string
+	// Attempts counts the reads of the resource, not including the import
+	Attempts   int
+	DurationMs int64
+	Error      string `json:",omitempty"`
+}
+
+// StepReport is the outcome of a step of a resource
+type StepReport struct {
+	Status string
+	Error  string `json:",omitempty"`
+}
+
+func NewImportReport() *ImportReport {
+	return &ImportReport{Version: ReportVersion, Started: time.Now(), Scopes: []*ScopeReport{}}
+}
+
+// NewScope adds the report of a provider scope, the scope of a nil report is nil
+func (r *ImportReport) NewScope(provider string, args []string) *ScopeReport {
+	if r == nil {
+		return nil
+	}
+	s := &ScopeReport{
+		Provider:  provider,
+		Args:      args,
+		Started:   time.Now(),
+		Services:  []*ServiceReport{},
+		services:  map[string]*ServiceReport{},
+		resources: map[string]*ResourceReport{},
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Scopes = append(r.Scopes, s)
+	return s
+}
+
+// Write writes the report to path, replacing the report of a previous write
+func (r *ImportReport) Write(path string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, s := range r.Scopes {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
+	r.finish()
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return WriteFileAtomic(path, append(data, '\n'))
+}
+
+// finish sets the status and counts of the report from its scopes
+func (r *ImportReport) finish() {
+	r.Finished = time.Now()
+	r.DurationMs = r.Finished.Sub(r.Started).Milliseconds()
+	r.Status = StatusComplete
+	r.Resources = ReportCounts{}
+	for _, s := range r.Scopes {
+		switch s.Status {
+		case StatusFailed, "":
+			r.Status = StatusFailed
+		case StatusPartial:
+			if r.Status == StatusComplete {
+				r.Status = StatusPartial
+			}
+		}
+		for _, service := range s.Services {
+			for _, resource := range service.Resources {
+				r.Resources.Listed++
+				switch resource.Status {
+				case StatusWritten:
+					r.Resources.Written++
+				case StatusFailed:
+					r.Resources.Failed++
+				case StatusDeleted:
+					r.Resources.Deleted++
+				case StatusFiltered:
+					r.Resources.Filtered++
+				}
+			}
+		}
+	}
+}
+
+// The methods of a nil ScopeReport do nothing, so that plan files are written without a report.
+
+func (s *ScopeReport) service(name string) *ServiceReport {
+	service, ok := s.services[name]
+	if !ok {
+		service = &ServiceReport{Name: name, Resources: []*ResourceReport{}}
+		s.services[name] = service
+		s.Services = append(s.Services, service)
+		sort.Slice(s.Services, func(i, j int) bool { return s.Services[i].Name < s.Services[j].Name })
+	}
+	return service
+}
+
+// Listed records the resources listed by a service, restored when read from the checkpoint
+func (s *ScopeReport) Listed(service string, resources []Resource, duration time.Duration, restored bool) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	report := s.service(service)
+	report.List = StatusOK
+	if restored {
+		report.List = StatusRestored
+	}
+	report.ListMs = duration.Milliseconds()
+	for _, r := range resources {
+		resource := &ResourceReport{Type: r.InstanceInfo.Type}
+		if r.InstanceState != nil {
+			resource.ID = r.InstanceState.ID
+		}
+		report.Resources = append(report.Resources, resource)
+		s.resources[resource.Type+"."+resource.ID] = resource
+	}
+}
+
+// ServiceFailed records a service which could not be listed
+func (s *ScopeReport) ServiceFailed(service string, err error) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	report := s.service(service)
+	report.List = StatusFailed
+	report.Error = err.Error()
+}
+
+// Refreshed records the refresh of a resource listed with the import ID id
+func (s *ScopeReport) Refreshed(r *Resource, id string, refresh RefreshReport) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	resource, ok := s.resources[r.InstanceInfo.Type+"."+id]
+	if !ok {
+		return
+	}
+	resource.Refresh = &refresh
+	if r.InstanceState != nil && r.InstanceState.ID != id {
+		// later steps find the resource by its refreshed ID
+		s.resources[r.InstanceInfo.Type+"."+r.InstanceState.ID] = resource
+	}
+}
+
+// RefreshFailed reports whether the refresh of the resource of resourceType listed with the import ID id failed
+func (s *ScopeReport) RefreshFailed(resourceType, id string) bool {
+	if s == nil {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	resource, ok := s.resources[resourceType+"."+id]
+	return ok && resource.Refresh != nil && resource.Refresh.Status == StatusFailed
+}
+
+// Converted records the conversion of the refreshed state of a resource to its attributes
+func (s *ScopeReport) Converted(r *Resource, err error) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if resource := s.find(r); resource != nil {
+		resource.Convert = &StepReport{Status: StatusOK}
+		if err != nil {
+			resource.Convert = &StepReport{Status: StatusFailed, Error: err.Error()}
+		}
+	}
+}
+
+// PostConverted records the outcome of the PostConvertHook of a service
+func (s *ScopeReport) PostConverted(service string, err error) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	report := s.service(service)
+	report.PostConvert = &StepReport{Status: StatusOK}
+	if err != nil {
+		report.PostConvert = &StepReport{Status: StatusFailed, Error: err.Error()}
+	}
+}
+
+// Written records the address and file of resources written to the directory of a service
+func (s *ScopeReport) Written(service, path string, resources []Resource, location func(Resource) (address, file string)) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if service != "" {
+		s.service(service).Path = path
+	}
+	for _, r := range resources {
+		if resource := s.find(&r); resource != nil {
+			resource.Address, resource.File = location(r)
+		}
+	}
+}
+
+func (s *ScopeReport) find(r *Resource) *ResourceReport {
+	if r.InstanceState == nil {
+		return nil
+	}
+	return s.resources[r.InstanceInfo.Type+"."+r.InstanceState.ID]
+}
+
+// Finish sets the status of the scope, its services and resources, err is the error aborting the import
+func (s *ScopeReport) Finish(err error) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Finished = time.Now()
+	s.DurationMs = s.Finished.Sub(s.Started).Milliseconds()
+	if s.Status == StatusSkipped {
+		return
+	}
+	s.Status = StatusComplete
+	if err != nil {
+		s.Status = StatusFailed
+		s.Error = err.Error()
+	}
+	for _, service := range s.Services {
+		service.Status = StatusComplete
+		if service.List == StatusFailed {
+			service.Status = StatusFailed
+		} else if service.PostConvert != nil && service.PostConvert.Status == StatusFailed {
+			service.Status = StatusPartial
+		}
+		for _, resource := range service.Resources {
+			resource.Status = resourceStatus(resource)
+			if resource.Status == StatusFailed && service.Status == StatusComplete {
+				service.Status = StatusPartial
+			}
+		}
+		if service.Status != StatusComplete && s.Status == StatusComplete {
+			s.Status = StatusPartial
+		}
+	}
+}
+
+// Skipped marks a scope imported by a previous run
+func (s *ScopeReport) Skipped() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Status = StatusSkipped
+}
+
+func resourceStatus(resource *ResourceReport) string {
+	switch {
+	case resource.Convert != nil && resource.Convert.Status == StatusFailed:
+		return StatusFailed
+	case resource.File != "":
+		return StatusWritten
+	case resource.Refresh == nil || resource.Refresh.Status == StatusFailed:
+		return StatusFailed
+	case resource.Refresh.Status == StatusDeleted:
+		return StatusDeleted
+	}
+	return StatusFiltered
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/providerwrapper"
+)
+
+func TestImportReport(t *testing.T) {
+	report := NewImportReport()
+	scope := report.NewScope("google", []string{"global", "my-project"})
+
+	resources := refreshTestResources("a", 5)
+	listed := []Resource{}
+	for _, r := range resources {
+		listed = append(listed, *r)
+	}
+	scope.Listed("networks", listed, time.Second, false)
+	scope.ServiceFailed("firewall", errors.New("permission denied"))
+
+	failure := errors.New("connection reset")
+	reader := newFakeReader(map[string][]error{
+		"a-1": {failure, failure},
+		"a-2": {fmt.Errorf("gone: %w", providerwrapper.ErrNullState)},
+	})
+	options := DefaultRefreshOptions()
+	options.RetryCount = 2
+	options.RetryDelay = time.Millisecond
+	options.Report = scope
+	NewRefreshScheduler(reader, options).Refresh(context.Background(), resources)
+
+	scope.Converted(resources[0], nil)
+	scope.Converted(resources[1], nil)
+	scope.Converted(resources[3], errors.New("unsupported attribute"))
+	// a-4 is dropped by a filter, a-0 and a-1 are written
+	scope.Written("networks", "generated/networks", []Resource{*resources[0], *resources[1]}, func(r Resource) (string, string) {
+		return r.InstanceInfo.Type + "." + r.ResourceName, "generated/networks/a.tf"
+	})
+	scope.Finish(nil)
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	if err := report.Write(path); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	written := ImportReport{}
+	if err := json.Unmarshal(data, &written); err != nil {
+		t.Fatal(err)
+	}
+
+	if written.Status != StatusPartial || written.Scopes[0].Status != StatusPartial {
+		t.Errorf("expected a partial import, got %s", written.Status)
+	}
+	expectedCounts := ReportCounts{Listed: 5, Written: 2, Failed: 1, Deleted: 1, Filtered: 1}
+	if written.Resources != expectedCounts {
+		t.Errorf("unexpected counts %+v", written.Resources)
+	}
+	services := written.Scopes[0].Services
+	if len(services) != 2 || services[0].Name != "firewall" || services[0].Status != StatusFailed || services[0].Error != "permission denied" {
+		t.Fatalf("unexpected services %+v", services)
+	}
+	if services[1].Status != StatusPartial || services[1].List != StatusOK || services[1].Path != "generated/networks" {
+		t.Errorf("unexpected service %+v", services[1])
+	}
+	for i, expected := range []struct {
+		status   string
+		refresh  string
+		attempts int
+	}{
+		{StatusWritten, StatusOK, 1},
+		{StatusWritten, StatusImported, 2},
+		{StatusDeleted, StatusDeleted, 1},
+		{StatusFailed, StatusOK, 1},
+		{StatusFiltered, StatusOK, 1},
+	} {
+		resource := services[1].Resources[i]
+		if resource.Status != expected.status || resource.Refresh.Status != expected.refresh || resource.Refresh.Attempts != expected.attempts {
+			t.Errorf("%s: expected %+v, got %s %+v", resource.ID, expected, resource.Status, resource.Refresh)
+		}
+	}
+	if resource := services[1].Resources[1]; resource.File != "generated/networks/a.tf" || resource.Address != "a.tfer--name-a" || resource.Refresh.Error != failure.Error() {
+		t.Errorf("unexpected resource %+v", resource)
+	}
+}
+
+func TestScopeReportNil(t *testing.T) {
+	var report *ImportReport
+	scope := report.NewScope("google", nil)
+	r := prepareNoAttrs("ID1", "type1")
+	scope.Listed("networks", []Resource{r}, 0, false)
+	scope.Refreshed(&r, "ID1", RefreshReport{})
+	scope.Converted(&r, nil)
+	scope.Finish(nil)
+}
@@ -15,12 +15,12 @@
 package terraformutils
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"reflect"
 	"regexp"
 	"strings"
-	"time"
 
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils/providerwrapper"
 	"github.com/hashicorp/terraform/configs/configschema"
@@ -43,6 +43,13 @@ type Resource struct {
 	AdditionalFields  map[string]interface{} `json:",omitempty"`
 	SlowQueryRequired bool
 	DataFiles         map[string][]byte
+	// ReferencedAttributes are read by resources of other services through terraform_remote_state outputs
+	ReferencedAttributes []string `json:",omitempty"`
+	// SensitiveAttributes are the dot separated paths of the attributes the provider schema marks as sensitive,
+	// e.g. settings.password, set by ConvertTFstate and redacted by RedactSecrets
+	SensitiveAttributes []string `json:",omitempty"`
+	// schema of the resource type, set by ConvertTFstate. HCL files are written without it after a planfile.
+	schema *configschema.Block
 }
 
 type ApplicableFilter interface {
@@ -127,12 +134,9 @@ func NewSimpleResource(id, resourceName, resourceType, provider string, allowEmp
 	)
 }
 
-func (r *Resource) Refresh(provider *providerwrapper.ProviderWrapper) {
+func (r *Resource) Refresh(ctx context.Context, provider *providerwrapper.ProviderWrapper) {
 	var err error
-	if r.SlowQueryRequired {
-		time.Sleep(200 * time.Millisecond)
-	}
-	r.InstanceState, err = provider.Refresh(r.InstanceInfo, r.InstanceState)
+	r.InstanceState, err = provider.Refresh(ctx, r.InstanceInfo, r.InstanceState)
 	if err != nil {
 		log.Println(err)
 	}
@@ -185,6 +189,8 @@ func (r *Resource) ConvertTFstate(provider *providerwrapper.ProviderWrapper) err
 
 	// Add the new call to the cleanup function here, after the state has been parsed into r.Item
 	r.CleanUpOptionalEmptyAttributes(resourceSchema.Block)
+	r.schema = resourceSchema.Block
+	r.SensitiveAttributes = sensitiveAttributes(resourceSchema.Block, "")
 
 	return nil
 }
@@ -0,0 +1,73 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command generator writes the resource types created by the generators of the providers to
+// terraformutils/resourcetypes, run it from the root of the repository:
+//
+//	go run terraformutils/resourcetypes/generator/main.go
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/resourcetypes"
+)
+
+const header = `// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// AUTO-GENERATED CODE. DO NOT EDIT.
+package resourcetypes
+
+// generatedResourceTypes are the resource types created by the generators of the providers, sorted
+var generatedResourceTypes = []string{
+`
+
+func main() {
+	types, err := resourcetypes.Scan("providers")
+	if err != nil {
+		log.Fatal(err)
+	}
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	for _, t := range types {
+		buf.WriteString(strconv.Quote(t) + ",\n")
+	}
+	buf.WriteString("}\n")
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	path := filepath.Join("terraformutils", "resourcetypes", "resource_types_gen.go")
+	if err := os.WriteFile(path, code, 0644); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("%d resource types written to %s", len(types), path)
+}
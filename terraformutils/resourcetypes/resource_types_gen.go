@@ -0,0 +1,1021 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// AUTO-GENERATED CODE. DO NOT EDIT.
+package resourcetypes
+
+// generatedResourceTypes are the resource types created by the generators of the providers, sorted
+var generatedResourceTypes = []string{
+	"alicloud_alidns_domain",
+	"alicloud_alidns_record",
+	"alicloud_db_instance",
+	"alicloud_instance",
+	"alicloud_key_pair",
+	"alicloud_nat_gateway",
+	"alicloud_pvtz_zone",
+	"alicloud_pvtz_zone_attachment",
+	"alicloud_pvtz_zone_record",
+	"alicloud_ram_role",
+	"alicloud_ram_role_policy_attachment",
+	"alicloud_security_group",
+	"alicloud_security_group_rule",
+	"alicloud_slb",
+	"alicloud_slb_listener",
+	"alicloud_slb_server_group",
+	"alicloud_vpc",
+	"alicloud_vswitch",
+	"auth0_action",
+	"auth0_branding",
+	"auth0_client",
+	"auth0_client_grant",
+	"auth0_custom_domain",
+	"auth0_email",
+	"auth0_hook",
+	"auth0_log_stream",
+	"auth0_prompt",
+	"auth0_resource_server",
+	"auth0_role",
+	"auth0_rule",
+	"auth0_rule_config",
+	"auth0_tenant",
+	"auth0_trigger_binding",
+	"auth0_user",
+	"aws_accessanalyzer_analyzer",
+	"aws_acm_certificate",
+	"aws_api_gateway_api_key",
+	"aws_api_gateway_authorizer",
+	"aws_api_gateway_documentation_part",
+	"aws_api_gateway_gateway_response",
+	"aws_api_gateway_integration",
+	"aws_api_gateway_integration_response",
+	"aws_api_gateway_method",
+	"aws_api_gateway_method_response",
+	"aws_api_gateway_model",
+	"aws_api_gateway_resource",
+	"aws_api_gateway_rest_api",
+	"aws_api_gateway_stage",
+	"aws_api_gateway_usage_plan",
+	"aws_api_gateway_vpc_link",
+	"aws_apigatewayv2_api",
+	"aws_apigatewayv2_authorizer",
+	"aws_apigatewayv2_model",
+	"aws_apigatewayv2_route",
+	"aws_apigatewayv2_route_response",
+	"aws_apigatewayv2_vpc_link",
+	"aws_appsync_graphql_api",
+	"aws_autoscaling_group",
+	"aws_batch_compute_environment",
+	"aws_batch_job_definition",
+	"aws_batch_job_queue",
+	"aws_budgets_budget",
+	"aws_cloud9_environment_ec2",
+	"aws_cloudformation_stack",
+	"aws_cloudformation_stack_set",
+	"aws_cloudformation_stack_set_instance",
+	"aws_cloudfront_cache_policy",
+	"aws_cloudfront_distribution",
+	"aws_cloudhsm_v2_cluster",
+	"aws_cloudhsm_v2_hsm",
+	"aws_cloudtrail",
+	"aws_cloudwatch_dashboard",
+	"aws_cloudwatch_event_rule",
+	"aws_cloudwatch_event_target",
+	"aws_cloudwatch_log_group",
+	"aws_cloudwatch_metric_alarm",
+	"aws_codebuild_project",
+	"aws_codecommit_approval_rule_template",
+	"aws_codecommit_repository",
+	"aws_codedeploy_app",
+	"aws_codepipeline",
+	"aws_codepipeline_webhook",
+	"aws_cognito_identity_pool",
+	"aws_cognito_user_pool",
+	"aws_cognito_user_pool_client",
+	"aws_config_config_rule",
+	"aws_config_configuration_recorder",
+	"aws_config_delivery_channel",
+	"aws_customer_gateway",
+	"aws_datapipeline_pipeline",
+	"aws_db_cluster_snapshot",
+	"aws_db_event_subscription",
+	"aws_db_instance",
+	"aws_db_option_group",
+	"aws_db_parameter_group",
+	"aws_db_proxy",
+	"aws_db_snapshot",
+	"aws_db_subnet_group",
+	"aws_default_network_acl",
+	"aws_devicefarm_project",
+	"aws_docdb_cluster",
+	"aws_docdb_cluster_instance",
+	"aws_docdb_cluster_parameter_group",
+	"aws_docdb_subnet_group",
+	"aws_dx_connection",
+	"aws_dx_gateway",
+	"aws_dx_private_virtual_interface",
+	"aws_dx_public_virtual_interface",
+	"aws_dynamodb_table",
+	"aws_ebs_volume",
+	"aws_ec2_transit_gateway",
+	"aws_ec2_transit_gateway_route_table",
+	"aws_ec2_transit_gateway_vpc_attachment",
+	"aws_ecr_lifecycle_policy",
+	"aws_ecr_repository",
+	"aws_ecr_repository_policy",
+	"aws_ecrpublic_repository",
+	"aws_ecs_cluster",
+	"aws_ecs_service",
+	"aws_ecs_task_definition",
+	"aws_efs_access_point",
+	"aws_efs_file_system",
+	"aws_efs_file_system_policy",
+	"aws_efs_mount_target",
+	"aws_eip",
+	"aws_eks_cluster",
+	"aws_eks_node_group",
+	"aws_elastic_beanstalk_application",
+	"aws_elastic_beanstalk_environment",
+	"aws_elasticache_cluster",
+	"aws_elasticache_parameter_group",
+	"aws_elasticache_replication_group",
+	"aws_elasticache_subnet_group",
+	"aws_elasticsearch_domain",
+	"aws_elb",
+	"aws_emr_cluster",
+	"aws_emr_security_configuration",
+	"aws_glue_catalog_database",
+	"aws_glue_catalog_table",
+	"aws_glue_crawler",
+	"aws_glue_job",
+	"aws_glue_trigger",
+	"aws_iam_access_key",
+	"aws_iam_group",
+	"aws_iam_group_policy",
+	"aws_iam_group_policy_attachment",
+	"aws_iam_instance_profile",
+	"aws_iam_policy",
+	"aws_iam_role",
+	"aws_iam_role_policy",
+	"aws_iam_role_policy_attachment",
+	"aws_iam_user",
+	"aws_iam_user_group_membership",
+	"aws_iam_user_policy",
+	"aws_iam_user_policy_attachment",
+	"aws_identitystore_group",
+	"aws_identitystore_group_membership",
+	"aws_identitystore_user",
+	"aws_instance",
+	"aws_internet_gateway",
+	"aws_iot_role_alias",
+	"aws_iot_thing",
+	"aws_iot_thing_type",
+	"aws_iot_topic_rule",
+	"aws_kinesis_firehose_delivery_stream",
+	"aws_kinesis_stream",
+	"aws_kms_alias",
+	"aws_kms_grant",
+	"aws_kms_key",
+	"aws_lambda_event_source_mapping",
+	"aws_lambda_function",
+	"aws_lambda_function_event_invoke_config",
+	"aws_lambda_layer_version",
+	"aws_lambda_permission",
+	"aws_launch_configuration",
+	"aws_launch_template",
+	"aws_lb",
+	"aws_lb_listener",
+	"aws_lb_listener_certificate",
+	"aws_lb_listener_rule",
+	"aws_lb_target_group",
+	"aws_lb_target_group_attachment",
+	"aws_main_route_table_association",
+	"aws_media_package_channel",
+	"aws_media_store_container",
+	"aws_medialive_channel",
+	"aws_medialive_input",
+	"aws_medialive_input_security_group",
+	"aws_mq_broker",
+	"aws_msk_cluster",
+	"aws_nat_gateway",
+	"aws_network_acl",
+	"aws_network_interface",
+	"aws_opsworks_application",
+	"aws_opsworks_custom_layer",
+	"aws_opsworks_instance",
+	"aws_opsworks_java_app_layer",
+	"aws_opsworks_php_app_layer",
+	"aws_opsworks_stack",
+	"aws_opsworks_static_web_layer",
+	"aws_opsworks_user_profile",
+	"aws_organizations_account",
+	"aws_organizations_organization",
+	"aws_organizations_organizational_unit",
+	"aws_organizations_policy",
+	"aws_organizations_policy_attachment",
+	"aws_qldb_ledger",
+	"aws_rds_cluster",
+	"aws_rds_global_cluster",
+	"aws_redshift_cluster",
+	"aws_redshift_event_subscription",
+	"aws_redshift_parameter_group",
+	"aws_redshift_snapshot_schedule",
+	"aws_redshift_snapshot_schedule_association",
+	"aws_redshift_subnet_group",
+	"aws_resourcegroups_group",
+	"aws_route53_health_check",
+	"aws_route53_record",
+	"aws_route53_zone",
+	"aws_route_table",
+	"aws_route_table_association",
+	"aws_s3_bucket",
+	"aws_s3_bucket_policy",
+	"aws_secretsmanager_secret",
+	"aws_security_group",
+	"aws_security_group_rule",
+	"aws_securityhub_account",
+	"aws_securityhub_member",
+	"aws_securityhub_standards_subscription",
+	"aws_servicecatalog_portfolio",
+	"aws_ses_configuration_set",
+	"aws_ses_domain_identity",
+	"aws_ses_email_identity",
+	"aws_ses_receipt_rule",
+	"aws_ses_receipt_rule_set",
+	"aws_ses_template",
+	"aws_sfn_activity",
+	"aws_sfn_state_machine",
+	"aws_sns_topic",
+	"aws_sns_topic_subscription",
+	"aws_sqs_queue",
+	"aws_ssm_parameter",
+	"aws_subnet",
+	"aws_swf_domain",
+	"aws_volume_attachment",
+	"aws_vpc",
+	"aws_vpc_endpoint",
+	"aws_vpc_peering_connection",
+	"aws_vpn_connection",
+	"aws_vpn_gateway",
+	"aws_waf_byte_match_set",
+	"aws_waf_geo_match_set",
+	"aws_waf_ipset",
+	"aws_waf_rate_based_rule",
+	"aws_waf_regex_match_set",
+	"aws_waf_regex_pattern_set",
+	"aws_waf_rule",
+	"aws_waf_rule_group",
+	"aws_waf_size_constraint_set",
+	"aws_waf_sql_injection_match_set",
+	"aws_waf_web_acl",
+	"aws_waf_xss_match_set",
+	"aws_wafregional_byte_match_set",
+	"aws_wafregional_geo_match_set",
+	"aws_wafregional_ipset",
+	"aws_wafregional_rate_based_rule",
+	"aws_wafregional_regex_match_set",
+	"aws_wafregional_regex_pattern_set",
+	"aws_wafregional_rule",
+	"aws_wafregional_rule_group",
+	"aws_wafregional_size_constraint_set",
+	"aws_wafregional_sql_injection_match_set",
+	"aws_wafregional_web_acl",
+	"aws_wafregional_xss_match_set",
+	"aws_wafv2_ip_set",
+	"aws_wafv2_regex_pattern_set",
+	"aws_wafv2_rule_group",
+	"aws_wafv2_web_acl",
+	"aws_wafv2_web_acl_association",
+	"aws_wafv2_web_acl_logging_configuration",
+	"aws_workspaces_directory",
+	"aws_workspaces_ip_group",
+	"aws_workspaces_workspace",
+	"aws_xray_sampling_rule",
+	"azurerm_analysis_services_server",
+	"azurerm_app_service",
+	"azurerm_application_gateway",
+	"azurerm_container_group",
+	"azurerm_container_registry",
+	"azurerm_container_registry_webhook",
+	"azurerm_cosmosdb_account",
+	"azurerm_cosmosdb_sql_container",
+	"azurerm_cosmosdb_sql_database",
+	"azurerm_cosmosdb_table",
+	"azurerm_dns_zone",
+	"azurerm_key_vault",
+	"azurerm_lb",
+	"azurerm_lb_backend_address_pool",
+	"azurerm_lb_nat_rule",
+	"azurerm_lb_probe",
+	"azurerm_linux_virtual_machine",
+	"azurerm_managed_disk",
+	"azurerm_mariadb_configuration",
+	"azurerm_mariadb_database",
+	"azurerm_mariadb_firewall_rule",
+	"azurerm_mariadb_server",
+	"azurerm_mariadb_virtual_network_rule",
+	"azurerm_mssql_database",
+	"azurerm_mssql_firewall_rule",
+	"azurerm_mssql_server",
+	"azurerm_mysql_configuration",
+	"azurerm_mysql_database",
+	"azurerm_mysql_firewall_rule",
+	"azurerm_mysql_server",
+	"azurerm_mysql_virtual_network_rule",
+	"azurerm_network_interface",
+	"azurerm_postgresql_configuration",
+	"azurerm_postgresql_database",
+	"azurerm_postgresql_firewall_rule",
+	"azurerm_postgresql_server",
+	"azurerm_postgresql_virtual_network_rule",
+	"azurerm_private_dns_zone",
+	"azurerm_private_dns_zone_virtual_network_link",
+	"azurerm_public_ip",
+	"azurerm_public_ip_prefix",
+	"azurerm_redis_cache",
+	"azurerm_resource_group",
+	"azurerm_security_center_contact",
+	"azurerm_security_center_subscription_pricing",
+	"azurerm_sql_active_directory_administrator",
+	"azurerm_sql_elasticpool",
+	"azurerm_sql_failover_group",
+	"azurerm_sql_virtual_network_rule",
+	"azurerm_storage_account",
+	"azurerm_storage_blob",
+	"azurerm_storage_container",
+	"azurerm_virtual_machine_scale_set",
+	"azurerm_virtual_network",
+	"azurerm_windows_virtual_machine",
+	"cloudflare_access_application",
+	"cloudflare_access_rule",
+	"cloudflare_account_member",
+	"cloudflare_filter",
+	"cloudflare_firewall_rule",
+	"cloudflare_page_rule",
+	"cloudflare_rate_limit",
+	"cloudflare_record",
+	"cloudflare_zone",
+	"cloudflare_zone_lockdown",
+	"commercetools_api_extension",
+	"commercetools_channel",
+	"commercetools_custom_object",
+	"commercetools_product_type",
+	"commercetools_shipping_method",
+	"commercetools_shipping_zone",
+	"commercetools_state",
+	"commercetools_store",
+	"commercetools_subscription",
+	"commercetools_tax_category",
+	"commercetools_type",
+	"datadog_dashboard",
+	"datadog_dashboard_json",
+	"datadog_dashboard_list",
+	"datadog_downtime",
+	"datadog_integration_aws",
+	"datadog_integration_aws_lambda_arn",
+	"datadog_integration_aws_log_collection",
+	"datadog_integration_azure",
+	"datadog_integration_gcp",
+	"datadog_integration_pagerduty",
+	"datadog_integration_pagerduty_service_object",
+	"datadog_integration_slack_channel",
+	"datadog_logs_archive",
+	"datadog_logs_archive_order",
+	"datadog_logs_custom_pipeline",
+	"datadog_logs_index",
+	"datadog_logs_index_order",
+	"datadog_logs_integration_pipeline",
+	"datadog_logs_metric",
+	"datadog_logs_pipeline_order",
+	"datadog_metric_metadata",
+	"datadog_monitor",
+	"datadog_role",
+	"datadog_security_monitoring_default_rule",
+	"datadog_security_monitoring_rule",
+	"datadog_service_level_objective",
+	"datadog_synthetics_global_variable",
+	"datadog_synthetics_private_location",
+	"datadog_synthetics_test",
+	"datadog_user",
+	"digitalocean_cdn",
+	"digitalocean_certificate",
+	"digitalocean_database_cluster",
+	"digitalocean_database_connection_pool",
+	"digitalocean_database_db",
+	"digitalocean_database_replica",
+	"digitalocean_database_user",
+	"digitalocean_domain",
+	"digitalocean_droplet",
+	"digitalocean_droplet_snapshot",
+	"digitalocean_firewall",
+	"digitalocean_floating_ip",
+	"digitalocean_kubernetes_cluster",
+	"digitalocean_kubernetes_node_pool",
+	"digitalocean_loadbalancer",
+	"digitalocean_project",
+	"digitalocean_record",
+	"digitalocean_ssh_key",
+	"digitalocean_tag",
+	"digitalocean_volume",
+	"digitalocean_volume_snapshot",
+	"digitalocean_vpc",
+	"fastly_service_acl_entries_v1",
+	"fastly_service_compute",
+	"fastly_service_dictionary_items_v1",
+	"fastly_service_dynamic_snippet_content_v1",
+	"fastly_service_v1",
+	"fastly_tls_activation",
+	"fastly_tls_subscription",
+	"fastly_user_v1",
+	"github_branch_protection",
+	"github_membership",
+	"github_organization_block",
+	"github_organization_project",
+	"github_organization_webhook",
+	"github_repository",
+	"github_repository_collaborator",
+	"github_repository_deploy_key",
+	"github_repository_webhook",
+	"github_team",
+	"github_team_membership",
+	"github_team_repository",
+	"github_user_ssh_key",
+	"gitlab_branch_protection",
+	"gitlab_group",
+	"gitlab_group_membership",
+	"gitlab_group_variable",
+	"gitlab_project",
+	"gitlab_project_membership",
+	"gitlab_project_variable",
+	"gitlab_tag_protection",
+	"gmailfilter_filter",
+	"gmailfilter_label",
+	"google_app_engine_application",
+	"google_app_engine_application_url_dispatch_rules",
+	"google_app_engine_domain_mapping",
+	"google_app_engine_firewall_rule",
+	"google_app_engine_service_network_settings",
+	"google_bigquery_dataset",
+	"google_bigquery_table",
+	"google_cloud_run_domain_mapping",
+	"google_cloud_run_v2_job",
+	"google_cloud_run_v2_job_iam_member",
+	"google_cloud_run_v2_service",
+	"google_cloud_run_v2_service_iam_member",
+	"google_cloud_run_v2_worker_pool",
+	"google_cloud_run_v2_worker_pool_iam_member",
+	"google_cloud_scheduler_job",
+	"google_cloud_tasks_queue",
+	"google_cloudbuild_trigger",
+	"google_cloudbuild_worker_pool",
+	"google_cloudbuildv2_connection",
+	"google_cloudbuildv2_repository",
+	"google_cloudfunctions2_function",
+	"google_cloudfunctions_function",
+	"google_compute_address",
+	"google_compute_autoscaler",
+	"google_compute_backend_bucket",
+	"google_compute_backend_service",
+	"google_compute_disk",
+	"google_compute_external_vpn_gateway",
+	"google_compute_firewall",
+	"google_compute_forwarding_rule",
+	"google_compute_global_address",
+	"google_compute_global_forwarding_rule",
+	"google_compute_health_check",
+	"google_compute_http_health_check",
+	"google_compute_https_health_check",
+	"google_compute_image",
+	"google_compute_instance",
+	"google_compute_instance_group",
+	"google_compute_instance_group_manager",
+	"google_compute_instance_template",
+	"google_compute_interconnect_attachment",
+	"google_compute_managed_ssl_certificate",
+	"google_compute_network",
+	"google_compute_network_endpoint",
+	"google_compute_network_endpoint_group",
+	"google_compute_node_group",
+	"google_compute_node_template",
+	"google_compute_packet_mirroring",
+	"google_compute_region_autoscaler",
+	"google_compute_region_backend_service",
+	"google_compute_region_disk",
+	"google_compute_region_health_check",
+	"google_compute_region_instance_group_manager",
+	"google_compute_region_network_endpoint",
+	"google_compute_region_network_endpoint_group",
+	"google_compute_region_ssl_certificate",
+	"google_compute_region_target_http_proxy",
+	"google_compute_region_target_https_proxy",
+	"google_compute_region_url_map",
+	"google_compute_reservation",
+	"google_compute_resource_policy",
+	"google_compute_route",
+	"google_compute_router",
+	"google_compute_security_policy",
+	"google_compute_ssl_policy",
+	"google_compute_subnetwork",
+	"google_compute_target_http_proxy",
+	"google_compute_target_https_proxy",
+	"google_compute_target_instance",
+	"google_compute_target_pool",
+	"google_compute_target_ssl_proxy",
+	"google_compute_target_tcp_proxy",
+	"google_compute_url_map",
+	"google_compute_vpn_gateway",
+	"google_compute_vpn_tunnel",
+	"google_container_cluster",
+	"google_container_node_pool",
+	"google_dataproc_cluster",
+	"google_dns_managed_zone",
+	"google_dns_policy",
+	"google_dns_record_set",
+	"google_dns_response_policy",
+	"google_dns_response_policy_rule",
+	"google_firestore_backup_schedule",
+	"google_firestore_database",
+	"google_firestore_field",
+	"google_firestore_index",
+	"google_iap_brand",
+	"google_iap_client",
+	"google_iap_settings",
+	"google_iap_tunnel_dest_group",
+	"google_kms_crypto_key",
+	"google_kms_key_ring",
+	"google_logging_metric",
+	"google_memcache_instance",
+	"google_monitoring_alert_policy",
+	"google_monitoring_group",
+	"google_monitoring_notification_channel",
+	"google_monitoring_uptime_check_config",
+	"google_project",
+	"google_project_iam_custom_role",
+	"google_project_iam_member",
+	"google_project_service",
+	"google_pubsub_subscription",
+	"google_pubsub_topic",
+	"google_redis_cluster",
+	"google_redis_instance",
+	"google_secret_manager_regional_secret",
+	"google_secret_manager_regional_secret_iam_member",
+	"google_secret_manager_secret",
+	"google_secret_manager_secret_iam_member",
+	"google_service_account",
+	"google_sql_database",
+	"google_sql_database_instance",
+	"google_sql_source_representation_instance",
+	"google_sql_ssl_cert",
+	"google_sql_user",
+	"google_storage_bucket",
+	"google_storage_bucket_acl",
+	"google_storage_bucket_iam_binding",
+	"google_storage_bucket_iam_member",
+	"google_storage_bucket_iam_policy",
+	"google_storage_default_object_acl",
+	"google_storage_notification",
+	"google_storage_transfer_job",
+	"google_vpc_access_connector",
+	"grafana_dashboard",
+	"grafana_folder",
+	"heroku_account_feature",
+	"heroku_addon",
+	"heroku_addon_attachment",
+	"heroku_app",
+	"heroku_app_feature",
+	"heroku_app_webhook",
+	"heroku_domain",
+	"heroku_drain",
+	"heroku_formation",
+	"heroku_pipeline",
+	"heroku_pipeline_coupling",
+	"heroku_ssl",
+	"heroku_team_collaborator",
+	"heroku_team_member",
+	"honeycombio_board",
+	"honeycombio_burn_alert",
+	"honeycombio_column",
+	"honeycombio_dataset",
+	"honeycombio_derived_column",
+	"honeycombio_query",
+	"honeycombio_query_annotation",
+	"honeycombio_slo",
+	"honeycombio_trigger",
+	"ibm_cd_tekton_pipeline",
+	"ibm_cd_toolchain",
+	"ibm_certificate_manager_import",
+	"ibm_certificate_manager_order",
+	"ibm_cis",
+	"ibm_cis_cache_settings",
+	"ibm_cis_certificate_order",
+	"ibm_cis_custom_page",
+	"ibm_cis_dns_record",
+	"ibm_cis_domain",
+	"ibm_cis_domain_settings",
+	"ibm_cis_edge_functions_action",
+	"ibm_cis_edge_functions_trigger",
+	"ibm_cis_filter",
+	"ibm_cis_firewall",
+	"ibm_cis_global_load_balancer",
+	"ibm_cis_healthcheck",
+	"ibm_cis_origin_pool",
+	"ibm_cis_page_rule",
+	"ibm_cis_range_app",
+	"ibm_cis_rate_limit",
+	"ibm_cis_routing",
+	"ibm_cis_tls_settings",
+	"ibm_cis_waf_group",
+	"ibm_cis_waf_package",
+	"ibm_cloudant",
+	"ibm_container_cluster",
+	"ibm_container_nlb_dns",
+	"ibm_container_vpc_cluster",
+	"ibm_container_vpc_worker_pool",
+	"ibm_container_worker_pool",
+	"ibm_container_worker_pool_zone_attachment",
+	"ibm_cos_bucket",
+	"ibm_database",
+	"ibm_dl_gateway",
+	"ibm_dl_provider_gateway",
+	"ibm_dl_virtual_connection",
+	"ibm_dns_glb",
+	"ibm_dns_glb_monitor",
+	"ibm_dns_glb_pool",
+	"ibm_dns_permitted_network",
+	"ibm_dns_resource_record",
+	"ibm_dns_zone",
+	"ibm_function_action",
+	"ibm_function_package",
+	"ibm_function_rule",
+	"ibm_function_trigger",
+	"ibm_iam_access_group",
+	"ibm_iam_access_group_dynamic_rule",
+	"ibm_iam_access_group_members",
+	"ibm_iam_access_group_policy",
+	"ibm_iam_authorization_policy",
+	"ibm_iam_custom_role",
+	"ibm_iam_service_id",
+	"ibm_iam_service_policy",
+	"ibm_iam_user_policy",
+	"ibm_is_floating_ip",
+	"ibm_is_flow_log",
+	"ibm_is_ike_policy",
+	"ibm_is_image",
+	"ibm_is_instance",
+	"ibm_is_instance_action",
+	"ibm_is_instance_group",
+	"ibm_is_instance_group_manager",
+	"ibm_is_instance_group_manager_policy",
+	"ibm_is_instance_template",
+	"ibm_is_instance_volume_attachment",
+	"ibm_is_ipsec_policy",
+	"ibm_is_lb",
+	"ibm_is_lb_listener",
+	"ibm_is_lb_listener_policy",
+	"ibm_is_lb_listener_policy_rule",
+	"ibm_is_lb_pool",
+	"ibm_is_lb_pool_member",
+	"ibm_is_network_acl",
+	"ibm_is_public_gateway",
+	"ibm_is_security_group",
+	"ibm_is_security_group_rule",
+	"ibm_is_ssh_key",
+	"ibm_is_subnet",
+	"ibm_is_virtual_endpoint_gateway",
+	"ibm_is_virtual_endpoint_gateway_ip",
+	"ibm_is_volume",
+	"ibm_is_vpc",
+	"ibm_is_vpc_address_prefix",
+	"ibm_is_vpc_route",
+	"ibm_is_vpc_routing_table",
+	"ibm_is_vpc_routing_table_route",
+	"ibm_is_vpn_gateway",
+	"ibm_is_vpn_gateway_connection",
+	"ibm_kms_key",
+	"ibm_kms_key_alias",
+	"ibm_kms_key_policies",
+	"ibm_resource_instance",
+	"ibm_satellite_cluster",
+	"ibm_satellite_host",
+	"ibm_satellite_location",
+	"ibm_tg_connection",
+	"ibm_tg_gateway",
+	"ibm_tg_route_report",
+	"ionoscloud_application_loadbalancer",
+	"ionoscloud_application_loadbalancer_forwardingrule",
+	"ionoscloud_backup_unit",
+	"ionoscloud_certificate",
+	"ionoscloud_container_registry",
+	"ionoscloud_container_registry_token",
+	"ionoscloud_cube_server",
+	"ionoscloud_datacenter",
+	"ionoscloud_dataplatform_cluster",
+	"ionoscloud_dataplatform_node_pool",
+	"ionoscloud_dns_record",
+	"ionoscloud_dns_zone",
+	"ionoscloud_firewall",
+	"ionoscloud_group",
+	"ionoscloud_ipblock",
+	"ionoscloud_ipfailover",
+	"ionoscloud_k8s_cluster",
+	"ionoscloud_k8s_node_pool",
+	"ionoscloud_lan",
+	"ionoscloud_loadbalancer",
+	"ionoscloud_logging_pipeline",
+	"ionoscloud_mongo_cluster",
+	"ionoscloud_mongo_user",
+	"ionoscloud_natgateway",
+	"ionoscloud_natgateway_rule",
+	"ionoscloud_networkloadbalancer",
+	"ionoscloud_networkloadbalancer_forwardingrule",
+	"ionoscloud_nic",
+	"ionoscloud_pg_cluster",
+	"ionoscloud_pg_database",
+	"ionoscloud_pg_user",
+	"ionoscloud_private_crossconnect",
+	"ionoscloud_s3_key",
+	"ionoscloud_server",
+	"ionoscloud_share",
+	"ionoscloud_target_group",
+	"ionoscloud_user",
+	"ionoscloud_vcpu_server",
+	"ionoscloud_volume",
+	"keycloak_authentication_execution",
+	"keycloak_authentication_execution_config",
+	"keycloak_authentication_flow",
+	"keycloak_authentication_subflow",
+	"keycloak_default_groups",
+	"keycloak_group",
+	"keycloak_group_memberships",
+	"keycloak_group_roles",
+	"keycloak_ldap_full_name_mapper",
+	"keycloak_ldap_group_mapper",
+	"keycloak_ldap_hardcoded_group_mapper",
+	"keycloak_ldap_hardcoded_role_mapper",
+	"keycloak_ldap_msad_lds_user_account_control_mapper",
+	"keycloak_ldap_msad_user_account_control_mapper",
+	"keycloak_ldap_role_mapper",
+	"keycloak_ldap_user_attribute_mapper",
+	"keycloak_ldap_user_federation",
+	"keycloak_openid_client",
+	"keycloak_openid_client_scope",
+	"keycloak_openid_client_service_account_role",
+	"keycloak_realm",
+	"keycloak_required_action",
+	"keycloak_role",
+	"keycloak_user",
+	"launchdarkly_feature_flag",
+	"launchdarkly_feature_flag_environment",
+	"launchdarkly_project",
+	"launchdarkly_segment",
+	"linode_domain",
+	"linode_domain_record",
+	"linode_image",
+	"linode_instance",
+	"linode_nodebalancer",
+	"linode_nodebalancer_config",
+	"linode_nodebalancer_node",
+	"linode_rdns",
+	"linode_sshkey",
+	"linode_stackscript",
+	"linode_token",
+	"linode_volume",
+	"logzio_alert",
+	"logzio_endpoint",
+	"mackerel_alert_group_setting",
+	"mackerel_aws_integration",
+	"mackerel_channel",
+	"mackerel_downtime",
+	"mackerel_monitor",
+	"mackerel_notification_group",
+	"mackerel_role",
+	"mackerel_service",
+	"metal_device",
+	"metal_spot_market_request",
+	"metal_ssh_key",
+	"metal_volume",
+	"mikrotik_dhcp_lease",
+	"myrasec_cache_setting",
+	"myrasec_dns_record",
+	"myrasec_domain",
+	"myrasec_error_page",
+	"myrasec_ip_filter",
+	"myrasec_maintenance",
+	"myrasec_ratelimit",
+	"myrasec_redirect",
+	"myrasec_settings",
+	"myrasec_waf_rule",
+	"newrelic_alert_channel",
+	"newrelic_alert_condition",
+	"newrelic_alert_policy",
+	"newrelic_entity_tags",
+	"newrelic_infra_alert_condition",
+	"newrelic_nrql_alert_condition",
+	"newrelic_synthetics_monitor",
+	"ns1_monitoringjob",
+	"ns1_record",
+	"ns1_team",
+	"ns1_zone",
+	"octopusdeploy_account",
+	"octopusdeploy_certificate",
+	"octopusdeploy_environment",
+	"octopusdeploy_feed",
+	"octopusdeploy_library_variable_set",
+	"octopusdeploy_lifecycle",
+	"octopusdeploy_project",
+	"octopusdeploy_project_deployment_target_trigger",
+	"octopusdeploy_project_group",
+	"octopusdeploy_tag_set",
+	"okta_app_auto_login",
+	"okta_app_basic_auth",
+	"okta_app_bookmark",
+	"okta_app_oauth",
+	"okta_app_saml",
+	"okta_app_secure_password_store",
+	"okta_app_signon_policy",
+	"okta_app_signon_policy_rule",
+	"okta_app_swa",
+	"okta_app_three_field",
+	"okta_app_user_base_schema_property",
+	"okta_app_user_schema_property",
+	"okta_auth_server",
+	"okta_auth_server_claim",
+	"okta_auth_server_claim_default",
+	"okta_auth_server_default",
+	"okta_auth_server_policy",
+	"okta_auth_server_policy_rule",
+	"okta_auth_server_scope",
+	"okta_authenticator",
+	"okta_event_hook",
+	"okta_factor",
+	"okta_factor_totp",
+	"okta_group",
+	"okta_group_rule",
+	"okta_idp_oidc",
+	"okta_idp_saml",
+	"okta_idp_social",
+	"okta_inline_hook",
+	"okta_network_zone",
+	"okta_policy_mfa",
+	"okta_policy_mfa_default",
+	"okta_policy_password",
+	"okta_policy_password_default",
+	"okta_policy_rule_mfa",
+	"okta_policy_rule_password",
+	"okta_policy_rule_signon",
+	"okta_policy_signon",
+	"okta_template_sms",
+	"okta_trusted_origin",
+	"okta_user",
+	"okta_user_base_schema_property",
+	"okta_user_schema_property",
+	"okta_user_type",
+	"opal_group",
+	"opal_message_channel",
+	"opal_on_call_schedule",
+	"opal_owner",
+	"opal_resource",
+	"openstack_compute_instance_v2",
+	"openstack_compute_volume_attach_v2",
+	"openstack_networking_secgroup_rule_v2",
+	"openstack_networking_secgroup_v2",
+	"opsgenie_service",
+	"opsgenie_team",
+	"opsgenie_user",
+	"pagerduty_business_service",
+	"pagerduty_escalation_policy",
+	"pagerduty_ruleset",
+	"pagerduty_ruleset_rule",
+	"pagerduty_schedule",
+	"pagerduty_service",
+	"pagerduty_service_event_rule",
+	"pagerduty_team",
+	"pagerduty_team_membership",
+	"pagerduty_user",
+	"panos_aggregate_interface",
+	"panos_application_object",
+	"panos_bgp",
+	"panos_bgp_aggregate",
+	"panos_bgp_conditional_adv",
+	"panos_bgp_peer_group",
+	"panos_device_group_parent",
+	"panos_ethernet_interface",
+	"panos_general_settings",
+	"panos_ike_crypto_profile",
+	"panos_ike_gateway",
+	"panos_ipsec_crypto_profile",
+	"panos_ipsec_tunnel",
+	"panos_management_profile",
+	"panos_panorama_aggregate_interface",
+	"panos_panorama_application_object",
+	"panos_panorama_bgp",
+	"panos_panorama_bgp_aggregate",
+	"panos_panorama_bgp_conditional_adv",
+	"panos_panorama_bgp_peer_group",
+	"panos_panorama_ethernet_interface",
+	"panos_panorama_gcp_account",
+	"panos_panorama_gke_cluster",
+	"panos_panorama_gke_cluster_group",
+	"panos_panorama_ike_crypto_profile",
+	"panos_panorama_ike_gateway",
+	"panos_panorama_ipsec_crypto_profile",
+	"panos_panorama_ipsec_tunnel",
+	"panos_panorama_loopback_interface",
+	"panos_panorama_management_profile",
+	"panos_panorama_security_rule_group",
+	"panos_panorama_tunnel_interface",
+	"panos_panorama_virtual_router",
+	"panos_panorama_vlan",
+	"panos_panorama_vlan_interface",
+	"panos_panorama_zone",
+	"panos_telemetry",
+	"panos_virtual_router",
+	"rabbitmq_binding",
+	"rabbitmq_exchange",
+	"rabbitmq_permissions",
+	"rabbitmq_policy",
+	"rabbitmq_queue",
+	"rabbitmq_shovel",
+	"rabbitmq_user",
+	"rabbitmq_vhost",
+	"tencentcloud_as_scaling_config",
+	"tencentcloud_as_scaling_group",
+	"tencentcloud_cbs_storage",
+	"tencentcloud_cbs_storage_attachment",
+	"tencentcloud_cdn_domain",
+	"tencentcloud_cfs_file_system",
+	"tencentcloud_clb_attachment",
+	"tencentcloud_clb_instance",
+	"tencentcloud_clb_listener",
+	"tencentcloud_clb_listener_rule",
+	"tencentcloud_cos_bucket",
+	"tencentcloud_dnspod_domain_instance",
+	"tencentcloud_dnspod_record",
+	"tencentcloud_eip",
+	"tencentcloud_eip_association",
+	"tencentcloud_elasticsearch_instance",
+	"tencentcloud_gaap_certificate",
+	"tencentcloud_gaap_http_domain",
+	"tencentcloud_gaap_http_rule",
+	"tencentcloud_gaap_layer4_listener",
+	"tencentcloud_gaap_layer7_listener",
+	"tencentcloud_gaap_proxy",
+	"tencentcloud_gaap_realserver",
+	"tencentcloud_instance",
+	"tencentcloud_key_pair",
+	"tencentcloud_mongodb_instance",
+	"tencentcloud_mysql_instance",
+	"tencentcloud_mysql_readonly_instance",
+	"tencentcloud_nat_gateway",
+	"tencentcloud_pts_project",
+	"tencentcloud_redis_instance",
+	"tencentcloud_route_table",
+	"tencentcloud_route_table_entry",
+	"tencentcloud_scf_function",
+	"tencentcloud_security_group",
+	"tencentcloud_security_group_lite_rule",
+	"tencentcloud_ses_domain",
+	"tencentcloud_ses_email_address",
+	"tencentcloud_ses_template",
+	"tencentcloud_ssl_certificate",
+	"tencentcloud_subnet",
+	"tencentcloud_tat_command",
+	"tencentcloud_tat_invoker",
+	"tencentcloud_tcaplus_cluster",
+	"tencentcloud_vpc",
+	"tencentcloud_vpc_acl",
+	"tencentcloud_vpc_acl_attachment",
+	"tencentcloud_vpn_gateway",
+	"vault_generic_secret",
+	"vault_mount",
+	"vault_policy",
+	"vultr_bare_metal_server",
+	"vultr_block_storage",
+	"vultr_dns_domain",
+	"vultr_dns_record",
+	"vultr_firewall_group",
+	"vultr_firewall_rule",
+	"vultr_network",
+	"vultr_reserved_ip",
+	"vultr_server",
+	"vultr_snapshot",
+	"vultr_ssh_key",
+	"vultr_startup_script",
+	"vultr_user",
+	"xenorchestra_acl",
+	"xenorchestra_resource_set",
+	"yandex_compute_disk",
+	"yandex_compute_instance",
+	"yandex_vpc_network",
+	"yandex_vpc_subnet",
+}
@@ -0,0 +1,266 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package resourcetypes lists the resource types created by the generators of the providers, found by scanning
+// the calls of terraformutils.NewResource and NewSimpleResource in their sources. After adding a generator,
+// regenerate the list with:
+//
+//	go run terraformutils/resourcetypes/generator/main.go
+package resourcetypes
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// resourceType matches the names of resource types, e.g. google_compute_network
+var resourceType = regexp.MustCompile(`^[a-z0-9]+_[a-z0-9_]+$`)
+
+// constructors are the functions of terraformutils creating a resource, the type is their third argument
+var constructors = map[string]bool{"NewResource": true, "NewSimpleResource": true}
+
+// Generated returns the resource types created by the generators, sorted
+func Generated() []string {
+	return append([]string{}, generatedResourceTypes...)
+}
+
+// Covered returns whether a generator creates resources of a type
+func Covered(resourceType string) bool {
+	i := sort.SearchStrings(generatedResourceTypes, resourceType)
+	return i < len(generatedResourceTypes) && generatedResourceTypes[i] == resourceType
+}
+
+// Uncovered returns the resource types no generator creates, sorted
+func Uncovered(resourceTypes []string) []string {
+	uncovered := []string{}
+	for _, t := range resourceTypes {
+		if !Covered(t) {
+			uncovered = append(uncovered, t)
+		}
+	}
+	sort.Strings(uncovered)
+	return uncovered
+}
+
+// Scan returns the resource types passed to NewResource and NewSimpleResource by the Go sources under root,
+// sorted. A type is found when it is made of string literals and constants, a local variable assigned such
+// values, or a call of a function of the package returning them. Other expressions, e.g. parameters, are skipped.
+func Scan(root string) ([]string, error) {
+	packages := map[string][]*ast.File{}
+	fset := token.NewFileSet()
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != root && (strings.HasPrefix(entry.Name(), ".") || entry.Name() == "testdata" || entry.Name() == "test_data") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+		dir := filepath.Dir(path)
+		packages[dir] = append(packages[dir], file)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	types := map[string]bool{}
+	for _, files := range packages {
+		s := newScanner(files)
+		for _, file := range files {
+			s.file = file
+			ast.Inspect(file, func(node ast.Node) bool {
+				call, ok := node.(*ast.CallExpr)
+				if !ok || len(call.Args) < 3 || !constructors[functionName(call.Fun)] {
+					return true
+				}
+				for _, t := range s.values(call.Args[2]) {
+					if resourceType.MatchString(t) {
+						types[t] = true
+					}
+				}
+				return true
+			})
+		}
+	}
+	sorted := make([]string, 0, len(types))
+	for t := range types {
+		sorted = append(sorted, t)
+	}
+	sort.Strings(sorted)
+	return sorted, nil
+}
+
+func functionName(fun ast.Expr) string {
+	switch fun := fun.(type) {
+	case *ast.SelectorExpr:
+		return fun.Sel.Name
+	case *ast.Ident:
+		return fun.Name
+	}
+	return ""
+}
+
+// scanner resolves the string values of expressions of a package
+type scanner struct {
+	// constants are the expressions of the package level constants and variables by name
+	constants map[string]ast.Expr
+	// functions are the functions of the package by name
+	functions map[string]*ast.FuncDecl
+	// file is the file of the expressions, where local variables are assigned
+	file *ast.File
+	// visiting are the identifiers and functions being resolved, which are not followed again
+	visiting map[interface{}]bool
+}
+
+func newScanner(files []*ast.File) *scanner {
+	s := &scanner{constants: map[string]ast.Expr{}, functions: map[string]*ast.FuncDecl{}, visiting: map[interface{}]bool{}}
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Recv == nil {
+					s.functions[decl.Name.Name] = decl
+				}
+			case *ast.GenDecl:
+				if decl.Tok != token.CONST && decl.Tok != token.VAR {
+					continue
+				}
+				for _, spec := range decl.Specs {
+					valueSpec := spec.(*ast.ValueSpec)
+					for i, name := range valueSpec.Names {
+						if i < len(valueSpec.Values) {
+							s.constants[name.Name] = valueSpec.Values[i]
+						}
+					}
+				}
+			}
+		}
+	}
+	return s
+}
+
+// values returns the string values an expression may have
+func (s *scanner) values(expr ast.Expr) []string {
+	switch expr := expr.(type) {
+	case *ast.BasicLit:
+		if expr.Kind != token.STRING {
+			return nil
+		}
+		if value, err := strconv.Unquote(expr.Value); err == nil {
+			return []string{value}
+		}
+	case *ast.ParenExpr:
+		return s.values(expr.X)
+	case *ast.BinaryExpr:
+		if expr.Op != token.ADD {
+			return nil
+		}
+		values := []string{}
+		for _, x := range s.values(expr.X) {
+			for _, y := range s.values(expr.Y) {
+				values = append(values, x+y)
+			}
+		}
+		return values
+	case *ast.Ident:
+		return s.follow(expr, func() []string { return s.identValues(expr) })
+	case *ast.CallExpr:
+		if function, ok := expr.Fun.(*ast.Ident); ok && s.functions[function.Name] != nil {
+			decl := s.functions[function.Name]
+			return s.follow(decl, func() []string { return s.returnValues(decl) })
+		}
+	}
+	return nil
+}
+
+// follow resolves the values of a node unless it is being resolved already
+func (s *scanner) follow(node interface{}, resolve func() []string) []string {
+	if s.visiting[node] {
+		return nil
+	}
+	s.visiting[node] = true
+	defer delete(s.visiting, node)
+	return resolve()
+}
+
+// identValues returns the values of a local constant or variable, every value assigned in the file, or of a
+// package level one
+func (s *scanner) identValues(ident *ast.Ident) []string {
+	if ident.Obj == nil {
+		if value, ok := s.constants[ident.Name]; ok {
+			return s.values(value)
+		}
+		return nil
+	}
+	if ident.Obj.Kind != ast.Con && ident.Obj.Kind != ast.Var {
+		return nil
+	}
+	values := []string{}
+	if spec, ok := ident.Obj.Decl.(*ast.ValueSpec); ok {
+		for i, name := range spec.Names {
+			if name.Name == ident.Name && i < len(spec.Values) {
+				values = append(values, s.values(spec.Values[i])...)
+			}
+		}
+	}
+	ast.Inspect(s.file, func(node ast.Node) bool {
+		assign, ok := node.(*ast.AssignStmt)
+		if !ok || len(assign.Lhs) != len(assign.Rhs) {
+			return true
+		}
+		for i, lhs := range assign.Lhs {
+			if name, ok := lhs.(*ast.Ident); ok && name.Obj == ident.Obj {
+				values = append(values, s.values(assign.Rhs[i])...)
+			}
+		}
+		return true
+	})
+	return values
+}
+
+// returnValues returns the values of the first result of the return statements of a function
+func (s *scanner) returnValues(decl *ast.FuncDecl) []string {
+	values := []string{}
+	if decl.Body == nil {
+		return values
+	}
+	ast.Inspect(decl.Body, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if len(node.Results) > 0 {
+				values = append(values, s.values(node.Results[0])...)
+			}
+		}
+		return true
+	})
+	return values
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcetypes
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const source = `package example
+
+import "github.com/GoogleCloudPlatform/terraformer/terraformutils"
+
+const prefix = "example_"
+
+const bucketType = prefix + "bucket"
+
+func serverType(kind string) string {
+	switch kind {
+	case "cube":
+		return "example_cube_server"
+	}
+	return "example_server"
+}
+
+func resources(kind, parameterType string, isDefault bool) []terraformutils.Resource {
+	networkType := "example_network"
+	if isDefault {
+		networkType = "example_default_network"
+	}
+	return []terraformutils.Resource{
+		terraformutils.NewSimpleResource("id", "name", "example_instance", "example", nil),
+		terraformutils.NewResource("id", "name", bucketType, "example", nil, nil, nil),
+		terraformutils.NewSimpleResource("id", "name", networkType, "example", nil),
+		terraformutils.NewSimpleResource("id", "name", serverType(kind), "example", nil),
+		terraformutils.NewSimpleResource("id", "name", parameterType, "example", nil),
+		terraformutils.NewSimpleResource("id", "name", "not a type", "example", nil),
+	}
+}
+`
+
+func TestScan(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "example", "test_data"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "example", "example.go"), []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ignored := `package example
+
+func init() { terraformutils.NewSimpleResource("id", "name", "example_ignored", "example", nil) }
+`
+	for _, path := range []string{filepath.Join("example", "example_test.go"), filepath.Join("example", "test_data", "fixture.go")} {
+		if err := os.WriteFile(filepath.Join(root, path), []byte(ignored), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	types, err := Scan(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"example_bucket",
+		"example_cube_server",
+		"example_default_network",
+		"example_instance",
+		"example_network",
+		"example_server",
+	}
+	if !reflect.DeepEqual(types, expected) {
+		t.Errorf("expected %v, got %v", expected, types)
+	}
+}
+
+func TestUncovered(t *testing.T) {
+	if !Covered("google_compute_network") || Covered("google_compute_unknown") {
+		t.Error("unexpected coverage")
+	}
+	uncovered := Uncovered([]string{"google_compute_unknown", "google_compute_network", "aws_unknown"})
+	if !reflect.DeepEqual(uncovered, []string{"aws_unknown", "google_compute_unknown"}) {
+		t.Errorf("unexpected uncovered types %v", uncovered)
+	}
+}
+
+// TestGeneratedUpToDate fails when a generator was added without running the generator of the list
+func TestGeneratedUpToDate(t *testing.T) {
+	types, err := Scan(filepath.Join("..", "..", "providers"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(types, Generated()) {
+		t.Error("resource_types_gen.go is out of date, run: go run terraformutils/resourcetypes/generator/main.go")
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/resourcetypes"
+
+	"github.com/hashicorp/terraform/configs/configschema"
+	"github.com/hashicorp/terraform/providers"
+)
+
+// PrintResourceTypes prints the resource types of a provider schema, one per line, marking the ones without a
+// generator
+func PrintResourceTypes(w io.Writer, schema *providers.GetSchemaResponse) {
+	for _, resourceType := range schemaResourceTypes(schema) {
+		if resourcetypes.Covered(resourceType) {
+			fmt.Fprintln(w, resourceType)
+		} else {
+			fmt.Fprintf(w, "%s (no generator)\n", resourceType)
+		}
+	}
+}
+
+// PrintResourceSchema prints the attributes and nested blocks of a resource type, with their type and the
+// required, optional, computed and sensitive flags
+func PrintResourceSchema(w io.Writer, resourceType string, schema providers.Schema) {
+	fmt.Fprintf(w, "%s (schema version %d)\n", resourceType, schema.Version)
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	printBlock(tw, schema.Block, 1)
+	_ = tw.Flush()
+}
+
+func printBlock(w io.Writer, block *configschema.Block, depth int) {
+	if block == nil {
+		return
+	}
+	indent := strings.Repeat("  ", depth)
+	for _, name := range planKeys(block.Attributes) {
+		attribute := block.Attributes[name]
+		fmt.Fprintf(w, "%s%s\t%s\t%s\n", indent, name, attribute.Type.FriendlyName(), strings.Join(attributeFlags(attribute), " "))
+	}
+	for _, name := range planKeys(block.BlockTypes) {
+		nested := block.BlockTypes[name]
+		fmt.Fprintf(w, "%s%s\tblock %s\t%s\n", indent, name, nestingMode(nested.Nesting), itemLimits(nested))
+		printBlock(w, &nested.Block, depth+1)
+	}
+}
+
+func attributeFlags(attribute *configschema.Attribute) []string {
+	flags := []string{}
+	if attribute.Required {
+		flags = append(flags, "required")
+	}
+	if attribute.Optional {
+		flags = append(flags, "optional")
+	}
+	if attribute.Computed {
+		flags = append(flags, "computed")
+	}
+	if attribute.Sensitive {
+		flags = append(flags, "sensitive")
+	}
+	return flags
+}
+
+func nestingMode(mode configschema.NestingMode) string {
+	switch mode {
+	case configschema.NestingSingle:
+		return "single"
+	case configschema.NestingGroup:
+		return "group"
+	case configschema.NestingList:
+		return "list"
+	case configschema.NestingSet:
+		return "set"
+	case configschema.NestingMap:
+		return "map"
+	}
+	return "invalid"
+}
+
+func itemLimits(nested *configschema.NestedBlock) string {
+	switch {
+	case nested.MinItems > 0 && nested.MaxItems > 0:
+		return fmt.Sprintf("min %d, max %d", nested.MinItems, nested.MaxItems)
+	case nested.MinItems > 0:
+		return fmt.Sprintf("min %d", nested.MinItems)
+	case nested.MaxItems > 0:
+		return fmt.Sprintf("max %d", nested.MaxItems)
+	}
+	return ""
+}
+
+// PrintCoverage prints the resource types of a provider schema no generator creates, see resourcetypes.Generated,
+// and returns their number
+func PrintCoverage(w io.Writer, providerName string, schema *providers.GetSchemaResponse) int {
+	resourceTypes := schemaResourceTypes(schema)
+	uncovered := resourcetypes.Uncovered(resourceTypes)
+	for _, resourceType := range uncovered {
+		fmt.Fprintln(w, resourceType)
+	}
+	covered := len(resourceTypes) - len(uncovered)
+	percent := 100.0
+	if len(resourceTypes) > 0 {
+		percent = float64(covered) * 100 / float64(len(resourceTypes))
+	}
+	fmt.Fprintf(w, "\n%d of %d %s resource types have a generator (%.1f%%), %d have none\n", covered, len(resourceTypes), providerName, percent, len(uncovered))
+	return len(uncovered)
+}
+
+// schemaResourceTypes returns the resource types of a schema, sorted
+func schemaResourceTypes(schema *providers.GetSchemaResponse) []string {
+	return planKeys(schema.ResourceTypes)
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hashicorp/terraform/configs/configschema"
+	"github.com/hashicorp/terraform/providers"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var printedSchema = &providers.GetSchemaResponse{
+	ResourceTypes: map[string]providers.Schema{
+		"google_example_user": {Version: 1, Block: &configschema.Block{
+			Attributes: map[string]*configschema.Attribute{
+				"name":     {Type: cty.String, Required: true},
+				"password": {Type: cty.String, Optional: true, Sensitive: true},
+				"labels":   {Type: cty.Map(cty.String), Optional: true, Computed: true},
+			},
+			BlockTypes: map[string]*configschema.NestedBlock{
+				"password_policy": {
+					Nesting:  configschema.NestingList,
+					MaxItems: 1,
+					Block: configschema.Block{
+						Attributes: map[string]*configschema.Attribute{
+							"allowed_failed_attempts": {Type: cty.Number, Optional: true},
+						},
+					},
+				},
+			},
+		}},
+		"google_storage_bucket": {Block: &configschema.Block{}},
+	},
+}
+
+func TestPrintResourceSchema(t *testing.T) {
+	var buf bytes.Buffer
+	PrintResourceSchema(&buf, "google_example_user", printedSchema.ResourceTypes["google_example_user"])
+	expected := `google_example_user (schema version 1)
+  labels                     map of string  optional computed
+  name                       string         required
+  password                   string         optional sensitive
+  password_policy            block list     max 1
+    allowed_failed_attempts  number         optional
+`
+	if buf.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, buf.String())
+	}
+}
+
+func TestPrintCoverage(t *testing.T) {
+	var buf bytes.Buffer
+	if uncovered := PrintCoverage(&buf, "google", printedSchema); uncovered != 1 {
+		t.Errorf("expected 1 uncovered type, got %d", uncovered)
+	}
+	expected := `google_example_user
+
+1 of 2 google resource types have a generator (50.0%), 1 have none
+`
+	if buf.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, buf.String())
+	}
+
+	buf.Reset()
+	PrintResourceTypes(&buf, printedSchema)
+	if expected := "google_example_user (no generator)\ngoogle_storage_bucket\n"; buf.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, buf.String())
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/configs/configschema"
+)
+
+// Modes of RedactSecrets
+const (
+	// SecretsOmit drops the sensitive attributes from the generated configuration
+	SecretsOmit = "omit"
+	// SecretsVariables replaces the sensitive attributes with sensitive input variables
+	SecretsVariables = "variables"
+	// SecretsTfvars also writes the values of the input variables to SecretsTfvarsFile
+	SecretsTfvars = "tfvars"
+)
+
+// SecretsTfvarsFile is the file of the values of the sensitive input variables, it is ignored by git
+const SecretsTfvarsFile = "secrets.auto.tfvars"
+
+// ValidateSecrets returns an error for an unknown mode, the empty mode writes sensitive attributes in plaintext
+func ValidateSecrets(mode string) error {
+	switch mode {
+	case "", SecretsOmit, SecretsVariables, SecretsTfvars:
+		return nil
+	}
+	return fmt.Errorf("unknown secrets mode %q, expected %s, %s or %s", mode, SecretsOmit, SecretsVariables, SecretsTfvars)
+}
+
+// sensitiveAttributes returns the dot separated paths of the attributes a block schema marks as sensitive, e.g.
+// settings.password for the password attribute of the settings block
+func sensitiveAttributes(block *configschema.Block, prefix string) []string {
+	if block == nil {
+		return nil
+	}
+	paths := []string{}
+	for name, attribute := range block.Attributes {
+		if attribute.Sensitive {
+			paths = append(paths, prefix+name)
+		}
+	}
+	for name, blockType := range block.BlockTypes {
+		paths = append(paths, sensitiveAttributes(&blockType.Block, prefix+name+".")...)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// RedactSecrets removes the sensitive attributes of resources, see Resource.SensitiveAttributes, from their
+// attributes. With SecretsOmit they are dropped, otherwise they are replaced with references to input variables
+// named <type>_<name>_<attribute>, attributes of nested blocks with the index of the block, e.g.
+// google_sql_user_tfer--admin_password or google_sql_database_instance_main_settings_0_password. It returns the
+// values of the variables by name. Empty values are kept as they are. In every mode the sensitive attributes are
+// removed from the state of the resources too, so no plaintext is written.
+func RedactSecrets(resources []Resource, mode string) map[string]interface{} {
+	secrets := map[string]interface{}{}
+	for i := range resources {
+		r := &resources[i]
+		for _, path := range r.SensitiveAttributes {
+			redactSecret(r.Item, strings.Split(path, "."), r.InstanceInfo.Type+"_"+r.ResourceName, mode, secrets)
+			if r.InstanceState != nil {
+				redactStateSecret(r.InstanceState.Attributes, strings.Split(path, "."))
+			}
+		}
+	}
+	return secrets
+}
+
+// PlaintextSecrets returns the number of sensitive attributes of resources with a value, which are written in
+// plaintext unless they are redacted by RedactSecrets
+func PlaintextSecrets(resources []Resource) int {
+	count := 0
+	for _, r := range resources {
+		for _, path := range r.SensitiveAttributes {
+			count += countSecrets(r.Item, strings.Split(path, "."))
+		}
+	}
+	return count
+}
+
+func countSecrets(item map[string]interface{}, path []string) int {
+	value, ok := item[path[0]]
+	if !ok || value == nil {
+		return 0
+	}
+	if len(path) == 1 {
+		if isEmptySecret(value) {
+			return 0
+		}
+		return 1
+	}
+	count := 0
+	switch value := value.(type) {
+	case []interface{}:
+		for _, block := range value {
+			if block, ok := block.(map[string]interface{}); ok {
+				count += countSecrets(block, path[1:])
+			}
+		}
+	case map[string]interface{}:
+		count += countSecrets(value, path[1:])
+	}
+	return count
+}
+
+// redactStateSecret removes a sensitive attribute from the flatmap attributes of a state, in every nested block,
+// e.g. settings.0.private_key and settings.1.private_key for settings.private_key
+func redactStateSecret(attributes map[string]string, path []string) {
+	for key := range attributes {
+		segments := []string{}
+		for _, segment := range strings.Split(key, ".") {
+			// indexes of lists and hashes of sets
+			if _, err := strconv.Atoi(segment); err != nil {
+				segments = append(segments, segment)
+			}
+		}
+		if len(segments) >= len(path) && strings.Join(segments[:len(path)], ".") == strings.Join(path, ".") {
+			delete(attributes, key)
+		}
+	}
+}
+
+func redactSecret(item map[string]interface{}, path []string, variable string, mode string, secrets map[string]interface{}) {
+	value, ok := item[path[0]]
+	if !ok || value == nil {
+		return
+	}
+	variable += "_" + path[0]
+	if len(path) > 1 {
+		switch value := value.(type) {
+		case []interface{}:
+			for i, block := range value {
+				if block, ok := block.(map[string]interface{}); ok {
+					redactSecret(block, path[1:], variable+"_"+strconv.Itoa(i), mode, secrets)
+				}
+			}
+		case map[string]interface{}:
+			redactSecret(value, path[1:], variable, mode, secrets)
+		}
+		return
+	}
+	if isEmptySecret(value) {
+		return
+	}
+	if mode == SecretsOmit {
+		delete(item, path[0])
+		return
+	}
+	secrets[variable] = value
+	item[path[0]] = "${var." + variable + "}"
+}
+
+func isEmptySecret(value interface{}) bool {
+	switch value := value.(type) {
+	case string:
+		return value == ""
+	case []interface{}:
+		return len(value) == 0
+	case map[string]interface{}:
+		return len(value) == 0
+	}
+	return false
+}
@@ -15,6 +15,7 @@
 package terraformutils
 
 import (
+	"context"
 	"log"
 	"strings"
 
@@ -40,6 +41,11 @@ type ServiceGenerator interface {
 	PostRefreshCleanup()
 }
 
+// ServiceWithContext is implemented by services embedding Service, the context is set before InitResources
+type ServiceWithContext interface {
+	SetContext(ctx context.Context)
+}
+
 type Service struct {
 	Name         string
 	Resources    []Resource
@@ -47,6 +53,21 @@ type Service struct {
 	Args         map[string]interface{}
 	Filter       []ResourceFilter
 	Verbose      bool
+
+	ctx context.Context
+}
+
+// SetContext sets the context of the import, InitResources stops its API calls when it is canceled
+func (s *Service) SetContext(ctx context.Context) {
+	s.ctx = ctx
+}
+
+// Context returns the context of the import, context.Background() until SetContext is called
+func (s *Service) Context() context.Context {
+	if s.ctx == nil {
+		return context.Background()
+	}
+	return s.ctx
 }
 
 func (s *Service) SetProviderName(providerName string) {
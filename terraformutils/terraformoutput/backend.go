@@ -0,0 +1,161 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package terraformoutput
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// StateBackend stores generated state files and renders the Terraform configuration reading them back.
+// path is the output path of a generated service, every path gets its own state.
+type StateBackend interface {
+	// Name returns the Terraform backend type, e.g. gcs or s3
+	Name() string
+	// Upload stores the state generated for path, the request is canceled with ctx
+	Upload(ctx context.Context, path string, state []byte) error
+	// Download reads the state stored for path, os.ErrNotExist is returned when there is none
+	Download(ctx context.Context, path string) ([]byte, error)
+	// BackendConfig returns the terraform backend block for path, nil when no block is required
+	BackendConfig(path string) map[string]interface{}
+	// RemoteStateConfig returns the terraform_remote_state config reading the state of statePath from fromPath
+	RemoteStateConfig(fromPath, statePath string) map[string]interface{}
+}
+
+// NewStateBackend returns the backend for a --state value. bucket is the legacy --bucket flag and config holds
+// the --backend-config key=value pairs, which are passed through to the rendered backend configuration.
+func NewStateBackend(name, bucket string, config map[string]string) (StateBackend, error) {
+	if config == nil {
+		config = map[string]string{}
+	}
+	switch name {
+	case "", "local":
+		return LocalBackend{Config: config}, nil
+	case "bucket", "gcs":
+		return newGCSBackend(bucket, config)
+	case "s3":
+		return newS3Backend(bucket, config)
+	case "azurerm":
+		return newAzureRMBackend(config)
+	case "http":
+		return newHTTPBackend(config)
+	}
+	return nil, fmt.Errorf("unsupported state backend: %s", name)
+}
+
+// stateURLSchemes are the schemes of the state URLs of DownloadStateURL
+var stateURLSchemes = []string{"gs", "s3", "azurerm", "http", "https"}
+
+// IsStateURL returns true for the state URLs of DownloadStateURL, false for local paths
+func IsStateURL(location string) bool {
+	for _, scheme := range stateURLSchemes {
+		if strings.HasPrefix(location, scheme+"://") {
+			return true
+		}
+	}
+	return false
+}
+
+// DownloadStateURL reads a state through the backend of the scheme of its URL: gs://bucket/object,
+// s3://bucket/key, azurerm://storage_account/container/blob or http(s)://address. config holds the --backend-config
+// values of the backend, e.g. the region of s3 or the access_key of azurerm. os.ErrNotExist is returned when there
+// is no state.
+func DownloadStateURL(ctx context.Context, location string, config map[string]string) ([]byte, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, err
+	}
+	backendConfig := map[string]string{}
+	for k, v := range config {
+		backendConfig[k] = v
+	}
+	key := strings.TrimPrefix(u.Path, "/")
+	if u.Scheme != "http" && u.Scheme != "https" && (u.Host == "" || key == "") {
+		return nil, fmt.Errorf("invalid state URL %s, expected %s://<bucket>/<object>", location, u.Scheme)
+	}
+	switch u.Scheme {
+	case "gs":
+		return GCSBackend{Bucket: u.Host, Config: backendConfig}.downloadObject(ctx, key)
+	case "s3":
+		return S3Backend{Bucket: u.Host, Config: backendConfig}.downloadKey(ctx, key)
+	case "azurerm":
+		container, blob, ok := strings.Cut(key, "/")
+		if !ok || blob == "" {
+			return nil, fmt.Errorf("invalid state URL %s, expected azurerm://<storage_account>/<container>/<blob>", location)
+		}
+		backendConfig["storage_account_name"] = u.Host
+		backendConfig["container_name"] = container
+		return AzureRMBackend{Config: backendConfig}.downloadBlob(ctx, blob)
+	case "http", "https":
+		return HTTPBackend{Config: backendConfig}.downloadAddress(ctx, location)
+	}
+	return nil, fmt.Errorf("unsupported state URL %s, expected a scheme of %s", location, strings.Join(stateURLSchemes, ", "))
+}
+
+// ParseBackendConfig parses --backend-config key=value pairs
+func ParseBackendConfig(rawConfig []string) (map[string]string, error) {
+	config := map[string]string{}
+	for _, raw := range rawConfig {
+		parts := strings.SplitN(raw, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid backend config %q, expected key=value", raw)
+		}
+		config[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	return config, nil
+}
+
+// BackendTfData wraps a backend block into a terraform block, ready to be printed
+func BackendTfData(backend StateBackend, path string) map[string]interface{} {
+	backendConfig := backend.BackendConfig(path)
+	if backendConfig == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"terraform": map[string]interface{}{
+			"backend": []map[string]interface{}{
+				{
+					backend.Name(): backendConfig,
+				},
+			},
+		},
+	}
+}
+
+// statePrefix returns the object prefix of the state generated for path
+func statePrefix(path string) string {
+	return strings.TrimSuffix(path, "/")
+}
+
+// passthroughConfig copies --backend-config values which are not computed by the backend itself.
+// Credentials are never written to the generated files, Terraform reads them from the environment.
+func passthroughConfig(config map[string]string, computed map[string]interface{}, secrets ...string) map[string]interface{} {
+	for k, v := range config {
+		if _, exist := computed[k]; exist {
+			continue
+		}
+		isSecret := false
+		for _, secret := range secrets {
+			if k == secret {
+				isSecret = true
+			}
+		}
+		if !isSecret {
+			computed[k] = v
+		}
+	}
+	return computed
+}
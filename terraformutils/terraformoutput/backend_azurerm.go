@@ -0,0 +1,121 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package terraformoutput
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/Azure/azure-storage-blob-go/azblob"
+)
+
+// AzureRMBackend stores state as block blobs in an Azure Storage container, one <key>/terraform.tfstate blob per path
+type AzureRMBackend struct {
+	Config map[string]string
+}
+
+func newAzureRMBackend(config map[string]string) (AzureRMBackend, error) {
+	if config["storage_account_name"] == "" || config["container_name"] == "" {
+		return AzureRMBackend{}, errors.New("azurerm state backend requires --backend-config storage_account_name= and container_name=")
+	}
+	return AzureRMBackend{Config: config}, nil
+}
+
+func (b AzureRMBackend) Name() string {
+	return "azurerm"
+}
+
+func (b AzureRMBackend) Key(path string) string {
+	key := statePrefix(path) + "/" + localStateFile
+	if prefix := b.Config["key"]; prefix != "" {
+		key = strings.TrimSuffix(prefix, "/") + "/" + key
+	}
+	return key
+}
+
+func (b AzureRMBackend) BackendConfig(path string) map[string]interface{} {
+	return passthroughConfig(b.Config, map[string]interface{}{
+		"storage_account_name": b.Config["storage_account_name"],
+		"container_name":       b.Config["container_name"],
+		"key":                  b.Key(path),
+	}, "access_key", "sas_token", "client_secret")
+}
+
+func (b AzureRMBackend) RemoteStateConfig(fromPath, statePath string) map[string]interface{} {
+	return b.BackendConfig(statePath)
+}
+
+// blobURL authenticates with the storage account access key, read from access_key or ARM_ACCESS_KEY like the azurerm
+// backend. key is the name of the blob in the container.
+func (b AzureRMBackend) blobURL(key string) (azblob.BlockBlobURL, error) {
+	accessKey := b.Config["access_key"]
+	if accessKey == "" {
+		accessKey = os.Getenv("ARM_ACCESS_KEY")
+	}
+	if accessKey == "" {
+		return azblob.BlockBlobURL{}, errors.New("azurerm state backend requires an access key in --backend-config access_key= or ARM_ACCESS_KEY")
+	}
+	credential, err := azblob.NewSharedKeyCredential(b.Config["storage_account_name"], accessKey)
+	if err != nil {
+		return azblob.BlockBlobURL{}, err
+	}
+	endpoint := b.Config["endpoint"]
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.blob.core.windows.net", b.Config["storage_account_name"])
+	}
+	blobURL, err := url.Parse(fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(endpoint, "/"), b.Config["container_name"], key))
+	if err != nil {
+		return azblob.BlockBlobURL{}, err
+	}
+	return azblob.NewBlockBlobURL(*blobURL, azblob.NewPipeline(credential, azblob.PipelineOptions{})), nil
+}
+
+func (b AzureRMBackend) Upload(ctx context.Context, path string, file []byte) error {
+	blob, err := b.blobURL(b.Key(path))
+	if err != nil {
+		return err
+	}
+	_, err = azblob.UploadBufferToBlockBlob(ctx, file, blob, azblob.UploadToBlockBlobOptions{
+		BlobHTTPHeaders: azblob.BlobHTTPHeaders{ContentType: "application/json"},
+	})
+	return err
+}
+
+func (b AzureRMBackend) Download(ctx context.Context, path string) ([]byte, error) {
+	return b.downloadBlob(ctx, b.Key(path))
+}
+
+// downloadBlob reads a blob of the container, os.ErrNotExist is returned when it doesn't exist
+func (b AzureRMBackend) downloadBlob(ctx context.Context, key string) ([]byte, error) {
+	blob, err := b.blobURL(key)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := blob.Download(ctx, 0, azblob.CountToEnd, azblob.BlobAccessConditions{}, false)
+	var storageErr azblob.StorageError
+	if errors.As(err, &storageErr) && storageErr.ServiceCode() == azblob.ServiceCodeBlobNotFound {
+		return nil, os.ErrNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+	body := resp.Body(azblob.RetryReaderOptions{})
+	defer body.Close()
+	return io.ReadAll(body)
+}
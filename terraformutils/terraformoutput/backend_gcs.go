@@ -0,0 +1,97 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package terraformoutput
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+
+	"cloud.google.com/go/storage"
+)
+
+// GCSBackend stores state in a Cloud Storage bucket, one <prefix>/default.tfstate object per path
+type GCSBackend struct {
+	Bucket string
+	Config map[string]string
+}
+
+func newGCSBackend(bucket string, config map[string]string) (GCSBackend, error) {
+	if config["bucket"] != "" {
+		bucket = config["bucket"]
+	}
+	if bucket == "" {
+		return GCSBackend{}, errors.New("gcs state backend requires --bucket or --backend-config bucket=")
+	}
+	return GCSBackend{Bucket: strings.ReplaceAll(bucket, "gs://", ""), Config: config}, nil
+}
+
+func (b GCSBackend) Name() string {
+	return "gcs"
+}
+
+func (b GCSBackend) BucketPrefix(path string) string {
+	if prefix := b.Config["prefix"]; prefix != "" {
+		return strings.TrimSuffix(prefix, "/") + "/" + statePrefix(path)
+	}
+	return statePrefix(path)
+}
+
+func (b GCSBackend) BackendConfig(path string) map[string]interface{} {
+	return passthroughConfig(b.Config, map[string]interface{}{
+		"bucket": b.Bucket,
+		"prefix": b.BucketPrefix(path),
+	}, "credentials", "access_token")
+}
+
+func (b GCSBackend) RemoteStateConfig(fromPath, statePath string) map[string]interface{} {
+	return b.BackendConfig(statePath)
+}
+
+func (b GCSBackend) Upload(ctx context.Context, path string, file []byte) error {
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	wc := client.Bucket(b.Bucket).Object(b.BucketPrefix(path) + "/default.tfstate").NewWriter(ctx)
+	if _, err = wc.Write(file); err != nil {
+		return err
+	}
+	return wc.Close()
+}
+
+func (b GCSBackend) Download(ctx context.Context, path string) ([]byte, error) {
+	return b.downloadObject(ctx, b.BucketPrefix(path)+"/default.tfstate")
+}
+
+// downloadObject reads an object of the bucket, os.ErrNotExist is returned when it doesn't exist
+func (b GCSBackend) downloadObject(ctx context.Context, object string) ([]byte, error) {
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	rc, err := client.Bucket(b.Bucket).Object(object).NewReader(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, os.ErrNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
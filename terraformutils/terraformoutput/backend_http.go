@@ -0,0 +1,135 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package terraformoutput
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5" //nolint
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// HTTPBackend stores state with the REST protocol of Terraform's http backend. Every path gets its own
+// state address by appending the path to the configured address (and lock_address/unlock_address).
+type HTTPBackend struct {
+	Config map[string]string
+	Client *http.Client
+}
+
+func newHTTPBackend(config map[string]string) (HTTPBackend, error) {
+	if config["address"] == "" {
+		return HTTPBackend{}, errors.New("http state backend requires --backend-config address=")
+	}
+	return HTTPBackend{Config: config, Client: http.DefaultClient}, nil
+}
+
+func (b HTTPBackend) Name() string {
+	return "http"
+}
+
+func (b HTTPBackend) address(key, path string) string {
+	return strings.TrimSuffix(b.Config[key], "/") + "/" + strings.TrimPrefix(statePrefix(path), "/")
+}
+
+func (b HTTPBackend) BackendConfig(path string) map[string]interface{} {
+	computed := map[string]interface{}{
+		"address": b.address("address", path),
+	}
+	for _, key := range []string{"lock_address", "unlock_address"} {
+		if b.Config[key] != "" {
+			computed[key] = b.address(key, path)
+		}
+	}
+	return passthroughConfig(b.Config, computed, "password")
+}
+
+func (b HTTPBackend) RemoteStateConfig(fromPath, statePath string) map[string]interface{} {
+	config := b.BackendConfig(statePath)
+	// terraform_remote_state only reads the state
+	delete(config, "lock_address")
+	delete(config, "lock_method")
+	delete(config, "unlock_address")
+	delete(config, "unlock_method")
+	return config
+}
+
+func (b HTTPBackend) Upload(ctx context.Context, path string, file []byte) error {
+	method := b.Config["update_method"]
+	if method == "" {
+		method = http.MethodPost
+	}
+	req, err := http.NewRequestWithContext(ctx, method, b.address("address", path), bytes.NewReader(file))
+	if err != nil {
+		return err
+	}
+	sum := md5.Sum(file) //nolint
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-MD5", base64.StdEncoding.EncodeToString(sum[:]))
+	resp, err := b.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("failed to upload state to %s: %s", req.URL.Redacted(), resp.Status)
+	}
+	return nil
+}
+
+func (b HTTPBackend) Download(ctx context.Context, path string) ([]byte, error) {
+	return b.downloadAddress(ctx, b.address("address", path))
+}
+
+// downloadAddress reads the state of an address, os.ErrNotExist is returned when none was stored
+func (b HTTPBackend) downloadAddress(ctx context.Context, address string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, address, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := b.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	// the http backend answers 204 or 404 when no state was stored yet
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusNoContent {
+		return nil, os.ErrNotExist
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("failed to download state from %s: %s", req.URL.Redacted(), resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// do sends req with basic auth from the config or TF_HTTP_USERNAME and TF_HTTP_PASSWORD
+func (b HTTPBackend) do(req *http.Request) (*http.Response, error) {
+	username, password := b.Config["username"], b.Config["password"]
+	if username == "" {
+		username, password = os.Getenv("TF_HTTP_USERNAME"), os.Getenv("TF_HTTP_PASSWORD")
+	}
+	if username != "" {
+		req.SetBasicAuth(username, password)
+	}
+	client := b.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return client.Do(req)
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package terraformoutput
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const localStateFile = "terraform.tfstate"
+
+// LocalBackend writes terraform.tfstate next to the generated files. The state is written while holding
+// the same lock info file as Terraform's local backend, so a concurrent terraform run is never overwritten.
+type LocalBackend struct {
+	Config map[string]string
+	// FS is the file system of the generated files, the local file system when nil
+	FS FileSystem
+}
+
+func (b LocalBackend) fileSystem() FileSystem {
+	if b.FS == nil {
+		return OS
+	}
+	return b.FS
+}
+
+func (b LocalBackend) Name() string {
+	return "local"
+}
+
+// BackendConfig returns nil, local is the Terraform default backend
+func (b LocalBackend) BackendConfig(path string) map[string]interface{} {
+	return nil
+}
+
+func (b LocalBackend) RemoteStateConfig(fromPath, statePath string) map[string]interface{} {
+	if fromPath == statePath {
+		return map[string]interface{}{
+			"path": localStateFile,
+		}
+	}
+	return map[string]interface{}{
+		"path": strings.Repeat("../", strings.Count(fromPath, "/")) + statePath + localStateFile,
+	}
+}
+
+func (b LocalBackend) Upload(ctx context.Context, path string, file []byte) error {
+	dir, ok := b.fileSystem().(DirFileSystem)
+	if !ok {
+		// files written elsewhere can't be locked by terraform
+		return b.FS.WriteFile(filepath.Join(path, localStateFile), file, os.ModePerm)
+	}
+	path = dir.Path(path)
+	lockPath := filepath.Join(path, "."+localStateFile+".lock.info")
+	lock, err := os.OpenFile(lockPath, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		if os.IsExist(err) {
+			return fmt.Errorf("state %s is locked, remove %s if no terraform process is running", filepath.Join(path, localStateFile), lockPath)
+		}
+		return err
+	}
+	defer os.Remove(lockPath)
+	lockInfo, _ := json.Marshal(map[string]string{
+		"Operation": "terraformer import",
+		"Path":      filepath.Join(path, localStateFile),
+		"Created":   time.Now().UTC().Format(time.RFC3339),
+	})
+	_, err = lock.Write(lockInfo)
+	if closeErr := lock.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(path, localStateFile), file, os.ModePerm)
+}
+
+func (b LocalBackend) Download(ctx context.Context, path string) ([]byte, error) {
+	return b.fileSystem().ReadFile(filepath.Join(path, localStateFile))
+}
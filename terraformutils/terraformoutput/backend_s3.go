@@ -0,0 +1,134 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package terraformoutput
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// S3Backend stores state in an S3 bucket, one <key>/terraform.tfstate object per path
+type S3Backend struct {
+	Bucket string
+	Config map[string]string
+}
+
+func newS3Backend(bucket string, config map[string]string) (S3Backend, error) {
+	if config["bucket"] != "" {
+		bucket = config["bucket"]
+	}
+	if bucket == "" {
+		return S3Backend{}, errors.New("s3 state backend requires --bucket or --backend-config bucket=")
+	}
+	return S3Backend{Bucket: strings.TrimPrefix(bucket, "s3://"), Config: config}, nil
+}
+
+func (b S3Backend) Name() string {
+	return "s3"
+}
+
+func (b S3Backend) Key(path string) string {
+	key := statePrefix(path) + "/" + localStateFile
+	if prefix := b.Config["key"]; prefix != "" {
+		key = strings.TrimSuffix(prefix, "/") + "/" + key
+	}
+	return key
+}
+
+func (b S3Backend) BackendConfig(path string) map[string]interface{} {
+	return passthroughConfig(b.Config, map[string]interface{}{
+		"bucket": b.Bucket,
+		"key":    b.Key(path),
+	}, "access_key", "secret_key", "token")
+}
+
+func (b S3Backend) RemoteStateConfig(fromPath, statePath string) map[string]interface{} {
+	return b.BackendConfig(statePath)
+}
+
+func (b S3Backend) client(ctx context.Context) (*s3.Client, error) {
+	var loadOptions []func(*config.LoadOptions) error
+	if region := b.Config["region"]; region != "" {
+		loadOptions = append(loadOptions, config.WithRegion(region))
+	}
+	if profile := b.Config["profile"]; profile != "" {
+		loadOptions = append(loadOptions, config.WithSharedConfigProfile(profile))
+	}
+	if b.Config["access_key"] != "" {
+		loadOptions = append(loadOptions, config.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(b.Config["access_key"], b.Config["secret_key"], b.Config["token"])))
+	}
+	cfg, err := config.LoadDefaultConfig(ctx, loadOptions...)
+	if err != nil {
+		return nil, err
+	}
+	return s3.NewFromConfig(cfg, func(o *s3.Options) {
+		if endpoint := b.Config["endpoint"]; endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+		}
+		o.UsePathStyle = b.Config["use_path_style"] == "true" || b.Config["force_path_style"] == "true"
+	}), nil
+}
+
+func (b S3Backend) Upload(ctx context.Context, path string, file []byte) error {
+	client, err := b.client(ctx)
+	if err != nil {
+		return err
+	}
+	input := &s3.PutObjectInput{
+		Bucket: aws.String(b.Bucket),
+		Key:    aws.String(b.Key(path)),
+		Body:   bytes.NewReader(file),
+	}
+	if b.Config["encrypt"] == "true" {
+		input.ServerSideEncryption = "AES256"
+	}
+	_, err = client.PutObject(ctx, input)
+	return err
+}
+
+func (b S3Backend) Download(ctx context.Context, path string) ([]byte, error) {
+	return b.downloadKey(ctx, b.Key(path))
+}
+
+// downloadKey reads an object of the bucket, os.ErrNotExist is returned when it doesn't exist
+func (b S3Backend) downloadKey(ctx context.Context, key string) ([]byte, error) {
+	client, err := b.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	output, err := client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(b.Bucket),
+		Key:    aws.String(key),
+	})
+	var noSuchKey *types.NoSuchKey
+	if errors.As(err, &noSuchKey) {
+		return nil, os.ErrNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer output.Body.Close()
+	return io.ReadAll(output.Body)
+}
@@ -0,0 +1,244 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package terraformoutput
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// stateServer is a stand-in for S3 and http state backends, it records uploaded objects by request path
+type stateServer struct {
+	sync.Mutex
+	objects map[string][]byte
+	methods map[string]string
+	users   map[string]string
+}
+
+func newStateServer(t *testing.T) (*stateServer, *httptest.Server) {
+	s := &stateServer{objects: map[string][]byte{}, methods: map[string]string{}, users: map[string]string{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		s.Lock()
+		defer s.Unlock()
+		if r.Method == http.MethodGet {
+			object, exist := s.objects[r.URL.Path]
+			if !exist {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(object)
+			return
+		}
+		s.objects[r.URL.Path] = body
+		s.methods[r.URL.Path] = r.Method
+		if user, _, ok := r.BasicAuth(); ok {
+			s.users[r.URL.Path] = user
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return s, server
+}
+
+func TestS3BackendUpload(t *testing.T) {
+	s, server := newStateServer(t)
+	backend, err := NewStateBackend("s3", "s3://state-bucket", map[string]string{
+		"region":         "us-east-1",
+		"endpoint":       server.URL,
+		"use_path_style": "true",
+		"access_key":     "AKIDEXAMPLE",
+		"secret_key":     "secret",
+		"key":            "imports",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.Upload(context.Background(), "generated/aws/vpc/", []byte(`{"version": 4}`)); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(s.objects["/state-bucket/imports/generated/aws/vpc/terraform.tfstate"]); got != `{"version": 4}` {
+		t.Errorf("unexpected uploaded objects %v", s.objects)
+	}
+
+	expected := map[string]interface{}{
+		"bucket":         "state-bucket",
+		"key":            "imports/generated/aws/subnet/terraform.tfstate",
+		"region":         "us-east-1",
+		"endpoint":       server.URL,
+		"use_path_style": "true",
+	}
+	if config := backend.RemoteStateConfig("generated/aws/vpc/", "generated/aws/subnet/"); !reflect.DeepEqual(config, expected) {
+		t.Errorf("unexpected remote state config %v", config)
+	}
+}
+
+func TestHTTPBackendUpload(t *testing.T) {
+	s, server := newStateServer(t)
+	backend, err := NewStateBackend("http", "", map[string]string{
+		"address":      server.URL + "/state/",
+		"lock_address": server.URL + "/lock",
+		"username":     "terraformer",
+		"password":     "secret",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.Upload(context.Background(), "generated/google/networks/", []byte("state")); err != nil {
+		t.Fatal(err)
+	}
+	if string(s.objects["/state/generated/google/networks"]) != "state" || s.methods["/state/generated/google/networks"] != http.MethodPost {
+		t.Errorf("unexpected uploaded objects %v", s.objects)
+	}
+	if s.users["/state/generated/google/networks"] != "terraformer" {
+		t.Errorf("expected basic auth, got %v", s.users)
+	}
+	if data, err := backend.Download(context.Background(), "generated/google/networks/"); err != nil || string(data) != "state" {
+		t.Errorf("unexpected downloaded state %s: %v", data, err)
+	}
+	if _, err := backend.Download(context.Background(), "generated/google/firewall/"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected missing state error, got %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := backend.Upload(ctx, "generated/google/firewall/", []byte("state")); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected canceled upload, got %v", err)
+	}
+
+	backendConfig := BackendTfData(backend, "generated/google/networks/")["terraform"].(map[string]interface{})["backend"].([]map[string]interface{})[0]["http"]
+	expected := map[string]interface{}{
+		"address":      server.URL + "/state/generated/google/networks",
+		"lock_address": server.URL + "/lock/generated/google/networks",
+		"username":     "terraformer",
+	}
+	if !reflect.DeepEqual(backendConfig, expected) {
+		t.Errorf("unexpected backend config %v", backendConfig)
+	}
+	if _, exist := backend.RemoteStateConfig("a/", "b/")["lock_address"]; exist {
+		t.Errorf("remote state config should not lock")
+	}
+}
+
+func TestHTTPBackendUploadError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	}))
+	defer server.Close()
+	backend, err := NewStateBackend("http", "", map[string]string{"address": server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.Upload(context.Background(), "generated/", []byte("state")); err == nil {
+		t.Errorf("expected upload error")
+	}
+}
+
+func TestLocalBackendLock(t *testing.T) {
+	dir := t.TempDir()
+	backend, err := NewStateBackend("local", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.Upload(context.Background(), dir, []byte("state")); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := backend.Download(context.Background(), dir); string(data) != "state" {
+		t.Errorf("unexpected state %s", data)
+	}
+	if BackendTfData(backend, dir) != nil {
+		t.Errorf("local backend should not render a backend block")
+	}
+
+	lockPath := filepath.Join(dir, ".terraform.tfstate.lock.info")
+	if err := os.WriteFile(lockPath, []byte("{}"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.Upload(context.Background(), dir, []byte("new state")); err == nil {
+		t.Errorf("expected locked state error")
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "terraform.tfstate")); string(data) != "state" {
+		t.Errorf("locked state was overwritten: %s", data)
+	}
+}
+
+func TestLocalBackendRemoteState(t *testing.T) {
+	backend := LocalBackend{}
+	if path := backend.RemoteStateConfig("generated/google/", "generated/google/")["path"]; path != "terraform.tfstate" {
+		t.Errorf("unexpected path %s", path)
+	}
+	if path := backend.RemoteStateConfig("generated/google/firewall/", "generated/google/networks/")["path"]; path != "../../../generated/google/networks/terraform.tfstate" {
+		t.Errorf("unexpected path %s", path)
+	}
+}
+
+func TestNewStateBackendErrors(t *testing.T) {
+	for _, name := range []string{"gcs", "s3", "azurerm", "http", "consul"} {
+		if _, err := NewStateBackend(name, "", nil); err == nil {
+			t.Errorf("expected %s backend error", name)
+		}
+	}
+	if _, err := ParseBackendConfig([]string{"region"}); err == nil {
+		t.Errorf("expected invalid backend config error")
+	}
+}
+
+func TestDownloadStateURL(t *testing.T) {
+	s, server := newStateServer(t)
+	s.objects["/state-bucket/prod/terraform.tfstate"] = []byte("s3 state")
+	s.objects["/states/prod"] = []byte("http state")
+	s3Config := map[string]string{
+		"region":         "us-east-1",
+		"endpoint":       server.URL,
+		"use_path_style": "true",
+		"access_key":     "AKIDEXAMPLE",
+		"secret_key":     "secret",
+	}
+	if data, err := DownloadStateURL(context.Background(), "s3://state-bucket/prod/terraform.tfstate", s3Config); err != nil || string(data) != "s3 state" {
+		t.Errorf("unexpected s3 state %s: %v", data, err)
+	}
+	if _, err := DownloadStateURL(context.Background(), server.URL+"/states/dev", nil); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected missing state error, got %v", err)
+	}
+	if data, err := DownloadStateURL(context.Background(), server.URL+"/states/prod", nil); err != nil || string(data) != "http state" {
+		t.Errorf("unexpected http state %s: %v", data, err)
+	}
+	for _, location := range []string{"gs://bucket", "s3:///key", "azurerm://account/container"} {
+		if _, err := DownloadStateURL(context.Background(), location, nil); err == nil {
+			t.Errorf("%s: expected an invalid URL error", location)
+		}
+	}
+	for location, want := range map[string]bool{
+		"gs://bucket/default.tfstate": true,
+		"azurerm://account/c/blob":    true,
+		"https://state.example.com/a": true,
+		"terraform.tfstate":           false,
+		"/states/gs://":               false,
+	} {
+		if IsStateURL(location) != want {
+			t.Errorf("IsStateURL(%s) = %v, want %v", location, !want, want)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package terraformoutput
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileSystem is where generated files are written, with the semantics of the functions of package os of the
+// same names. Names are the paths built from --path-pattern and --path-output.
+type FileSystem interface {
+	ReadFile(name string) ([]byte, error)
+	ReadDir(name string) ([]fs.DirEntry, error)
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	MkdirAll(name string, perm fs.FileMode) error
+	Remove(name string) error
+	RemoveAll(name string) error
+}
+
+// OS is the local file system, names are relative to the working directory
+var OS FileSystem = DirFileSystem("")
+
+// DirFileSystem is the local file system with relative names resolved against a directory instead of the
+// working directory
+type DirFileSystem string
+
+// Path returns the local path of name
+func (d DirFileSystem) Path(name string) string {
+	if string(d) == "" || filepath.IsAbs(name) {
+		return name
+	}
+	return filepath.Join(string(d), name)
+}
+
+func (d DirFileSystem) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(d.Path(name))
+}
+
+func (d DirFileSystem) ReadDir(name string) ([]fs.DirEntry, error) {
+	return os.ReadDir(d.Path(name))
+}
+
+func (d DirFileSystem) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return os.WriteFile(d.Path(name), data, perm)
+}
+
+func (d DirFileSystem) MkdirAll(name string, perm fs.FileMode) error {
+	return os.MkdirAll(d.Path(name), perm)
+}
+
+func (d DirFileSystem) Remove(name string) error {
+	return os.Remove(d.Path(name))
+}
+
+func (d DirFileSystem) RemoveAll(name string) error {
+	return os.RemoveAll(d.Path(name))
+}
+
+// MemoryFileSystem keeps files in memory, names are cleaned to slash separated paths, e.g. google/gcs/provider.tf
+type MemoryFileSystem struct {
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+func NewMemoryFileSystem() *MemoryFileSystem {
+	return &MemoryFileSystem{files: map[string][]byte{}}
+}
+
+func memoryPath(name string) string {
+	return strings.TrimPrefix(path.Clean(filepath.ToSlash(name)), "/")
+}
+
+// Files returns a copy of the files by name
+func (m *MemoryFileSystem) Files() map[string][]byte {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	files := make(map[string][]byte, len(m.files))
+	for name, data := range m.files {
+		files[name] = append([]byte{}, data...)
+	}
+	return files
+}
+
+func (m *MemoryFileSystem) ReadFile(name string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.files[memoryPath(name)]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return append([]byte{}, data...), nil
+}
+
+// ReadDir lists the files and directories of a directory, a directory exists while it contains files
+func (m *MemoryFileSystem) ReadDir(name string) ([]fs.DirEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	dir := memoryPath(name)
+	prefix := dir + "/"
+	if dir == "." {
+		prefix = ""
+	}
+	entries := map[string]memoryDirEntry{}
+	for file, data := range m.files {
+		if !strings.HasPrefix(file, prefix) {
+			continue
+		}
+		rest := strings.TrimPrefix(file, prefix)
+		if i := strings.Index(rest, "/"); i != -1 {
+			entries[rest[:i]] = memoryDirEntry{name: rest[:i], dir: true}
+		} else {
+			entries[rest] = memoryDirEntry{name: rest, size: int64(len(data))}
+		}
+	}
+	if len(entries) == 0 {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	names := make([]string, 0, len(entries))
+	for entryName := range entries {
+		names = append(names, entryName)
+	}
+	sort.Strings(names)
+	list := make([]fs.DirEntry, 0, len(names))
+	for _, entryName := range names {
+		list = append(list, entries[entryName])
+	}
+	return list, nil
+}
+
+func (m *MemoryFileSystem) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.files[memoryPath(name)] = append([]byte{}, data...)
+	return nil
+}
+
+// MkdirAll does nothing, directories exist while they contain files
+func (m *MemoryFileSystem) MkdirAll(name string, perm fs.FileMode) error {
+	return nil
+}
+
+func (m *MemoryFileSystem) Remove(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	file := memoryPath(name)
+	if _, ok := m.files[file]; !ok {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+	}
+	delete(m.files, file)
+	return nil
+}
+
+func (m *MemoryFileSystem) RemoveAll(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	dir := memoryPath(name)
+	for file := range m.files {
+		if file == dir || dir == "." || strings.HasPrefix(file, dir+"/") {
+			delete(m.files, file)
+		}
+	}
+	return nil
+}
+
+type memoryDirEntry struct {
+	name string
+	dir  bool
+	size int64
+}
+
+func (e memoryDirEntry) Name() string               { return e.name }
+func (e memoryDirEntry) IsDir() bool                { return e.dir }
+func (e memoryDirEntry) Type() fs.FileMode          { return e.Mode().Type() }
+func (e memoryDirEntry) Info() (fs.FileInfo, error) { return e, nil }
+func (e memoryDirEntry) Size() int64                { return e.size }
+func (e memoryDirEntry) ModTime() time.Time         { return time.Time{} }
+func (e memoryDirEntry) Sys() interface{}           { return nil }
+
+func (e memoryDirEntry) Mode() fs.FileMode {
+	if e.dir {
+		return fs.ModeDir | 0o755
+	}
+	return 0o644
+}
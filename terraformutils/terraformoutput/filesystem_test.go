@@ -0,0 +1,115 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package terraformoutput
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestMemoryFileSystem(t *testing.T) {
+	fsys := NewMemoryFileSystem()
+	for _, name := range []string{"./google/networks/provider.tf", "google/networks/compute_network.tf", "/google/gcs/storage_bucket.tf"} {
+		if err := fsys.WriteFile(name, []byte(name), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, err := fsys.ReadFile("google/gcs/../networks/provider.tf")
+	if err != nil || string(data) != "./google/networks/provider.tf" {
+		t.Errorf("unexpected file %q, %v", data, err)
+	}
+	if _, err := fsys.ReadFile("google/networks/missing.tf"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected fs.ErrNotExist, got %v", err)
+	}
+
+	entries, err := fsys.ReadDir("google")
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := []string{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			t.Errorf("expected %s to be a directory", entry.Name())
+		}
+		names = append(names, entry.Name())
+	}
+	if !reflect.DeepEqual(names, []string{"gcs", "networks"}) {
+		t.Errorf("unexpected entries %v", names)
+	}
+
+	if err := fsys.RemoveAll("google/networks"); err != nil {
+		t.Fatal(err)
+	}
+	files := []string{}
+	for name := range fsys.Files() {
+		files = append(files, name)
+	}
+	sort.Strings(files)
+	if !reflect.DeepEqual(files, []string{"google/gcs/storage_bucket.tf"}) {
+		t.Errorf("unexpected files %v", files)
+	}
+	if _, err := fsys.ReadDir("google/networks"); !os.IsNotExist(err) {
+		t.Errorf("expected the removed directory not to exist, got %v", err)
+	}
+}
+
+func TestUpdateHclFilesMemoryFileSystem(t *testing.T) {
+	fsys := NewMemoryFileSystem()
+	if err := OutputHclFiles(fsys, []terraformutils.Resource{testNetwork("networks/a", "a", "first")}, testProvider{}, "google/networks", "networks", false, "hcl", true); err != nil {
+		t.Fatal(err)
+	}
+	_, summary, err := UpdateHclFiles(context.Background(), nil, fsys, LocalBackend{FS: fsys}, []terraformutils.Resource{
+		testNetwork("networks/a", "a", "first"),
+		testNetwork("networks/b", "b", "second"),
+	}, testProvider{}, "google/networks", "networks", false, "hcl", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(summary.Added, []string{"google_compute_network.tfer--b"}) {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+	data, err := fsys.ReadFile("google/networks/compute_network.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"tfer--b"`) {
+		t.Errorf("expected the added resource in:\n%s", data)
+	}
+}
+
+func TestDirFileSystem(t *testing.T) {
+	dir := t.TempDir()
+	fsys := DirFileSystem(dir)
+	if err := fsys.MkdirAll("google/networks", os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := fsys.WriteFile("google/networks/provider.tf", []byte("provider"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "google", "networks", "provider.tf")); err != nil {
+		t.Errorf("expected the file in %s: %v", dir, err)
+	}
+	if fsys.Path("/tmp/state") != "/tmp/state" {
+		t.Errorf("expected absolute names to be kept, got %s", fsys.Path("/tmp/state"))
+	}
+}
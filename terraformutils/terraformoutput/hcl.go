@@ -14,6 +14,8 @@
 package terraformoutput
 
 import (
+	"errors"
+	"io/fs"
 	"log"
 	"os"
 	"path/filepath"
@@ -27,9 +29,9 @@ import (
 
 // getExistingTfFiles reads a directory and returns a list of .tf and .tf.json files
 // that are considered resource files and candidates for cleanup.
-func getExistingTfFiles(dirPath string) ([]string, error) {
+func getExistingTfFiles(fsys FileSystem, dirPath string) ([]string, error) {
 	var files []string
-	entries, err := os.ReadDir(dirPath)
+	entries, err := fsys.ReadDir(dirPath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, nil // Directory doesn't exist, so no files to return
@@ -55,9 +57,9 @@ func getExistingTfFiles(dirPath string) ([]string, error) {
 }
 
 // getAllFilesFromDir reads a directory and returns a list of all files within it.
-func getAllFilesFromDir(dirPath string) ([]string, error) {
+func getAllFilesFromDir(fsys FileSystem, dirPath string) ([]string, error) {
 	var files []string
-	entries, err := os.ReadDir(dirPath)
+	entries, err := fsys.ReadDir(dirPath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, nil // Directory doesn't exist, no files to return
@@ -73,18 +75,28 @@ func getAllFilesFromDir(dirPath string) ([]string, error) {
 	return files, nil
 }
 
-func OutputHclFiles(resources []terraformutils.Resource, provider terraformutils.ProviderGenerator, path string, serviceName string, isCompact bool, output string, sort bool) error {
-	if err := os.MkdirAll(path, os.ModePerm); err != nil {
+func OutputHclFiles(fsys FileSystem, resources []terraformutils.Resource, provider terraformutils.ProviderGenerator, path string, serviceName string, isCompact bool, output string, sort bool) error {
+	return outputHclFiles(fsys, resources, provider, path, serviceName, isCompact, output, sort, true)
+}
+
+// OutputModuleHclFiles writes the files of a child module, its provider.tf only declares the required
+// provider, the provider is configured by the root module
+func OutputModuleHclFiles(fsys FileSystem, resources []terraformutils.Resource, provider terraformutils.ProviderGenerator, path string, serviceName string, isCompact bool, output string, sort bool) error {
+	return outputHclFiles(fsys, resources, provider, path, serviceName, isCompact, output, sort, false)
+}
+
+func outputHclFiles(fsys FileSystem, resources []terraformutils.Resource, provider terraformutils.ProviderGenerator, path string, serviceName string, isCompact bool, output string, sort bool, providerConfig bool) error {
+	if err := fsys.MkdirAll(path, os.ModePerm); err != nil {
 		return err
 	}
 
 	// Get a list of existing .tf files before we start generating new ones
-	existingTfFiles, err := getExistingTfFiles(path)
+	existingTfFiles, err := getExistingTfFiles(fsys, path)
 	if err != nil {
 		log.Printf("could not read directory for cleanup %s: %v", path, err)
 	}
 	// Get a list of existing data files
-	existingDataFiles, err := getAllFilesFromDir(filepath.Join(path, "data"))
+	existingDataFiles, err := getAllFilesFromDir(fsys, filepath.Join(path, "data"))
 	if err != nil {
 		log.Printf("could not read data directory for cleanup %s: %v", path, err)
 	}
@@ -93,6 +105,71 @@ func OutputHclFiles(resources []terraformutils.Resource, provider terraformutils
 	generatedTfFiles := map[string]bool{}
 	generatedDataFiles := map[string]bool{}
 
+	if err := outputProvider(fsys, provider, path, output, sort, providerConfig); err != nil {
+		return err
+	}
+	if err := outputOutputs(fsys, resources, provider, path, serviceName, output, sort); err != nil {
+		return err
+	}
+
+	// group by resource by type
+	typeOfServices := map[string][]terraformutils.Resource{}
+	for _, r := range resources {
+		typeOfServices[r.InstanceInfo.Type] = append(typeOfServices[r.InstanceInfo.Type], r)
+	}
+	if isCompact {
+		filePath := filepath.Join(path, "resources."+GetFileExtension(output))
+		err := printFile(fsys, resources, "resources", path, output, sort, generatedDataFiles)
+		if err != nil {
+			return err
+		}
+		generatedTfFiles[filePath] = true
+	} else {
+		for k, v := range typeOfServices {
+			fileName := resourceFileName(k, false)
+			filePath := filepath.Join(path, fileName+"."+GetFileExtension(output))
+			err := printFile(fsys, v, fileName, path, output, sort, generatedDataFiles)
+			if err != nil {
+				return err
+			}
+			generatedTfFiles[filePath] = true
+		}
+	}
+
+	// Delete stale .tf files that were not generated in this run
+	for _, filePath := range existingTfFiles {
+		if !generatedTfFiles[filePath] {
+			log.Printf("removing stale file: %s", filePath)
+			if err := fsys.Remove(filePath); err != nil {
+				log.Printf("failed to remove stale file %s: %v", filePath, err)
+			}
+		}
+	}
+
+	// Delete stale data files that were not generated in this run
+	for _, filePath := range existingDataFiles {
+		if !generatedDataFiles[filePath] {
+			log.Printf("removing stale data file: %s", filePath)
+			if err := fsys.Remove(filePath); err != nil {
+				log.Printf("failed to remove stale data file %s: %v", filePath, err)
+			}
+		}
+	}
+	return nil
+}
+
+// outputProviderAndOutputs writes provider.tf and outputs.tf and sets the outputs of every resource
+func outputProviderAndOutputs(fsys FileSystem, resources []terraformutils.Resource, provider terraformutils.ProviderGenerator, path string, serviceName string, output string, sort bool) error {
+	if err := outputProvider(fsys, provider, path, output, sort, true); err != nil {
+		return err
+	}
+	return outputOutputs(fsys, resources, provider, path, serviceName, output, sort)
+}
+
+// outputProvider writes provider.tf with the required provider and, when withConfig is set, its configuration. The
+// required provider of a plugin installed by providerwrapper.InstallPlugin is written to versions.tf instead, next to
+// the lock file of the plugin.
+func outputProvider(fsys FileSystem, provider terraformutils.ProviderGenerator, path string, output string, sort bool, withConfig bool) error {
 	providerConfig := map[string]interface{}{
 		"version": providerwrapper.GetProviderVersion(provider.GetName()),
 	}
@@ -100,21 +177,50 @@ func OutputHclFiles(resources []terraformutils.Resource, provider terraformutils
 	if providerWithSource, ok := provider.(terraformutils.ProviderWithSource); ok {
 		providerConfig["source"] = providerWithSource.GetSource()
 	}
-
-	// create provider file
-	providerData := provider.GetProviderData()
-	providerData["terraform"] = map[string]interface{}{
+	terraform := map[string]interface{}{
 		"required_providers": []map[string]interface{}{{
 			provider.GetName(): providerConfig,
 		}},
 	}
 
+	// create provider file
+	providerData := map[string]interface{}{}
+	if withConfig {
+		providerData = provider.GetProviderData()
+	}
+	providerFile := filepath.Join(path, "provider."+GetFileExtension(output))
+	plugin := providerwrapper.InstalledPlugin(provider.GetName())
+	if plugin == nil {
+		providerData["terraform"] = terraform
+	} else {
+		versionsFile, err := terraformutils.Print(map[string]interface{}{"terraform": terraform}, map[string]struct{}{}, output, sort, make(map[string]map[string][]string))
+		if err != nil {
+			return err
+		}
+		if err := PrintFile(fsys, filepath.Join(path, "versions."+GetFileExtension(output)), versionsFile); err != nil {
+			return err
+		}
+		if err := PrintFile(fsys, filepath.Join(path, providerwrapper.LockFileName), plugin.LockFile().Bytes()); err != nil {
+			return err
+		}
+		if len(providerData) == 0 {
+			// a provider.tf of a previous import would declare the required provider twice
+			if err := fsys.Remove(providerFile); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+			return nil
+		}
+	}
+
 	providerDataFile, err := terraformutils.Print(providerData, map[string]struct{}{}, output, sort, make(map[string]map[string][]string))
 	if err != nil {
 		return err
 	}
-	PrintFile(filepath.Join(path, "provider."+GetFileExtension(output)), providerDataFile)
+	return PrintFile(fsys, providerFile, providerDataFile)
+}
 
+// outputOutputs writes outputs.tf and sets the outputs of every resource
+func outputOutputs(fsys FileSystem, resources []terraformutils.Resource, provider terraformutils.ProviderGenerator, path string, serviceName string, output string, sort bool) error {
 	// create outputs files
 	outputs := map[string]interface{}{}
 	outputsByResource := map[string]map[string]interface{}{}
@@ -148,6 +254,16 @@ func OutputHclFiles(resources []terraformutils.Resource, provider terraformutils
 				}
 			}
 		}
+		for _, attribute := range r.ReferencedAttributes {
+			linkKey := r.InstanceInfo.Type + "_" + r.ResourceName + "_" + attribute
+			outputsByResource[linkKey] = map[string]interface{}{
+				"value": "${" + r.InstanceInfo.Type + "." + r.ResourceName + "." + attribute + "}",
+			}
+			outputState[linkKey] = &terraform.OutputState{
+				Type:  "string",
+				Value: r.InstanceState.Attributes[attribute],
+			}
+		}
 		resources[i].Outputs = outputState
 	}
 	if len(outputsByResource) > 0 {
@@ -156,92 +272,70 @@ func OutputHclFiles(resources []terraformutils.Resource, provider terraformutils
 		if err != nil {
 			return err
 		}
-		PrintFile(filepath.Join(path, "outputs."+GetFileExtension(output)), outputsFile)
-	}
-
-	// group by resource by type
-	typeOfServices := map[string][]terraformutils.Resource{}
-	for _, r := range resources {
-		typeOfServices[r.InstanceInfo.Type] = append(typeOfServices[r.InstanceInfo.Type], r)
-	}
-	if isCompact {
-		filePath := filepath.Join(path, "resources."+GetFileExtension(output))
-		err := printFile(resources, "resources", path, output, sort, generatedDataFiles)
-		if err != nil {
-			return err
-		}
-		generatedTfFiles[filePath] = true
-	} else {
-		for k, v := range typeOfServices {
-			fileName := strings.ReplaceAll(k, strings.Split(k, "_")[0]+"_", "")
-			filePath := filepath.Join(path, fileName+"."+GetFileExtension(output))
-			err := printFile(v, fileName, path, output, sort, generatedDataFiles)
-			if err != nil {
-				return err
-			}
-			generatedTfFiles[filePath] = true
-		}
+		return PrintFile(fsys, filepath.Join(path, "outputs."+GetFileExtension(output)), outputsFile)
 	}
+	return nil
+}
 
-	// Delete stale .tf files that were not generated in this run
-	for _, filePath := range existingTfFiles {
-		if !generatedTfFiles[filePath] {
-			log.Printf("removing stale file: %s", filePath)
-			if err := os.Remove(filePath); err != nil {
-				log.Printf("failed to remove stale file %s: %v", filePath, err)
-			}
-		}
+func printFile(fsys FileSystem, v []terraformutils.Resource, fileName, path, output string, sort bool, generatedDataFiles map[string]bool) error {
+	if err := printDataFiles(fsys, v, path, generatedDataFiles); err != nil {
+		return err
 	}
 
-	// Delete stale data files that were not generated in this run
-	for _, filePath := range existingDataFiles {
-		if !generatedDataFiles[filePath] {
-			log.Printf("removing stale data file: %s", filePath)
-			if err := os.Remove(filePath); err != nil {
-				log.Printf("failed to remove stale data file %s: %v", filePath, err)
-			}
-		}
+	tfFile, err := terraformutils.HclPrintResource(v, map[string]interface{}{}, output, sort)
+	if err != nil {
+		return err
 	}
-	return nil
+	return PrintFile(fsys, filepath.Join(path, fileName+"."+GetFileExtension(output)), tfFile)
 }
 
-func printFile(v []terraformutils.Resource, fileName, path, output string, sort bool, generatedDataFiles map[string]bool) error {
-	for _, res := range v {
+// printDataFiles writes the data files of resources under path/data and records them in generatedDataFiles
+func printDataFiles(fsys FileSystem, resources []terraformutils.Resource, path string, generatedDataFiles map[string]bool) error {
+	for _, res := range resources {
 		if res.DataFiles == nil {
 			continue
 		}
 		for dataFileName, content := range res.DataFiles {
 			dataDirPath := filepath.Join(path, "data")
-			if err := os.MkdirAll(dataDirPath, os.ModePerm); err != nil {
+			if err := fsys.MkdirAll(dataDirPath, os.ModePerm); err != nil {
 				return err
 			}
 			fullDataPath := filepath.Join(dataDirPath, dataFileName)
-			err := os.WriteFile(fullDataPath, content, os.ModePerm)
+			err := fsys.WriteFile(fullDataPath, content, os.ModePerm)
 			if err != nil {
 				return err
 			}
 			generatedDataFiles[fullDataPath] = true
 		}
 	}
+	return nil
+}
 
-	tfFile, err := terraformutils.HclPrintResource(v, map[string]interface{}{}, output, sort)
-	if err != nil {
-		return err
-	}
-	err = os.WriteFile(filepath.Join(path, fileName+"."+GetFileExtension(output)), tfFile, os.ModePerm)
+// OutputImportBlocks writes imports.tf (or imports.tf.json) with one import block per resource
+func OutputImportBlocks(fsys FileSystem, resources []terraformutils.Resource, path string, output string, sort bool) error {
+	importsFile, err := terraformutils.HclPrintImportBlocks(resources, output, sort)
 	if err != nil {
 		return err
 	}
+	return PrintFile(fsys, filepath.Join(path, "imports."+GetFileExtension(output)), importsFile)
+}
 
-	return nil
+// PrintFile writes a generated file
+func PrintFile(fsys FileSystem, path string, data []byte) error {
+	return fsys.WriteFile(path, data, os.ModePerm)
 }
 
-func PrintFile(path string, data []byte) {
-	err := os.WriteFile(path, data, os.ModePerm)
-	if err != nil {
-		log.Fatal(err)
-		return
+// resourceFileName returns the name of the file resources of resourceType are written to, without extension
+func resourceFileName(resourceType string, isCompact bool) string {
+	if isCompact {
+		return "resources"
 	}
+	return strings.ReplaceAll(resourceType, strings.Split(resourceType, "_")[0]+"_", "")
+}
+
+// ResourceFilePath returns the file under path new resources of resourceType are written to
+func ResourceFilePath(path, resourceType string, isCompact bool, output string) string {
+	return filepath.Join(path, resourceFileName(resourceType, isCompact)+"."+GetFileExtension(output))
 }
 
 func GetFileExtension(outputFormat string) string {
@@ -0,0 +1,161 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package terraformoutput
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+const ManifestFile = "terraformer-manifest.json"
+const ManifestVersion = 1
+
+// Manifest records the address every imported resource was written to
+type Manifest struct {
+	Version int `json:"version"`
+	// Resources maps resource types and import IDs to addresses
+	Resources map[string]map[string]string `json:"resources"`
+	// Moved are the address changes of all runs which still lead to a resource, states written by any
+	// earlier run can be migrated with them
+	Moved []terraformutils.Move `json:"moved,omitempty"`
+	// Generated lists the attributes and nested blocks written to every resource address, e.g. name or
+	// network_interface.0.network, --update removes the ones which are no longer generated
+	Generated map[string][]string `json:"generated,omitempty"`
+}
+
+// ReadManifest reads the manifest of path, a missing manifest is empty
+func ReadManifest(fsys FileSystem, path string) (*Manifest, error) {
+	manifest := &Manifest{Version: ManifestVersion, Resources: map[string]map[string]string{}}
+	data, err := fsys.ReadFile(filepath.Join(path, ManifestFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", filepath.Join(path, ManifestFile), err)
+	}
+	if manifest.Version > ManifestVersion {
+		return nil, fmt.Errorf("unsupported manifest version %d in %s", manifest.Version, path)
+	}
+	if manifest.Resources == nil {
+		manifest.Resources = map[string]map[string]string{}
+	}
+	return manifest, nil
+}
+
+// OutputManifest updates the manifest of path with the addresses of resources, grouped by the module they are
+// written to, the root module for an empty name. Resources whose address changed since an earlier run are
+// written as moved blocks to moved.tf, so Terraform renames them instead of replacing them. The attributes
+// and nested blocks of every resource are recorded for --update.
+func OutputManifest(fsys FileSystem, resourcesByModule map[string][]terraformutils.Resource, path string, output string) error {
+	previous, err := ReadManifest(fsys, path)
+	if err != nil {
+		return err
+	}
+	manifest := &Manifest{Version: ManifestVersion, Resources: map[string]map[string]string{}, Generated: map[string][]string{}}
+	for module, resources := range resourcesByModule {
+		for _, r := range resources {
+			if r.InstanceState == nil || r.InstanceState.ID == "" {
+				continue
+			}
+			address := r.ImportAddress()
+			if module != "" {
+				address = "module." + module + "." + address
+			}
+			if manifest.Resources[r.InstanceInfo.Type] == nil {
+				manifest.Resources[r.InstanceInfo.Type] = map[string]string{}
+			}
+			manifest.Resources[r.InstanceInfo.Type][r.InstanceState.ID] = address
+			block, err := renderResourceBlock(r, false)
+			if err != nil {
+				return err
+			}
+			manifest.Generated[address] = generatedPaths(block.Body(), "")
+		}
+	}
+	manifest.Moved = manifestMoves(previous, manifest)
+
+	movedPath := filepath.Join(path, "moved."+GetFileExtension(output))
+	if len(manifest.Moved) > 0 {
+		log.Printf("writing %d moved blocks to %s", len(manifest.Moved), movedPath)
+		movedFile, err := terraformutils.HclPrintMovedBlocks(manifest.Moved, output)
+		if err != nil {
+			return err
+		}
+		if err := PrintFile(fsys, movedPath, movedFile); err != nil {
+			return err
+		}
+	} else if err := fsys.Remove(movedPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return PrintFile(fsys, filepath.Join(path, ManifestFile), append(data, '\n'))
+}
+
+// manifestMoves adds the address changes between previous and current to the moves of previous and drops the
+// moves which no longer lead to a resource or start at an address in use again
+func manifestMoves(previous, current *Manifest) []terraformutils.Move {
+	addresses := map[string]bool{}
+	for _, ids := range current.Resources {
+		for _, address := range ids {
+			addresses[address] = true
+		}
+	}
+	moves := map[string]string{}
+	for _, m := range previous.Moved {
+		moves[m.From] = m.To
+	}
+	for resourceType, ids := range current.Resources {
+		for id, address := range ids {
+			if from, exist := previous.Resources[resourceType][id]; exist && from != address {
+				moves[from] = address
+			}
+		}
+	}
+	result := []terraformutils.Move{}
+	for from, to := range moves {
+		if addresses[from] {
+			continue
+		}
+		// follow chained moves, a -> b -> c, the chain ends at a resource or nowhere
+		end := to
+		for steps := 0; steps < len(moves) && !addresses[end]; steps++ {
+			next, exist := moves[end]
+			if !exist {
+				break
+			}
+			end = next
+		}
+		if addresses[end] {
+			result = append(result, terraformutils.Move{From: from, To: to})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].From < result[j].From
+	})
+	return result
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package terraformoutput
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func outputTestManifest(t *testing.T, dir string, names ...string) *Manifest {
+	resources := []terraformutils.Resource{}
+	for _, name := range names {
+		resources = append(resources, testNetwork("networks/"+name[:1], name, ""))
+	}
+	if err := OutputManifest(OS, map[string][]terraformutils.Resource{"": resources}, dir, "hcl"); err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := ReadManifest(OS, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return manifest
+}
+
+func TestOutputManifestMovedBlocks(t *testing.T) {
+	dir := t.TempDir()
+	movedPath := filepath.Join(dir, "moved.tf")
+
+	manifest := outputTestManifest(t, dir, "a", "b")
+	if manifest.Resources["google_compute_network"]["networks/a"] != "google_compute_network.tfer--a" {
+		t.Errorf("unexpected manifest %v", manifest.Resources)
+	}
+	if _, err := os.Stat(movedPath); !os.IsNotExist(err) {
+		t.Errorf("unexpected moved blocks on the first run")
+	}
+
+	// a is renamed
+	manifest = outputTestManifest(t, dir, "a2", "b")
+	expected := []terraformutils.Move{{From: "google_compute_network.tfer--a", To: "google_compute_network.tfer--a2"}}
+	if !reflect.DeepEqual(manifest.Moved, expected) {
+		t.Errorf("unexpected moves %v", manifest.Moved)
+	}
+	moved, err := os.ReadFile(movedPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(moved) != "moved {\n  from = google_compute_network.tfer--a\n  to   = google_compute_network.tfer--a2\n}\n" {
+		t.Errorf("unexpected moved blocks:\n%s", moved)
+	}
+
+	// a is renamed again, states of both earlier runs can be migrated
+	manifest = outputTestManifest(t, dir, "a3", "b")
+	expected = []terraformutils.Move{
+		{From: "google_compute_network.tfer--a", To: "google_compute_network.tfer--a2"},
+		{From: "google_compute_network.tfer--a2", To: "google_compute_network.tfer--a3"},
+	}
+	if !reflect.DeepEqual(manifest.Moved, expected) {
+		t.Errorf("unexpected chained moves %v", manifest.Moved)
+	}
+
+	// the original name is restored, moves starting at it would conflict with the resource
+	manifest = outputTestManifest(t, dir, "a", "b")
+	expected = []terraformutils.Move{
+		{From: "google_compute_network.tfer--a2", To: "google_compute_network.tfer--a3"},
+		{From: "google_compute_network.tfer--a3", To: "google_compute_network.tfer--a"},
+	}
+	if !reflect.DeepEqual(manifest.Moved, expected) {
+		t.Errorf("unexpected moves after restoring a name %v", manifest.Moved)
+	}
+
+	// a is deleted, its moves lead nowhere
+	manifest = outputTestManifest(t, dir, "b")
+	if len(manifest.Moved) != 0 {
+		t.Errorf("unexpected moves of a deleted resource %v", manifest.Moved)
+	}
+	if _, err := os.Stat(movedPath); !os.IsNotExist(err) {
+		t.Errorf("expected moved.tf to be removed")
+	}
+}
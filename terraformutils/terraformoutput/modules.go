@@ -0,0 +1,96 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package terraformoutput
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+// ModuleCall is a child module instantiated by the root module
+type ModuleCall struct {
+	Name   string
+	Source string
+	// Inputs maps the input variables of the module to the module whose output of the same name provides them
+	Inputs map[string]string
+	// Secrets are the sensitive input variables of the module, passed from the variables of the root module
+	Secrets []string
+}
+
+// OutputModuleVariables writes variables.tf declaring the string inputs of a child module and the sensitive input
+// variables of its secrets
+func OutputModuleVariables(fsys FileSystem, inputs map[string]string, secrets map[string]interface{}, path string, output string, sort bool) error {
+	if len(inputs) == 0 && len(secrets) == 0 {
+		return nil
+	}
+	// type constraints are plain strings in JSON syntax and keywords in HCL
+	typeConstraint := "${string}"
+	if output == "json" {
+		typeConstraint = "string"
+	}
+	variables := SecretVariables(secrets)
+	for name := range inputs {
+		variables[name] = map[string]interface{}{
+			"type": typeConstraint,
+		}
+	}
+	variablesFile, err := terraformutils.Print(map[string]interface{}{"variable": variables}, map[string]struct{}{}, output, sort, make(map[string]map[string][]string))
+	if err != nil {
+		return err
+	}
+	return PrintFile(fsys, filepath.Join(path, "variables."+GetFileExtension(output)), variablesFile)
+}
+
+// OutputRootModule writes provider.tf and main.tf of the root module, modules are instantiated in the given order.
+// The secrets of the modules are declared in variables.tf.
+func OutputRootModule(fsys FileSystem, provider terraformutils.ProviderGenerator, path string, modules []ModuleCall, output string, sort bool) error {
+	if err := fsys.MkdirAll(path, os.ModePerm); err != nil {
+		return err
+	}
+	if err := outputProvider(fsys, provider, path, output, sort, true); err != nil {
+		return err
+	}
+	calls := []map[string]interface{}{}
+	secrets := map[string]interface{}{}
+	for _, module := range modules {
+		call := map[string]interface{}{
+			"source": module.Source,
+		}
+		for input, source := range module.Inputs {
+			call[input] = "${module." + source + "." + input + "}"
+		}
+		for _, secret := range module.Secrets {
+			call[secret] = "${var." + secret + "}"
+			secrets[secret] = nil
+		}
+		calls = append(calls, map[string]interface{}{module.Name: call})
+	}
+	mainFile, err := terraformutils.Print(map[string]interface{}{"module": calls}, map[string]struct{}{}, output, sort, make(map[string]map[string][]string))
+	if err != nil {
+		return err
+	}
+	if err := PrintFile(fsys, filepath.Join(path, "main."+GetFileExtension(output)), mainFile); err != nil {
+		return err
+	}
+	if len(secrets) == 0 {
+		return nil
+	}
+	variablesFile, err := terraformutils.Print(map[string]interface{}{"variable": SecretVariables(secrets)}, map[string]struct{}{}, output, sort, make(map[string]map[string][]string))
+	if err != nil {
+		return err
+	}
+	return PrintFile(fsys, filepath.Join(path, "variables."+GetFileExtension(output)), variablesFile)
+}
@@ -0,0 +1,136 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package terraformoutput
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/providerwrapper"
+)
+
+func TestOutputModules(t *testing.T) {
+	dir := t.TempDir()
+	modulePath := filepath.Join(dir, "networks")
+	resources := []terraformutils.Resource{testNetwork("networks/a", "a", "first")}
+	if err := OutputModuleHclFiles(OS, resources, testProvider{}, modulePath, "networks", false, "hcl", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := OutputModuleVariables(OS, map[string]string{"project_id": "project"}, nil, modulePath, "hcl", true); err != nil {
+		t.Fatal(err)
+	}
+	modules := []ModuleCall{
+		{Name: "project", Source: "./project"},
+		{Name: "networks", Source: "./networks", Inputs: map[string]string{"project_id": "project"}},
+	}
+	if err := OutputRootModule(OS, testProvider{}, dir, modules, "hcl", true); err != nil {
+		t.Fatal(err)
+	}
+
+	provider, err := os.ReadFile(filepath.Join(modulePath, "provider.tf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(provider), `provider "google"`) || !strings.Contains(string(provider), "required_providers") {
+		t.Errorf("child module must only declare the provider:\n%s", provider)
+	}
+	variables, err := os.ReadFile(filepath.Join(modulePath, "variables.tf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(variables) != "variable \"project_id\" {\n  type = string\n}\n" {
+		t.Errorf("unexpected variables:\n%s", variables)
+	}
+	main, err := os.ReadFile(filepath.Join(dir, "main.tf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `module "project" {
+  source = "./project"
+}
+
+module "networks" {
+  project_id = module.project.project_id
+  source     = "./networks"
+}
+`
+	if string(main) != expected {
+		t.Errorf("unexpected main.tf:\n%s", main)
+	}
+	rootProvider, err := os.ReadFile(filepath.Join(dir, "provider.tf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(rootProvider), `provider "google"`) {
+		t.Errorf("root module must configure the provider:\n%s", rootProvider)
+	}
+}
+
+// randomProvider is a provider whose plugin the tests install
+type randomProvider struct {
+	testProvider
+}
+
+func (p randomProvider) GetName() string { return "random" }
+
+func TestOutputProviderVersions(t *testing.T) {
+	t.Setenv("TF_DATA_DIR", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	mirror := t.TempDir()
+	pluginDir := filepath.Join(mirror, "registry.terraform.io", "hashicorp", "random", "3.1.0", runtime.GOOS+"_"+runtime.GOARCH)
+	if err := os.MkdirAll(pluginDir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(pluginDir, "terraform-provider-random_v3.1.0_x5"), []byte("random"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	plugin, err := providerwrapper.InstallPlugin(context.Background(), "random", providerwrapper.InstallOptions{Constraint: "~> 3.0", Mirrors: []string{mirror}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fsys := NewMemoryFileSystem()
+	// provider.tf of an import without a pinned plugin
+	if err := fsys.WriteFile("module/provider.tf", []byte("terraform {}"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := OutputModuleHclFiles(fsys, nil, randomProvider{}, "module", "networks", false, "hcl", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := OutputRootModule(fsys, randomProvider{}, "root", nil, "hcl", true); err != nil {
+		t.Fatal(err)
+	}
+	files := fsys.Files()
+	versions := "terraform {\n  required_providers {\n    random = {\n      version = \"~> 3.0\"\n    }\n  }\n}\n"
+	for _, dir := range []string{"module", "root"} {
+		if string(files[dir+"/versions.tf"]) != versions {
+			t.Errorf("unexpected %s/versions.tf:\n%s", dir, files[dir+"/versions.tf"])
+		}
+		if !bytes.Equal(files[dir+"/.terraform.lock.hcl"], plugin.LockFile().Bytes()) {
+			t.Errorf("unexpected %s/.terraform.lock.hcl:\n%s", dir, files[dir+"/.terraform.lock.hcl"])
+		}
+	}
+	if _, ok := files["module/provider.tf"]; ok {
+		t.Error("provider.tf of the child module declares the required provider twice")
+	}
+	if provider := string(files["root/provider.tf"]); !strings.Contains(provider, `provider "google"`) || strings.Contains(provider, "required_providers") {
+		t.Errorf("unexpected root provider.tf:\n%s", provider)
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package terraformoutput
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"sort"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform/configs/hcl2shim"
+)
+
+// SecretVariables returns the variable blocks declaring the input variables of secrets as sensitive, see
+// terraformutils.RedactSecrets
+func SecretVariables(secrets map[string]interface{}) map[string]interface{} {
+	variables := map[string]interface{}{}
+	for name := range secrets {
+		variables[name] = map[string]interface{}{
+			"sensitive": true,
+		}
+	}
+	return variables
+}
+
+// OutputSecrets writes the values of secrets to secrets.auto.tfvars, or secrets.auto.tfvars.json, and adds the file
+// to the .gitignore of the directory
+func OutputSecrets(fsys FileSystem, secrets map[string]interface{}, path string, output string) error {
+	if len(secrets) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(secrets))
+	for name := range secrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fileName := terraformutils.SecretsTfvarsFile
+	var data []byte
+	if output == "json" {
+		fileName += ".json"
+		var err error
+		if data, err = json.MarshalIndent(secrets, "", "  "); err != nil {
+			return err
+		}
+		data = append(data, '\n')
+	} else {
+		// the JSON round trip turns the values into the types hcl2shim converts
+		normalized := map[string]interface{}{}
+		raw, err := json.Marshal(secrets)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(raw, &normalized); err != nil {
+			return err
+		}
+		file := hclwrite.NewEmptyFile()
+		for _, name := range names {
+			file.Body().SetAttributeValue(name, hcl2shim.HCL2ValueFromConfigValue(normalized[name]))
+		}
+		data = hclwrite.Format(file.Bytes())
+	}
+	if err := fsys.WriteFile(filepath.Join(path, fileName), data, 0o600); err != nil {
+		return err
+	}
+	return IgnoreFile(fsys, path, fileName)
+}
+
+// IgnoreFile adds a file name to the .gitignore of a directory, unless it is listed
+func IgnoreFile(fsys FileSystem, path string, fileName string) error {
+	gitignore := filepath.Join(path, ".gitignore")
+	data, err := fsys.ReadFile(gitignore)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if string(bytes.TrimSpace(line)) == fileName {
+			return nil
+		}
+	}
+	if len(data) > 0 && !bytes.HasSuffix(data, []byte("\n")) {
+		data = append(data, '\n')
+	}
+	data = append(data, fileName+"\n"...)
+	return fsys.WriteFile(gitignore, data, 0o644)
+}
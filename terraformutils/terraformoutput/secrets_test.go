@@ -0,0 +1,92 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package terraformoutput
+
+import (
+	"os"
+	"testing"
+)
+
+func TestOutputSecrets(t *testing.T) {
+	fsys := NewMemoryFileSystem()
+	if err := fsys.WriteFile("google/sql/.gitignore", []byte(".terraform"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	secrets := map[string]interface{}{
+		"google_sql_user_admin_password": "hunter${2}",
+		"vault_generic_secret_app_data":  map[string]interface{}{"token": "s3cr3t"},
+	}
+	for i := 0; i < 2; i++ {
+		if err := OutputSecrets(fsys, secrets, "google/sql", "hcl"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := OutputSecrets(fsys, secrets, "google/json", "json"); err != nil {
+		t.Fatal(err)
+	}
+
+	files := fsys.Files()
+	tfvars := `google_sql_user_admin_password = "hunter$${2}"
+vault_generic_secret_app_data = {
+  token = "s3cr3t"
+}
+`
+	if string(files["google/sql/secrets.auto.tfvars"]) != tfvars {
+		t.Errorf("unexpected secrets.auto.tfvars:\n%s", files["google/sql/secrets.auto.tfvars"])
+	}
+	if string(files["google/sql/.gitignore"]) != ".terraform\nsecrets.auto.tfvars\n" {
+		t.Errorf("unexpected .gitignore:\n%s", files["google/sql/.gitignore"])
+	}
+	tfvarsJSON := `{
+  "google_sql_user_admin_password": "hunter${2}",
+  "vault_generic_secret_app_data": {
+    "token": "s3cr3t"
+  }
+}
+`
+	if string(files["google/json/secrets.auto.tfvars.json"]) != tfvarsJSON {
+		t.Errorf("unexpected secrets.auto.tfvars.json:\n%s", files["google/json/secrets.auto.tfvars.json"])
+	}
+	if string(files["google/json/.gitignore"]) != "secrets.auto.tfvars.json\n" {
+		t.Errorf("unexpected .gitignore:\n%s", files["google/json/.gitignore"])
+	}
+}
+
+func TestOutputModuleSecrets(t *testing.T) {
+	fsys := NewMemoryFileSystem()
+	secrets := map[string]interface{}{"google_sql_user_admin_password": "hunter2"}
+	if err := OutputModuleVariables(fsys, nil, secrets, "sql", "hcl", true); err != nil {
+		t.Fatal(err)
+	}
+	modules := []ModuleCall{{Name: "sql", Source: "./sql", Secrets: []string{"google_sql_user_admin_password"}}}
+	if err := OutputRootModule(fsys, testProvider{}, ".", modules, "hcl", true); err != nil {
+		t.Fatal(err)
+	}
+
+	files := fsys.Files()
+	variables := "variable \"google_sql_user_admin_password\" {\n  sensitive = true\n}\n"
+	for _, name := range []string{"sql/variables.tf", "variables.tf"} {
+		if string(files[name]) != variables {
+			t.Errorf("unexpected %s:\n%s", name, files[name])
+		}
+	}
+	main := `module "sql" {
+  google_sql_user_admin_password = var.google_sql_user_admin_password
+  source                         = "./sql"
+}
+`
+	if string(files["main.tf"]) != main {
+		t.Errorf("unexpected main.tf:\n%s", files["main.tf"])
+	}
+}
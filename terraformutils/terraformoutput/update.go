@@ -0,0 +1,494 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package terraformoutput
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const UpdateSummaryFile = "update-summary.json"
+
+// UpdateSummary lists what an update run changed in the generated files, by resource address
+type UpdateSummary struct {
+	Added     []string            `json:"added"`
+	Removed   []string            `json:"removed"`
+	Updated   map[string][]string `json:"updated"`
+	Renamed   map[string]string   `json:"renamed"`
+	Unchanged int                 `json:"unchanged"`
+	// Files are the files of the resources by address
+	Files map[string]string `json:"-"`
+}
+
+func (s *UpdateSummary) String() string {
+	return fmt.Sprintf("%d added, %d updated, %d removed, %d unchanged", len(s.Added), len(s.Updated), len(s.Removed), s.Unchanged)
+}
+
+// existingBlock is a resource block found in the generated directory
+type existingBlock struct {
+	file  *hclwrite.File
+	path  string
+	block *hclwrite.Block
+}
+
+func (b existingBlock) address() string {
+	labels := b.block.Labels()
+	return labels[0] + "." + labels[1]
+}
+
+// metaBlocks are never generated by terraformer, they are always kept as written by the user
+var metaBlocks = map[string]struct{}{
+	"lifecycle":   {},
+	"provisioner": {},
+	"connection":  {},
+}
+
+// UpdateHclFiles updates the resource files already generated under path in place instead of rewriting them.
+// Resources are matched to existing blocks by import ID, read from the manifest, the state of backend and the
+// imports file. Matched blocks keep their label, comments, meta blocks and attributes terraformer doesn't
+// generate, only drifted attributes and nested blocks are replaced and the ones the manifest lists as generated
+// by the last run but no longer generated are removed. New resources are appended and resources which no longer
+// exist are removed. The returned resources carry the labels found in the existing files, resources without state are
+// left out. ctx cancels the download of the state, logger receives the log and may be nil.
+func UpdateHclFiles(ctx context.Context, logger *slog.Logger, fsys FileSystem, backend StateBackend, resources []terraformutils.Resource, provider terraformutils.ProviderGenerator, path string, serviceName string, isCompact bool, output string, sort bool) ([]terraformutils.Resource, *UpdateSummary, error) {
+	if output != "hcl" {
+		return nil, nil, errors.New("update mode supports hcl output only")
+	}
+	if err := fsys.MkdirAll(path, os.ModePerm); err != nil {
+		return nil, nil, err
+	}
+	manifest, err := ReadManifest(fsys, path)
+	if err != nil {
+		return nil, nil, err
+	}
+	idsByAddress, err := existingImportIDs(ctx, fsys, backend, path, manifest)
+	if err != nil {
+		return nil, nil, err
+	}
+	files, blocks, err := parseExistingResources(fsys, path)
+	if err != nil {
+		return nil, nil, err
+	}
+	addressByID := map[string]string{}
+	for address, id := range idsByAddress {
+		addressByID[id] = address
+	}
+
+	summary := &UpdateSummary{
+		Added:   []string{},
+		Removed: []string{},
+		Updated: map[string][]string{},
+		Renamed: map[string]string{},
+		Files:   map[string]string{},
+	}
+	changedFiles := map[string]bool{}
+	matched := map[string]bool{}
+	// a resource without state can't be matched or written, its existing block is kept as it is
+	stateful := make([]terraformutils.Resource, 0, len(resources))
+	for _, r := range resources {
+		if r.InstanceState == nil {
+			terraformutils.Logf(logger, "skipping %s.%s without state", r.InstanceInfo.Type, r.ResourceName)
+			matched[r.InstanceInfo.Type+"."+r.ResourceName] = true
+			continue
+		}
+		stateful = append(stateful, r)
+	}
+	resources = stateful
+	for i := range resources {
+		r := &resources[i]
+		generatedAddress := r.InstanceInfo.Type + "." + r.ResourceName
+		importID := r.InstanceInfo.Type + "/" + r.InstanceState.ID
+		address, known := addressByID[importID]
+		existing, exist := blocks[address]
+		if !known || !exist {
+			// a block with the generated label is only reused when it isn't known to hold another resource
+			existing, exist = blocks[generatedAddress]
+			if id, imported := idsByAddress[generatedAddress]; imported && id != importID {
+				exist = false
+			}
+		}
+		if exist && matched[existing.address()] {
+			exist = false
+		}
+		newBlock, err := renderResourceBlock(*r, sort)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !exist {
+			file, filePath, err := resourceFile(files, path, r.InstanceInfo.Type, isCompact, output)
+			if err != nil {
+				return nil, nil, err
+			}
+			if content := file.Bytes(); len(content) > 0 && !bytes.HasSuffix(content, []byte("\n\n")) {
+				file.Body().AppendNewline()
+			}
+			file.Body().AppendBlock(newBlock)
+			changedFiles[filePath] = true
+			summary.Files[generatedAddress] = filePath
+			matched[generatedAddress] = true
+			summary.Added = append(summary.Added, generatedAddress)
+			continue
+		}
+		matched[existing.address()] = true
+		summary.Files[existing.address()] = existing.path
+		if existing.address() != generatedAddress {
+			labels := existing.block.Labels()
+			summary.Renamed[generatedAddress] = existing.address()
+			r.ResourceName = labels[1]
+			r.InstanceInfo.Id = existing.address()
+		}
+		var previous map[string]bool
+		if paths, recorded := manifest.Generated[manifest.Resources[r.InstanceInfo.Type][r.InstanceState.ID]]; recorded {
+			previous = map[string]bool{}
+			for _, p := range paths {
+				previous[p] = true
+			}
+		}
+		if changes := mergeBody(existing.block.Body(), newBlock.Body(), "", previous); len(changes) > 0 {
+			summary.Updated[existing.address()] = changes
+			changedFiles[existing.path] = true
+		} else {
+			summary.Unchanged++
+		}
+	}
+
+	// only resources known to have been imported are removed, hand written resources are kept
+	for address, existing := range blocks {
+		if matched[address] {
+			continue
+		}
+		if _, imported := idsByAddress[address]; !imported {
+			continue
+		}
+		existing.file.Body().RemoveBlock(existing.block)
+		changedFiles[existing.path] = true
+		summary.Removed = append(summary.Removed, address)
+	}
+
+	for filePath := range changedFiles {
+		file := files[filePath]
+		if len(file.Body().Blocks()) == 0 && len(file.Body().Attributes()) == 0 {
+			terraformutils.Logf(logger, "removing empty file: %s", filePath)
+			if err := fsys.Remove(filePath); err != nil && !os.IsNotExist(err) {
+				return nil, nil, err
+			}
+			continue
+		}
+		if err := PrintFile(fsys, filePath, hclwrite.Format(file.Bytes())); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := printDataFiles(fsys, resources, path, map[string]bool{}); err != nil {
+		return nil, nil, err
+	}
+	if err := outputProviderAndOutputs(fsys, resources, provider, path, serviceName, output, sort); err != nil {
+		return nil, nil, err
+	}
+	sortSummary(summary)
+	summaryFile, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := PrintFile(fsys, filepath.Join(path, UpdateSummaryFile), append(summaryFile, '\n')); err != nil {
+		return nil, nil, err
+	}
+	terraformutils.Logf(logger, "%s updated: %s", path, summary)
+	return resources, summary, nil
+}
+
+func sortSummary(summary *UpdateSummary) {
+	sort.Strings(summary.Added)
+	sort.Strings(summary.Removed)
+	for _, changes := range summary.Updated {
+		sort.Strings(changes)
+	}
+}
+
+// mergeBody copies drifted attributes and nested blocks from generated into existing and returns their paths.
+// Nested blocks are merged by their labels or by their index among the blocks of their type. Attributes and blocks
+// missing from generated are removed when previous, the paths generated by the last run, lists them. Without
+// previous only the surplus blocks of generated block types are removed.
+func mergeBody(existing, generated *hclwrite.Body, prefix string, previous map[string]bool) []string {
+	changes := []string{}
+	for name, attr := range generated.Attributes() {
+		newTokens := attr.Expr().BuildTokens(nil)
+		if current := existing.GetAttribute(name); current != nil && sameTokens(current.Expr().BuildTokens(nil), newTokens) {
+			continue
+		}
+		existing.SetAttributeRaw(name, newTokens)
+		changes = append(changes, prefix+name)
+	}
+	for name := range existing.Attributes() {
+		if generated.GetAttribute(name) == nil && previous[prefix+name] {
+			existing.RemoveAttribute(name)
+			changes = append(changes, prefix+name)
+		}
+	}
+
+	newBlocks, newKeys := blocksByKey(generated)
+	currentBlocks, currentKeys := blocksByKey(existing)
+	generatedTypes := map[string]bool{}
+	for _, block := range newBlocks {
+		generatedTypes[block.Type()] = true
+	}
+	for _, key := range newKeys {
+		current, exist := currentBlocks[key]
+		if !exist {
+			existing.AppendBlock(newBlocks[key])
+			changes = append(changes, prefix+key)
+			continue
+		}
+		changes = append(changes, mergeBody(current.Body(), newBlocks[key].Body(), prefix+key+".", previous)...)
+	}
+	for _, key := range currentKeys {
+		if _, exist := newBlocks[key]; exist {
+			continue
+		}
+		if previous[prefix+key] || (previous == nil && generatedTypes[currentBlocks[key].Type()]) {
+			existing.RemoveBlock(currentBlocks[key])
+			changes = append(changes, prefix+key)
+		}
+	}
+	sort.Strings(changes)
+	return changes
+}
+
+// blocksByKey indexes the nested blocks of body, except meta blocks, by their type and labels or by their type and
+// index among the blocks of their type, e.g. network_interface.0. The keys are returned in the order of body.
+func blocksByKey(body *hclwrite.Body) (map[string]*hclwrite.Block, []string) {
+	blocks := map[string]*hclwrite.Block{}
+	keys := []string{}
+	counts := map[string]int{}
+	for _, block := range body.Blocks() {
+		if _, isMeta := metaBlocks[block.Type()]; isMeta {
+			continue
+		}
+		key := block.Type() + "." + strings.Join(block.Labels(), ".")
+		if len(block.Labels()) == 0 {
+			key = block.Type() + "." + strconv.Itoa(counts[block.Type()])
+			counts[block.Type()]++
+		}
+		blocks[key] = block
+		keys = append(keys, key)
+	}
+	return blocks, keys
+}
+
+// generatedPaths lists the attributes and nested blocks of a rendered resource body with the paths of mergeBody,
+// see Manifest.Generated
+func generatedPaths(body *hclwrite.Body, prefix string) []string {
+	paths := []string{}
+	for name := range body.Attributes() {
+		paths = append(paths, prefix+name)
+	}
+	blocks, keys := blocksByKey(body)
+	for _, key := range keys {
+		paths = append(paths, prefix+key)
+		paths = append(paths, generatedPaths(blocks[key].Body(), prefix+key+".")...)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func sameTokens(a, b hclwrite.Tokens) bool {
+	return strings.Join(strings.Fields(string(a.Bytes())), " ") == strings.Join(strings.Fields(string(b.Bytes())), " ")
+}
+
+// renderResourceBlock renders a resource with the regular HCL printer and parses it back into a block
+func renderResourceBlock(r terraformutils.Resource, sort bool) (*hclwrite.Block, error) {
+	data, err := terraformutils.HclPrintResource([]terraformutils.Resource{r}, map[string]interface{}{}, "hcl", sort)
+	if err != nil {
+		return nil, err
+	}
+	file, diags := hclwrite.ParseConfig(data, r.InstanceInfo.Id, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("failed to parse generated HCL of %s: %s", r.InstanceInfo.Id, diags.Error())
+	}
+	for _, block := range file.Body().Blocks() {
+		if block.Type() == "resource" {
+			return block, nil
+		}
+	}
+	return nil, fmt.Errorf("failed to render %s", r.InstanceInfo.Id)
+}
+
+// resourceFile returns the file new resources of resourceType are appended to, creating it when needed
+func resourceFile(files map[string]*hclwrite.File, path, resourceType string, isCompact bool, output string) (*hclwrite.File, string, error) {
+	filePath := ResourceFilePath(path, resourceType, isCompact, output)
+	if file, exist := files[filePath]; exist {
+		return file, filePath, nil
+	}
+	files[filePath] = hclwrite.NewEmptyFile()
+	return files[filePath], filePath, nil
+}
+
+// parseExistingResources parses the resource files under path and indexes their resource blocks by address
+func parseExistingResources(fsys FileSystem, path string) (map[string]*hclwrite.File, map[string]existingBlock, error) {
+	files := map[string]*hclwrite.File{}
+	blocks := map[string]existingBlock{}
+	filePaths, err := getExistingTfFiles(fsys, path)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, filePath := range filePaths {
+		if !strings.HasSuffix(filePath, ".tf") {
+			continue
+		}
+		src, err := fsys.ReadFile(filePath)
+		if err != nil {
+			return nil, nil, err
+		}
+		file, diags := hclwrite.ParseConfig(src, filePath, hcl.InitialPos)
+		if diags.HasErrors() {
+			return nil, nil, fmt.Errorf("failed to parse %s: %s", filePath, diags.Error())
+		}
+		files[filePath] = file
+		for _, block := range file.Body().Blocks() {
+			if block.Type() != "resource" || len(block.Labels()) != 2 {
+				continue
+			}
+			b := existingBlock{file: file, path: filePath, block: block}
+			blocks[b.address()] = b
+		}
+	}
+	return files, blocks, nil
+}
+
+// existingImportIDs returns "<type>/<id>" of every resource address found in the state of backend, the imports
+// file and the manifest. The state and imports file win as resources may have been moved by hand since the last
+// run, the manifest adds the resources missing from both, e.g. of a state which was never applied.
+func existingImportIDs(ctx context.Context, fsys FileSystem, backend StateBackend, path string, manifest *Manifest) (map[string]string, error) {
+	ids := map[string]string{}
+	state, err := backend.Download(ctx, path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to download the state of %s from %s: %v", path, backend.Name(), err)
+	}
+	if err := stateImportIDs(state, ids); err != nil {
+		return nil, fmt.Errorf("failed to read the state of %s: %v", path, err)
+	}
+	if err := importBlockIDs(fsys, filepath.Join(path, "imports.tf"), ids); err != nil {
+		return nil, err
+	}
+	known := map[string]bool{}
+	for _, id := range ids {
+		known[id] = true
+	}
+	for resourceType, addresses := range manifest.Resources {
+		for id, address := range addresses {
+			if _, exist := ids[address]; !exist && !known[resourceType+"/"+id] && !strings.HasPrefix(address, "module.") {
+				ids[address] = resourceType + "/" + id
+			}
+		}
+	}
+	return ids, nil
+}
+
+func stateImportIDs(data []byte, ids map[string]string) error {
+	if len(data) == 0 {
+		return nil
+	}
+	var state struct {
+		Version   int `json:"version"`
+		Resources []struct {
+			Mode      string `json:"mode"`
+			Type      string `json:"type"`
+			Name      string `json:"name"`
+			Instances []struct {
+				Attributes     map[string]interface{} `json:"attributes"`
+				AttributesFlat map[string]string      `json:"attributes_flat"`
+			} `json:"instances"`
+		} `json:"resources"`
+		Modules []struct {
+			Resources map[string]struct {
+				Type    string `json:"type"`
+				Primary struct {
+					ID string `json:"id"`
+				} `json:"primary"`
+			} `json:"resources"`
+		} `json:"modules"`
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	for _, r := range state.Resources {
+		if r.Mode != "managed" || len(r.Instances) != 1 {
+			continue
+		}
+		id, _ := r.Instances[0].Attributes["id"].(string)
+		if id == "" {
+			id = r.Instances[0].AttributesFlat["id"]
+		}
+		if id != "" {
+			ids[r.Type+"."+r.Name] = r.Type + "/" + id
+		}
+	}
+	for _, module := range state.Modules {
+		for address, r := range module.Resources {
+			if r.Primary.ID != "" && !strings.HasPrefix(address, "data.") {
+				ids[address] = r.Type + "/" + r.Primary.ID
+			}
+		}
+	}
+	return nil
+}
+
+func importBlockIDs(fsys FileSystem, importsPath string, ids map[string]string) error {
+	src, err := fsys.ReadFile(importsPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	file, diags := hclsyntax.ParseConfig(src, importsPath, hcl.InitialPos)
+	if diags.HasErrors() {
+		return fmt.Errorf("failed to parse %s: %s", importsPath, diags.Error())
+	}
+	for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+		if block.Type != "import" || block.Body.Attributes["to"] == nil || block.Body.Attributes["id"] == nil {
+			continue
+		}
+		to, diags := hcl.AbsTraversalForExpr(block.Body.Attributes["to"].Expr)
+		if diags.HasErrors() || len(to) != 2 {
+			continue
+		}
+		id, diags := block.Body.Attributes["id"].Expr.Value(nil)
+		if diags.HasErrors() || id.Type() != cty.String || id.IsNull() {
+			continue
+		}
+		resourceType := to.RootName()
+		name, ok := to[1].(hcl.TraverseAttr)
+		if !ok {
+			continue
+		}
+		ids[resourceType+"."+name.Name] = resourceType + "/" + id.AsString()
+	}
+	return nil
+}
@@ -0,0 +1,281 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package terraformoutput
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+type testProvider struct{}
+
+func (p testProvider) Init(args []string) error                           { return nil }
+func (p testProvider) InitService(serviceName string, verbose bool) error { return nil }
+func (p testProvider) GetName() string                                    { return "google" }
+func (p testProvider) GetService() terraformutils.ServiceGenerator        { return nil }
+func (p testProvider) GetConfig() cty.Value                               { return cty.EmptyObjectVal }
+func (p testProvider) GetBasicConfig() cty.Value                          { return cty.EmptyObjectVal }
+func (p testProvider) GetSupportedService() map[string]terraformutils.ServiceGenerator {
+	return nil
+}
+func (p testProvider) GenerateFiles() {}
+func (p testProvider) GetProviderData(arg ...string) map[string]interface{} {
+	return map[string]interface{}{"provider": map[string]interface{}{"google": map[string]interface{}{}}}
+}
+func (p testProvider) GenerateOutputPath() error { return nil }
+func (p testProvider) GetResourceConnections() map[string]map[string][]string {
+	return map[string]map[string][]string{}
+}
+
+func testNetwork(id, name, description string) terraformutils.Resource {
+	r := terraformutils.NewResource(id, name, "google_compute_network", "google", map[string]string{
+		"id":          id,
+		"name":        name,
+		"description": description,
+	}, []string{}, map[string]interface{}{})
+	r.Item = map[string]interface{}{"name": name, "description": description}
+	return r
+}
+
+func TestUpdateHclFiles(t *testing.T) {
+	dir := t.TempDir()
+	resources := []terraformutils.Resource{
+		testNetwork("networks/a", "a", "first"),
+		testNetwork("networks/b", "b", "second"),
+	}
+	if err := OutputHclFiles(OS, resources, testProvider{}, dir, "networks", false, "hcl", true); err != nil {
+		t.Fatal(err)
+	}
+	state, err := terraformutils.PrintTfStateV4(resources, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// and moves it in the state
+	state = []byte(strings.Replace(string(state), `"name": "tfer--a"`, `"name": "main"`, 1))
+	if err := os.WriteFile(filepath.Join(dir, localStateFile), state, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// the user renames a resource, adds a comment, an attribute and a lifecycle block
+	networksFile := filepath.Join(dir, "compute_network.tf")
+	data, err := os.ReadFile(networksFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edited := strings.Replace(string(data), `resource "google_compute_network" "tfer--a" {`, `# the main network
+resource "google_compute_network" "main" {
+  routing_mode = "GLOBAL"
+
+  lifecycle {
+    prevent_destroy = true
+  }
+`, 1)
+	if err := os.WriteFile(networksFile, []byte(edited), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	updated, summary, err := UpdateHclFiles(context.Background(), nil, OS, LocalBackend{}, []terraformutils.Resource{
+		testNetwork("networks/a", "a", "drifted"),
+		testNetwork("networks/c", "c", "third"),
+	}, testProvider{}, dir, "networks", false, "hcl", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &UpdateSummary{
+		Added:   []string{"google_compute_network.tfer--c"},
+		Removed: []string{"google_compute_network.tfer--b"},
+		Updated: map[string][]string{"google_compute_network.main": {"description"}},
+		Renamed: map[string]string{"google_compute_network.tfer--a": "google_compute_network.main"},
+		Files: map[string]string{
+			"google_compute_network.main":    networksFile,
+			"google_compute_network.tfer--c": networksFile,
+		},
+	}
+	if !reflect.DeepEqual(summary, expected) {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+	if updated[0].ResourceName != "main" {
+		t.Errorf("expected the existing label to be kept, got %s", updated[0].ResourceName)
+	}
+
+	data, err = os.ReadFile(networksFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+	for _, s := range []string{"# the main network", `routing_mode = "GLOBAL"`, "prevent_destroy = true", `"drifted"`, `"tfer--c"`} {
+		if !strings.Contains(content, s) {
+			t.Errorf("expected %q in updated file:\n%s", s, content)
+		}
+	}
+	for _, s := range []string{`"first"`, `"tfer--a"`, `"tfer--b"`} {
+		if strings.Contains(content, s) {
+			t.Errorf("unexpected %q in updated file:\n%s", s, content)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, UpdateSummaryFile)); err != nil {
+		t.Errorf("expected summary file: %v", err)
+	}
+}
+
+func TestUpdateHclFilesKeepsUnknownResources(t *testing.T) {
+	dir := t.TempDir()
+	handWritten := `resource "google_compute_network" "manual" {
+  name = "manual"
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "manual.tf"), []byte(handWritten), 0600); err != nil {
+		t.Fatal(err)
+	}
+	_, summary, err := UpdateHclFiles(context.Background(), nil, OS, LocalBackend{}, []terraformutils.Resource{testNetwork("networks/a", "a", "first")}, testProvider{}, dir, "networks", true, "hcl", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summary.Removed) != 0 || !reflect.DeepEqual(summary.Added, []string{"google_compute_network.tfer--a"}) {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "manual.tf")); string(data) != handWritten {
+		t.Errorf("hand written file was modified:\n%s", data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "resources.tf")); err != nil {
+		t.Errorf("expected resources.tf: %v", err)
+	}
+	if _, _, err := UpdateHclFiles(context.Background(), nil, OS, LocalBackend{}, nil, testProvider{}, dir, "networks", true, "json", true); err == nil {
+		t.Errorf("expected json output error")
+	}
+}
+
+func testInstance(id string, item map[string]interface{}) terraformutils.Resource {
+	r := terraformutils.NewResource(id, "vm", "google_compute_instance", "google", map[string]string{"id": id}, []string{}, map[string]interface{}{})
+	r.Item = item
+	return r
+}
+
+func TestUpdateHclFilesFromManifest(t *testing.T) {
+	dir := t.TempDir()
+	resources := []terraformutils.Resource{
+		testInstance("instances/vm", map[string]interface{}{
+			"name":        "vm",
+			"description": "first",
+			"network_interface": []interface{}{
+				map[string]interface{}{"network": "a", "nic_type": "GVNIC"},
+				map[string]interface{}{"network": "b"},
+			},
+		}),
+		testNetwork("networks/b", "b", "second"),
+	}
+	if err := OutputHclFiles(OS, resources, testProvider{}, dir, "compute", true, "hcl", true); err != nil {
+		t.Fatal(err)
+	}
+	// no state was written, the resources are known from the manifest
+	if err := OutputManifest(OS, map[string][]terraformutils.Resource{"": resources}, dir, "hcl"); err != nil {
+		t.Fatal(err)
+	}
+	resourcesFile := filepath.Join(dir, "resources.tf")
+	data, err := os.ReadFile(resourcesFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edited := strings.Replace(string(data), `network = "b"`, `network = "b"
+    # kept
+    queue_count = 2`, 1)
+	edited = strings.Replace(edited, `resource "google_compute_instance" "tfer--vm" {`, `resource "google_compute_instance" "tfer--vm" {
+  tags = ["manual"]`, 1)
+	if err := os.WriteFile(resourcesFile, []byte(edited), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, summary, err := UpdateHclFiles(context.Background(), nil, OS, LocalBackend{}, []terraformutils.Resource{
+		testInstance("instances/vm", map[string]interface{}{
+			"name": "vm",
+			"network_interface": []interface{}{
+				map[string]interface{}{"network": "a"},
+				map[string]interface{}{"network": "b"},
+				map[string]interface{}{"network": "c"},
+			},
+		}),
+	}, testProvider{}, dir, "compute", true, "hcl", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string][]string{"google_compute_instance.tfer--vm": {"description", "network_interface.0.nic_type", "network_interface.2"}}
+	if !reflect.DeepEqual(summary.Updated, expected) || !reflect.DeepEqual(summary.Removed, []string{"google_compute_network.tfer--b"}) {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+	data, err = os.ReadFile(resourcesFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+	for _, s := range []string{`tags = ["manual"]`, "# kept", "queue_count = 2", `network = "c"`} {
+		if !strings.Contains(content, s) {
+			t.Errorf("expected %q in updated file:\n%s", s, content)
+		}
+	}
+	for _, s := range []string{"description", "nic_type", `"tfer--b"`} {
+		if strings.Contains(content, s) {
+			t.Errorf("unexpected %q in updated file:\n%s", s, content)
+		}
+	}
+}
+
+func TestUpdateHclFilesWithoutState(t *testing.T) {
+	dir := t.TempDir()
+	resources := []terraformutils.Resource{
+		testNetwork("networks/a", "a", "first"),
+		testNetwork("networks/b", "b", "second"),
+	}
+	if err := OutputHclFiles(OS, resources, testProvider{}, dir, "networks", true, "hcl", true); err != nil {
+		t.Fatal(err)
+	}
+	state, err := terraformutils.PrintTfStateV4(resources, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, localStateFile), state, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	stateless := testNetwork("networks/a", "a", "first")
+	stateless.InstanceState = nil
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	updated, summary, err := UpdateHclFiles(context.Background(), logger, OS, LocalBackend{}, []terraformutils.Resource{
+		stateless,
+		testNetwork("networks/b", "b", "second"),
+	}, testProvider{}, dir, "networks", true, "hcl", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updated) != 1 || len(summary.Removed) != 0 || summary.Unchanged != 1 {
+		t.Errorf("unexpected update %+v of %d resources", summary, len(updated))
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "resources.tf")); !strings.Contains(string(data), `"tfer--a"`) {
+		t.Errorf("expected the block without state to be kept:\n%s", data)
+	}
+	if !strings.Contains(logs.String(), "skipping google_compute_network.tfer--a without state") {
+		t.Errorf("expected the skipped resource to be logged, got %q", logs.String())
+	}
+}
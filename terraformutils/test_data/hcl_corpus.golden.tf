@@ -0,0 +1,101 @@
+resource "aws_glue_job" "tfer--name-aws_glue_job" {
+  default_arguments = {
+    "--extra-py-files" = "s3://bucket/lib.zip"
+    "--job-language"   = "python"
+  }
+  name = "job"
+
+  command {
+    script_location = "s3://bucket/script.py"
+  }
+}
+
+resource "aws_iam_policy" "tfer--name-aws_iam_policy" {
+  description = "quotes \" backslash \\ tab \t newline \n directive %%{if} unicode é"
+  name        = "policy"
+  path        = data.terraform_remote_state.iam.outputs.path
+  policy      = <<POLICY
+{
+  "Statement": [
+    {
+      "Action": "s3:GetObject",
+      "Effect": "Allow",
+      "Resource": "arn:aws:s3:::$${aws:username}/*"
+    }
+  ],
+  "Version": "2012-10-17"
+}
+POLICY
+  user_data   = <<EOF
+#!/bin/bash
+echo hello
+EOF
+}
+
+resource "google_cloudbuild_trigger" "tfer--name-google_cloudbuild_trigger" {
+  name = "trigger"
+  substitutions = {
+    _REGION              = "europe-west1"
+    "kubernetes.io/name" = "app"
+  }
+  tags = ["a", "b", "c"]
+
+  build {
+    images = ["gcr.io/p/a", "gcr.io/p/b"]
+
+    step {
+      args = ["build", "-t", "gcr.io/p/a", "."]
+      name = "gcr.io/cloud-builders/docker"
+    }
+    step {
+      args = ["push", "gcr.io/p/a"]
+      name = "gcr.io/cloud-builders/docker"
+    }
+    step {
+      args = ["echo", "done"]
+      name = "alpine"
+    }
+  }
+}
+
+resource "google_container_node_pool" "tfer--name-google_container_node_pool" {
+  name           = "pool"
+  node_count     = 12345678901234567
+  node_locations = []
+  taint = [
+    {
+      key   = "dedicated"
+      value = "gpu"
+    },
+  ]
+
+  management {
+    auto_repair = true
+  }
+  setting "a" {
+    value = "1"
+  }
+  setting "b" {
+    value = "2"
+  }
+}
+
+resource "google_storage_bucket_iam_policy" "tfer--name-google_storage_bucket_iam_policy" {
+  policy_data = <<POLICY
+{
+  "version": 1,
+  "bindings": [
+    {
+      "role": "roles/viewer",
+      "condition": {
+        "expression": "a < b && c > d $${x} %%{y}"
+      }
+    }
+  ],
+  "etag": 12345678901234567890
+}
+POLICY
+  script      = <<-EOT
+  echo $${HOME}
+EOT
+}
@@ -0,0 +1,245 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform/providers"
+	"github.com/hashicorp/terraform/terraform"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+const StateV4Version = 4
+
+// DefaultProviderRegistry is the hostname used for provider sources without an explicit hostname
+const DefaultProviderRegistry = "registry.terraform.io"
+
+// StateV4 is the state file format written by Terraform 0.13 and later
+type StateV4 struct {
+	Version          uint64                   `json:"version"`
+	TerraformVersion string                   `json:"terraform_version"`
+	Serial           uint64                   `json:"serial"`
+	Lineage          string                   `json:"lineage"`
+	Outputs          map[string]OutputStateV4 `json:"outputs"`
+	Resources        []ResourceStateV4        `json:"resources"`
+}
+
+type OutputStateV4 struct {
+	Value     interface{}     `json:"value"`
+	Type      json.RawMessage `json:"type"`
+	Sensitive bool            `json:"sensitive,omitempty"`
+}
+
+type ResourceStateV4 struct {
+	Module    string            `json:"module,omitempty"`
+	Mode      string            `json:"mode"`
+	Type      string            `json:"type"`
+	Name      string            `json:"name"`
+	Provider  string            `json:"provider"`
+	Instances []InstanceStateV4 `json:"instances"`
+}
+
+type InstanceStateV4 struct {
+	SchemaVersion       uint64            `json:"schema_version"`
+	Attributes          json.RawMessage   `json:"attributes,omitempty"`
+	AttributesFlat      map[string]string `json:"attributes_flat,omitempty"`
+	SensitiveAttributes []interface{}     `json:"sensitive_attributes"`
+}
+
+// ProviderAddress returns the fully qualified provider address used in v4 state files,
+// e.g. provider["registry.terraform.io/hashicorp/google"]. localName may include an alias (aws.west),
+// providerSources maps provider local names to their required_providers source.
+func ProviderAddress(localName string, providerSources map[string]string) string {
+	alias := ""
+	if i := strings.Index(localName, "."); i != -1 {
+		localName, alias = localName[:i], localName[i+1:]
+	}
+	source, ok := providerSources[localName]
+	if !ok || source == "" {
+		source = "hashicorp/" + localName
+	}
+	if strings.Count(source, "/") == 1 {
+		source = DefaultProviderRegistry + "/" + source
+	}
+	address := fmt.Sprintf("provider[%q]", strings.ToLower(source))
+	if alias != "" {
+		address += "." + alias
+	}
+	return address
+}
+
+// ProviderSources returns the required_providers sources declared by a provider generator
+func ProviderSources(provider ProviderGenerator) map[string]string {
+	sources := map[string]string{}
+	if providerWithSource, ok := provider.(ProviderWithSource); ok {
+		sources[provider.GetName()] = providerWithSource.GetSource()
+	}
+	return sources
+}
+
+// NewTfStateV4 builds a v4 state from resources. Attributes are written as typed JSON when the
+// provider schema knows the resource type and fall back to attributes_flat otherwise, which
+// Terraform upgrades with the provider schema on the next refresh.
+func NewTfStateV4(resources []Resource, schema *providers.GetSchemaResponse, providerSources map[string]string) (*StateV4, error) {
+	state := &StateV4{
+		Version:          StateV4Version,
+		TerraformVersion: terraform.VersionString(), //nolint
+		Serial:           1,
+		Outputs:          map[string]OutputStateV4{},
+		Resources:        []ResourceStateV4{},
+	}
+	for _, r := range resources {
+		for k, v := range r.Outputs {
+			output, err := newOutputStateV4(v)
+			if err != nil {
+				return nil, err
+			}
+			state.Outputs[k] = output
+		}
+	}
+	seen := map[string]struct{}{}
+	for _, r := range resources {
+		if r.InstanceState == nil {
+			continue
+		}
+		if _, exist := seen[r.ImportAddress()]; exist {
+			continue
+		}
+		seen[r.ImportAddress()] = struct{}{}
+		instance, err := newInstanceStateV4(r, schema)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert state of %s: %v", r.ImportAddress(), err)
+		}
+		state.Resources = append(state.Resources, ResourceStateV4{
+			Mode:      "managed",
+			Type:      r.InstanceInfo.Type,
+			Name:      r.ResourceName,
+			Provider:  ProviderAddress(r.providerLocalName(), providerSources),
+			Instances: []InstanceStateV4{instance},
+		})
+	}
+	sort.Slice(state.Resources, func(i, j int) bool {
+		if state.Resources[i].Type != state.Resources[j].Type {
+			return state.Resources[i].Type < state.Resources[j].Type
+		}
+		return state.Resources[i].Name < state.Resources[j].Name
+	})
+	return state, nil
+}
+
+// providerLocalName returns the provider configuration the resource is bound to
+func (r Resource) providerLocalName() string {
+	if provider := r.importProvider(); provider != "" {
+		return provider
+	}
+	return r.Provider
+}
+
+func newInstanceStateV4(r Resource, schema *providers.GetSchemaResponse) (InstanceStateV4, error) {
+	instance := InstanceStateV4{
+		SchemaVersion:       metaSchemaVersion(r.InstanceState.Meta),
+		SensitiveAttributes: []interface{}{},
+	}
+	if schema != nil {
+		if resourceSchema, ok := schema.ResourceTypes[r.InstanceInfo.Type]; ok && resourceSchema.Block != nil {
+			impliedType := resourceSchema.Block.ImpliedType()
+			value, err := r.InstanceState.AttrsAsObjectValue(impliedType)
+			if err != nil {
+				return instance, err
+			}
+			attributes, err := ctyjson.Marshal(value, impliedType)
+			if err != nil {
+				return instance, err
+			}
+			instance.SchemaVersion = uint64(resourceSchema.Version)
+			instance.Attributes = attributes
+			return instance, nil
+		}
+	}
+	instance.AttributesFlat = r.InstanceState.Attributes
+	if instance.AttributesFlat == nil {
+		instance.AttributesFlat = map[string]string{}
+	}
+	return instance, nil
+}
+
+// metaSchemaVersion reads the schema version stored by the provider shims, it is an int after a
+// refresh but a float64 or string once the resource went through a JSON planfile
+func metaSchemaVersion(meta map[string]interface{}) uint64 {
+	switch v := meta["schema_version"].(type) {
+	case int:
+		return uint64(v)
+	case int64:
+		return uint64(v)
+	case float64:
+		return uint64(v)
+	case string:
+		version, _ := strconv.ParseUint(v, 10, 64)
+		return version
+	}
+	return 0
+}
+
+func newOutputStateV4(output *terraform.OutputState) (OutputStateV4, error) {
+	outputType := output.Type
+	if outputType == "" {
+		outputType = "string"
+	}
+	typeJSON, err := json.Marshal(outputType)
+	if err != nil {
+		return OutputStateV4{}, err
+	}
+	return OutputStateV4{
+		Value:     output.Value,
+		Type:      typeJSON,
+		Sensitive: output.Sensitive,
+	}, nil
+}
+
+// WriteStateV4 writes the state as indented JSON, a lineage is generated when the state has none
+func WriteStateV4(state *StateV4, w io.Writer) error {
+	if state.Lineage == "" {
+		lineage, err := uuid.GenerateUUID()
+		if err != nil {
+			return err
+		}
+		state.Lineage = lineage
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %v", err)
+	}
+	data = append(data, '\n')
+	_, err = w.Write(data)
+	return err
+}
+
+func PrintTfStateV4(resources []Resource, schema *providers.GetSchemaResponse, providerSources map[string]string) ([]byte, error) {
+	state, err := NewTfStateV4(resources, schema, providerSources)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	err = WriteStateV4(state, &buf)
+	return buf.Bytes(), err
+}
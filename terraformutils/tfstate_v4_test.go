@@ -0,0 +1,158 @@
+// Copyright 2026 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform/configs/configschema"
+	"github.com/hashicorp/terraform/providers"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files in test_data")
+
+func testProviderSchema() *providers.GetSchemaResponse {
+	return &providers.GetSchemaResponse{
+		ResourceTypes: map[string]providers.Schema{
+			"google_compute_firewall": {
+				Version: 1,
+				Block: &configschema.Block{
+					Attributes: map[string]*configschema.Attribute{
+						"id":             {Type: cty.String, Computed: true},
+						"name":           {Type: cty.String, Required: true},
+						"priority":       {Type: cty.Number, Optional: true},
+						"enable_logging": {Type: cty.Bool, Optional: true},
+						"source_ranges":  {Type: cty.Set(cty.String), Optional: true},
+						"labels":         {Type: cty.Map(cty.String), Optional: true},
+					},
+					BlockTypes: map[string]*configschema.NestedBlock{
+						"allow": {
+							Nesting: configschema.NestingList,
+							Block: configschema.Block{
+								Attributes: map[string]*configschema.Attribute{
+									"protocol": {Type: cty.String, Required: true},
+									"ports":    {Type: cty.List(cty.String), Optional: true},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func testStateResources() []Resource {
+	firewall := NewResource("resource-id", "resource-name", "google_compute_firewall", "google", map[string]string{
+		"id":                "resource-id",
+		"name":              "resource-name",
+		"priority":          "1000",
+		"enable_logging":    "false",
+		"source_ranges.#":   "1",
+		"source_ranges.0":   "0.0.0.0/0",
+		"labels.%":          "1",
+		"labels.env":        "prod",
+		"allow.#":           "1",
+		"allow.0.protocol":  "tcp",
+		"allow.0.ports.#":   "2",
+		"allow.0.ports.0":   "22",
+		"allow.0.ports.1":   "80",
+		"unknown_to_schema": "dropped",
+	}, []string{}, map[string]interface{}{})
+	firewall.Outputs = map[string]*terraform.OutputState{
+		"google_compute_firewall_tfer--resource-name_id": {Type: "string", Value: "resource-id"},
+	}
+
+	network := NewResource("projects/p/global/networks/default", "default", "google_compute_network", "google", map[string]string{
+		"id":   "projects/p/global/networks/default",
+		"name": "default",
+	}, []string{}, map[string]interface{}{})
+	network.InstanceState.Meta = map[string]interface{}{"schema_version": float64(2)}
+	network.Item = map[string]interface{}{"provider": "google-beta"}
+
+	return []Resource{network, firewall, firewall}
+}
+
+func TestTfStateV4Golden(t *testing.T) {
+	testCases := map[string]struct {
+		schema  *providers.GetSchemaResponse
+		sources map[string]string
+		golden  string
+	}{
+		"with schema": {
+			schema: testProviderSchema(),
+			golden: "state_v4_schema.golden.json",
+		},
+		"without schema": {
+			schema:  nil,
+			sources: map[string]string{"google-beta": "example.com/acme/google-beta"},
+			golden:  "state_v4_flat.golden.json",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			state, err := NewTfStateV4(testStateResources(), tc.schema, tc.sources)
+			if err != nil {
+				t.Fatal(err)
+			}
+			state.Lineage = "00000000-0000-0000-0000-000000000000"
+			state.TerraformVersion = "0.12.31"
+			var buf bytes.Buffer
+			if err := WriteStateV4(state, &buf); err != nil {
+				t.Fatal(err)
+			}
+			goldenPath := filepath.Join("test_data", tc.golden)
+			if *updateGolden {
+				if err := os.WriteFile(goldenPath, buf.Bytes(), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			expected, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(expected, buf.Bytes()) {
+				t.Errorf("state does not match %s, got:\n%s", goldenPath, buf.String())
+			}
+		})
+	}
+}
+
+func TestProviderAddress(t *testing.T) {
+	testCases := map[string]struct {
+		localName string
+		sources   map[string]string
+		expected  string
+	}{
+		"default namespace": {"google", nil, `provider["registry.terraform.io/hashicorp/google"]`},
+		"beta":              {"google-beta", nil, `provider["registry.terraform.io/hashicorp/google-beta"]`},
+		"source":            {"auth0", map[string]string{"auth0": "auth0/auth0"}, `provider["registry.terraform.io/auth0/auth0"]`},
+		"hostname":          {"opal", map[string]string{"opal": "Example.com/Opal/opal"}, `provider["example.com/opal/opal"]`},
+		"alias":             {"aws.west", nil, `provider["registry.terraform.io/hashicorp/aws"].west`},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if actual := ProviderAddress(tc.localName, tc.sources); actual != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, actual)
+			}
+		})
+	}
+}
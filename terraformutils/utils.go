@@ -16,8 +16,8 @@ package terraformutils
 
 import (
 	"bytes"
+	"context"
 	"log"
-	"sync"
 
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils/providerwrapper"
 
@@ -65,36 +65,14 @@ func PrintTfState(resources []Resource) ([]byte, error) {
 	return buf.Bytes(), err
 }
 
-func RefreshResources(resources []*Resource, provider *providerwrapper.ProviderWrapper, slowProcessingResources [][]*Resource) ([]*Resource, error) {
-	refreshedResources := []*Resource{}
-	input := make(chan *Resource, len(resources))
-	var wg sync.WaitGroup
-	poolSize := 15
-	for i := range resources {
-		wg.Add(1)
-		input <- resources[i]
-	}
-	close(input)
-
-	for i := 0; i < poolSize; i++ {
-		go RefreshResourceWorker(input, &wg, provider)
-	}
-
-	spInputs := []chan *Resource{}
-	for i, resourceGroup := range slowProcessingResources {
-		spInputs = append(spInputs, make(chan *Resource, len(resourceGroup)))
-		for j := range resourceGroup {
-			spInputs[i] <- resourceGroup[j]
-		}
-		close(spInputs[i])
+// RefreshResources refreshes resources with a RefreshScheduler and returns the resources which could be read,
+// the error of ctx when it was canceled
+func RefreshResources(ctx context.Context, resources []*Resource, provider ResourceReader, options RefreshOptions) ([]*Resource, error) {
+	NewRefreshScheduler(provider, options).Refresh(ctx, resources)
+	if ctx.Err() != nil {
+		return nil, context.Cause(ctx)
 	}
-
-	for i := 0; i < len(spInputs); i++ {
-		wg.Add(len(slowProcessingResources[i]))
-		go RefreshResourceWorker(spInputs[i], &wg, provider)
-	}
-
-	wg.Wait()
+	refreshedResources := []*Resource{}
 	for _, r := range resources {
 		if r.InstanceState != nil && r.InstanceState.ID != "" {
 			refreshedResources = append(refreshedResources, r)
@@ -102,43 +80,11 @@ func RefreshResources(resources []*Resource, provider *providerwrapper.ProviderW
 			log.Printf("ERROR: Unable to refresh resource %s", r.ResourceName)
 		}
 	}
-
-	for _, resourceGroup := range slowProcessingResources {
-		for i := range resourceGroup {
-			r := resourceGroup[i]
-			if r.InstanceState != nil && r.InstanceState.ID != "" {
-				refreshedResources = append(refreshedResources, r)
-			} else {
-				log.Printf("ERROR: Unable to refresh resource %s", r.ResourceName)
-			}
-		}
-	}
 	return refreshedResources, nil
 }
 
-func RefreshResourcesByProvider(providersMapping *ProvidersMapping, providerWrapper *providerwrapper.ProviderWrapper) error {
-	allResources := providersMapping.ShuffleResources()
-	slowProcessingResources := make(map[ProviderGenerator][]*Resource)
-	regularResources := []*Resource{}
-	for i := range allResources {
-		resource := allResources[i]
-		if resource.SlowQueryRequired {
-			provider := providersMapping.MatchProvider(resource)
-			if slowProcessingResources[provider] == nil {
-				slowProcessingResources[provider] = []*Resource{}
-			}
-			slowProcessingResources[provider] = append(slowProcessingResources[provider], resource)
-		} else {
-			regularResources = append(regularResources, resource)
-		}
-	}
-
-	var spResourcesList [][]*Resource
-	for p := range slowProcessingResources {
-		spResourcesList = append(spResourcesList, slowProcessingResources[p])
-	}
-
-	refreshedResources, err := RefreshResources(regularResources, providerWrapper, spResourcesList)
+func RefreshResourcesByProvider(ctx context.Context, providersMapping *ProvidersMapping, providerWrapper *providerwrapper.ProviderWrapper, options RefreshOptions) error {
+	refreshedResources, err := RefreshResources(ctx, providersMapping.ShuffleResources(), providerWrapper, options)
 	if err != nil {
 		return err
 	}
@@ -147,14 +93,6 @@ func RefreshResourcesByProvider(providersMapping *ProvidersMapping, providerWrap
 	return nil
 }
 
-func RefreshResourceWorker(input chan *Resource, wg *sync.WaitGroup, provider *providerwrapper.ProviderWrapper) {
-	for r := range input {
-		log.Println("Refreshing state...", r.InstanceInfo.Id)
-		r.Refresh(provider)
-		wg.Done()
-	}
-}
-
 func IgnoreKeys(resourcesTypes []string, p *providerwrapper.ProviderWrapper) map[string][]string {
 	readOnlyAttributes, err := p.GetReadOnlyAttributes(resourcesTypes)
 	if err != nil {
@@ -15,13 +15,16 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 	"os/exec"
 	"sort"
 
 	"github.com/GoogleCloudPlatform/terraformer/cmd"
+	"github.com/GoogleCloudPlatform/terraformer/importer"
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/terraformoutput"
 
 	gcp_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/gcp"
 )
@@ -57,39 +60,31 @@ func main() {
 		services = append(services, service)
 	}
 	sort.Strings(services)
+	options := importer.DefaultOptions()
+	options.Resources = services
+	options.PathOutput = cmd.DefaultPathOutput
+	options.Zone = "europe-west1-a"
 	provider = &gcp_terraforming.GCPProvider{
 		Provider: terraformutils.Provider{},
 	}
-	err := cmd.Import(provider, cmd.ImportOptions{
-		Resources:   services,
-		PathPattern: cmd.DefaultPathPattern,
-		PathOutput:  cmd.DefaultPathOutput,
-		State:       "local",
-		Zone:        "europe-west1-a",
-		Connect:     true,
-	}, []string{zone})
+	_, err := importer.Import(context.Background(), importer.Config{
+		Provider: provider,
+		Args:     []string{zone},
+		Options:  options,
+		Output:   terraformoutput.OS,
+	})
 	if err != nil {
 		log.Println(err)
 		os.Exit(1)
 	}
-	rootPath, _ := os.Getwd()
 	for _, serviceName := range services {
-		currentPath := cmd.Path(cmd.DefaultPathPattern, provider.GetName(), serviceName, cmd.DefaultPathOutput)
-		if err := os.Chdir(currentPath); err != nil {
+		terraform := exec.Command("sh", "-c", command)
+		terraform.Dir = cmd.Path(options.PathPattern, provider.GetName(), serviceName, options.PathOutput)
+		terraform.Stdout = os.Stdout
+		terraform.Stderr = os.Stderr
+		if err := terraform.Run(); err != nil {
 			log.Println(err)
 			os.Exit(1)
 		}
-		cmd := exec.Command("sh", "-c", command)
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-		err = cmd.Run()
-		if err != nil {
-			log.Println(err)
-			os.Exit(1)
-		}
-		err := os.Chdir(rootPath)
-		if err != nil {
-			log.Println(err)
-		}
 	}
 }